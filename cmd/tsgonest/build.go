@@ -305,6 +305,7 @@ func runBuildWithIncr(args []string, oldIncrProgram *shimincremental.Program, ou
 	var controllerRegistry *metadata.TypeRegistry
 	var controllerWarnings []analyzer.Warning
 	var rewriteCtx *rewrite.RewriteContext
+	var settings codegen.Settings
 
 	// Only do pre-emit analysis if no errors (type checker data may be unreliable)
 	if !hasPreEmitErrors && (needCompanions || needControllers) {
@@ -319,11 +320,26 @@ func runBuildWithIncr(args []string, oldIncrProgram *shimincremental.Program, ou
 		if opts.ExactOptionalPropertyTypes == core.TSTrue {
 			sharedWalker.SetExactOptionalPropertyTypes(true)
 		}
+		sharedWalker.SetJSONNaming(cfg.Transforms.JSONNaming)
 		timing.Checker = time.Since(checkerStart)
 
 		// Build source→output map (needed before emit for companion path computation)
 		sourceToOutput := buildSourceToOutputMapFromConfig(program, opts.RootDir, opts.OutDir)
 
+		// Schema names: collisions anywhere in the program are resolved again
+		// across the emitted types, so unrelated declarations don't rename them.
+		schemaNamer := analyzer.NewSchemaNamer(program.GetSourceFiles(), cfg.SchemaNames.Strategy, analyzer.ProjectRootDir(program))
+		schemaNamer.SetGenericNaming(cfg.SchemaNames.Generics, cfg.SchemaNames.GenericArgSeparator)
+		if schemaNamer.Ambiguous() {
+			restricted, err := restrictSchemaNames(program, cfg, sourceToOutput, sharedChecker, schemaNamer, opts.ExactOptionalPropertyTypes == core.TSTrue, syntaxErrorFiles)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				return 1
+			}
+			schemaNamer = restricted
+		}
+		sharedWalker.SetSchemaNamer(schemaNamer)
+
 		// ── Step 1: Analyze controllers to discover needed types ─────────
 		// No blanket pre-registration pass — the walker discovers and registers
 		// sub-field type aliases on-the-fly via Type_alias recovery (depth > 1).
@@ -340,16 +356,7 @@ func runBuildWithIncr(args []string, oldIncrProgram *shimincremental.Program, ou
 		timing.Controllers = time.Since(controllerStart)
 
 		// ── Step 2: Extract marker calls to discover explicitly used types ─
-		markerCalls := make(map[string][]rewrite.MarkerCall)
-		for _, sf := range program.GetSourceFiles() {
-			if sf.IsDeclarationFile {
-				continue
-			}
-			calls := rewrite.ExtractMarkerCalls(sf, sharedChecker)
-			if len(calls) > 0 {
-				markerCalls[sf.FileName()] = calls
-			}
-		}
+		markerCalls := extractMarkerCalls(program, sharedChecker, sharedWalker.SchemaNamer())
 
 		// ── Step 3: Collect the set of type names that actually need companions ─
 		// Only types referenced by controllers, marker calls, or transforms.include get companions.
@@ -364,10 +371,15 @@ func runBuildWithIncr(args []string, oldIncrProgram *shimincremental.Program, ou
 		// Collect query/param DTO type names that need coercion
 		coercionTypes := collectCoercionTypes(controllers)
 
+		// Serialization views of route return types (Groups<G>)
+		viewTypes := collectViewTypes(controllers)
+
 		// ── Step 4: Generate companions only for needed types ────────────
 		companionStart := time.Now()
+		settings = codegenSettings(cfg, configDir, sourceToOutput)
 		if needCompanions && (neededTypes == nil || len(neededTypes) > 0) {
-			companions, typesByFile, compErr := generateCompanionsInMemory(program, cfg, sourceToOutput, sharedChecker, sharedWalker, syntaxErrorFiles, modFmt, neededTypes, coercionTypes)
+			onDemandMarkers := rewrite.CollectOnDemandMarkers(markerCalls)
+			companions, typesByFile, body, compErr := generateCompanionsInMemory(program, cfg, sourceToOutput, sharedChecker, sharedWalker, syntaxErrorFiles, modFmt, neededTypes, coercionTypes, onDemandMarkers, viewTypes, settings)
 			if compErr != nil {
 				fmt.Fprintf(os.Stderr, "error generating companions: %v\n", compErr)
 				return 1
@@ -386,6 +398,25 @@ func runBuildWithIncr(args []string, oldIncrProgram *shimincremental.Program, ou
 				SourceToOutput: sourceToOutput,
 				OutputToSource: rewrite.BuildOutputToSourceMap(sourceToOutput),
 			}
+
+			// transforms.validationError: controllers send validation errors through TsgonestValidationFilter
+			if ve := cfg.Transforms.ValidationError; ve != nil {
+				rewriteCtx.ValidationError = &rewrite.ValidationErrorOptions{
+					Status: ve.Status,
+					Format: ve.Format,
+					Type:   ve.Type,
+					Title:  ve.Title,
+				}
+			}
+
+			// transforms.hydrate: @Body() classes are constructed with hydrate<Type>()
+			if cfg.Transforms.Hydrate {
+				rewriteCtx.HydrateTypes = body.hydrate
+			}
+			// Types with async custom validators are awaited through assertAsync<Type>()
+			rewriteCtx.AsyncTypes = body.async
+			// transforms.readOnly / onDeprecated / JSON names: @Body() types go through assertRequest<Type>()
+			rewriteCtx.RequestTypes = body.request
 		}
 		timing.Companions = time.Since(companionStart)
 
@@ -497,7 +528,7 @@ func runBuildWithIncr(args []string, oldIncrProgram *shimincremental.Program, ou
 		if !filepath.IsAbs(helpersRoot) {
 			helpersRoot = filepath.Join(cwd, helpersRoot)
 		}
-		allCompanions = append(allCompanions, codegen.GenerateHelpersFile(helpersRoot, modFmt)...)
+		allCompanions = append(allCompanions, codegen.GenerateHelpersFile(helpersRoot, codegen.CompanionOptions{ModuleFormat: modFmt, Settings: settings})...)
 		helpersDir := helpersRoot
 		// Fix relative import paths in each companion: "./_tsgonest_helpers.js" → correct relative path
 		for i := range allCompanions {
@@ -584,7 +615,11 @@ func runBuildWithIncr(args []string, oldIncrProgram *shimincremental.Program, ou
 	// Generate OpenAPI document (using pre-analyzed controllers)
 	openapiStart := time.Now()
 	if cfg != nil && cfg.OpenAPI.Output != "" && len(controllers) > 0 {
-		openapiErr := generateOpenAPIFromControllers(controllers, controllerRegistry, cfg, configDir)
+		var companionMap map[string]string
+		if rewriteCtx != nil {
+			companionMap = rewriteCtx.CompanionMap
+		}
+		openapiErr := generateOpenAPIFromControllers(controllers, controllerRegistry, cfg, configDir, companionMap)
 		if openapiErr != nil {
 			fmt.Fprintf(os.Stderr, "error generating OpenAPI: %v\n", openapiErr)
 			return 1
@@ -726,7 +761,8 @@ func smartCleanDir(outDir string, tsbuildInfoPath string) error {
 
 // generateOpenAPIFromControllers generates an OpenAPI 3.1 document from pre-analyzed controllers.
 // This avoids creating a duplicate type checker and re-analyzing controllers.
-func generateOpenAPIFromControllers(controllers []analyzer.ControllerInfo, registry *metadata.TypeRegistry, cfg *config.Config, configDir string) error {
+// companionMap maps the types with companions to their companion files.
+func generateOpenAPIFromControllers(controllers []analyzer.ControllerInfo, registry *metadata.TypeRegistry, cfg *config.Config, configDir string, companionMap map[string]string) error {
 	// Generate OpenAPI document with versioning and prefix options
 	gen := openapi.NewGenerator(registry)
 
@@ -741,6 +777,32 @@ func generateOpenAPIFromControllers(controllers []analyzer.ControllerInfo, regis
 			genOpts.VersionPrefix = cfg.NestJS.Versioning.Prefix
 		}
 	}
+	// transforms.validationError: document the response on every route the
+	// controller rewrite validates, i.e. with the same companion check
+	if ve := cfg.Transforms.ValidationError; ve != nil && cfg.Transforms.Validation {
+		if genOpts == nil {
+			genOpts = &openapi.GenerateOptions{}
+		}
+		genOpts.ValidationError = &openapi.ValidationErrorResponse{
+			Status: ve.Status,
+			Format: ve.Format,
+			HasCompanion: func(typeName string) bool {
+				_, ok := companionMap[typeName]
+				return ok
+			},
+		}
+	}
+	// transforms.limits: document the implicit bounds of generated validators
+	if l := cfg.Transforms.Limits; l != nil && cfg.Transforms.Validation {
+		if genOpts == nil {
+			genOpts = &openapi.GenerateOptions{}
+		}
+		genOpts.Limits = &openapi.SchemaLimits{
+			MaxArrayLength:  l.MaxArrayLength,
+			MaxStringLength: l.MaxStringLength,
+			MaxProperties:   l.MaxProperties,
+		}
+	}
 	doc := gen.GenerateWithOptions(controllers, genOpts)
 
 	// Apply document-level config (title, description, servers, security schemes)
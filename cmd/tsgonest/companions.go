@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -39,6 +41,45 @@ func collectCoercionTypes(controllers []analyzer.ControllerInfo) map[string]bool
 	return types
 }
 
+// collectViewTypes returns the serialization views of each type returned by a
+// route with a view (@view / @Returns({ view })): array routes use the view of
+// their element type. Views are sorted for a stable companion output.
+func collectViewTypes(controllers []analyzer.ControllerInfo) map[string][]string {
+	views := make(map[string][]string)
+	for _, ctrl := range controllers {
+		for _, route := range ctrl.Routes {
+			if route.View == "" {
+				continue
+			}
+			m := &route.ReturnType
+			if m.Kind == metadata.KindArray && m.ElementType != nil {
+				m = m.ElementType
+			}
+			typeName := m.Ref
+			if m.Kind == metadata.KindObject {
+				typeName = m.Name
+			}
+			if typeName == "" {
+				continue
+			}
+			found := false
+			for _, v := range views[typeName] {
+				if v == route.View {
+					found = true
+					break
+				}
+			}
+			if !found {
+				views[typeName] = append(views[typeName], route.View)
+			}
+		}
+	}
+	for _, v := range views {
+		sort.Strings(v)
+	}
+	return views
+}
+
 func collectNeededTypes(controllers []analyzer.ControllerInfo, markerCalls map[string][]rewrite.MarkerCall, excludePatterns []string) map[string]bool {
 	needed := make(map[string]bool)
 
@@ -124,6 +165,129 @@ func collectTypeNamesFromMetadata(m *metadata.Metadata, names map[string]bool) {
 	// by codegen and don't need separate companion files.
 }
 
+// codegenSettings returns the code generation settings of cfg.Transforms.
+// Modules of format predicates and of the deprecation hook are resolved
+// relative to configDir and imported from their emitted .js file when they are
+// part of the program.
+func codegenSettings(cfg *config.Config, configDir string, sourceToOutput map[string]string) codegen.Settings {
+	t := cfg.Transforms
+	settings := codegen.Settings{
+		Formats:       customFormats(cfg, configDir, sourceToOutput),
+		Locales:       t.Locales,
+		DefaultLocale: t.DefaultLocale,
+		MaxErrors:     t.MaxErrors,
+		SensitiveMask: t.SensitiveMask,
+		ReadOnlyMode:  t.ReadOnly,
+	}
+	if ve := t.ValidationError; ve != nil {
+		settings.ValidationErrorStatus = ve.Status
+	}
+	if t.FailFast {
+		settings.MaxErrors = 1
+	}
+	if l := t.Limits; l != nil {
+		settings.Limits = codegen.Limits{
+			MaxDepth:        l.MaxDepth,
+			MaxArrayLength:  l.MaxArrayLength,
+			MaxStringLength: l.MaxStringLength,
+			MaxProperties:   l.MaxProperties,
+		}
+	}
+	if h := t.OnDeprecated; h != nil {
+		settings.DeprecationHook = codegen.DeprecationHook{Module: resolveConfigModule(h.Module, configDir, sourceToOutput), Export: h.Export}
+	}
+	return settings
+}
+
+// customFormats returns the custom formats of transforms.formats, sorted by name.
+func customFormats(cfg *config.Config, configDir string, sourceToOutput map[string]string) []codegen.CustomFormat {
+	builtin := make(map[string]bool)
+	for _, name := range codegen.FormatNames() {
+		builtin[name] = true
+	}
+	var formats []codegen.CustomFormat
+	for name, f := range cfg.Transforms.Formats {
+		if builtin[name] {
+			fmt.Fprintf(os.Stderr, "warning: transforms.formats.%s is ignored: %q is a built-in format\n", name, name)
+			continue
+		}
+		format := codegen.CustomFormat{Name: name, Pattern: f.Pattern, Flags: f.Flags, Export: f.Export}
+		if f.Module != "" {
+			format.Module = resolveConfigModule(f.Module, configDir, sourceToOutput)
+		}
+		formats = append(formats, format)
+	}
+	sort.Slice(formats, func(i, j int) bool {
+		return formats[i].Name < formats[j].Name
+	})
+	return formats
+}
+
+// resolveConfigModule resolves a module path of the config file, relative to
+// configDir, to the emitted JS file when it is a compiled source file.
+func resolveConfigModule(module string, configDir string, sourceToOutput map[string]string) string {
+	if !filepath.IsAbs(module) {
+		module = filepath.Join(configDir, module)
+	}
+	module = filepath.ToSlash(module)
+	if out, ok := sourceToOutput[module]; ok {
+		module = strings.TrimSuffix(out, ".ts") + ".js"
+	}
+	return module
+}
+
+// companionSchemaName returns the disambiguated schema name for a declaration,
+// falling back to its declared name. Exclude patterns still match the declared name.
+func companionSchemaName(walker *analyzer.TypeWalker, decl *ast.Node, declaredName string) string {
+	if name := walker.SchemaNamer().DeclarationName(decl); name != "" {
+		return name
+	}
+	return declaredName
+}
+
+// extractMarkerCalls collects the marker calls of the program's source files, by file.
+func extractMarkerCalls(program *shimcompiler.Program, checker *shimchecker.Checker, namer *analyzer.SchemaNamer) map[string][]rewrite.MarkerCall {
+	markerCalls := make(map[string][]rewrite.MarkerCall)
+	for _, sf := range program.GetSourceFiles() {
+		if sf.IsDeclarationFile {
+			continue
+		}
+		calls := rewrite.ExtractMarkerCallsWithNamer(sf, checker, namer)
+		if len(calls) > 0 {
+			markerCalls[sf.FileName()] = calls
+		}
+	}
+	return markerCalls
+}
+
+// restrictSchemaNames resolves schema name collisions across the emitted types
+// only. It walks the controllers, marker calls and companion types with a
+// throwaway walker to find the declarations named by the build, then restricts
+// namer to them (see SchemaNamer.Restrict).
+func restrictSchemaNames(program *shimcompiler.Program, cfg *config.Config, sourceToOutput map[string]string, checker *shimchecker.Checker, namer *analyzer.SchemaNamer, exactOptional bool, skipFiles map[string]bool) (*analyzer.SchemaNamer, error) {
+	walker := analyzer.NewTypeWalker(checker)
+	walker.SetExactOptionalPropertyTypes(exactOptional)
+	walker.SetSchemaNamer(namer)
+	walker.SetJSONNaming(cfg.Transforms.JSONNaming)
+
+	var controllers []analyzer.ControllerInfo
+	if len(cfg.Controllers.Include) > 0 {
+		ca := analyzer.NewControllerAnalyzerWithWalker(program, checker, walker)
+		controllers = ca.AnalyzeProgram(cfg.Controllers.Include, cfg.Controllers.Exclude)
+	}
+	markerCalls := extractMarkerCalls(program, checker, namer)
+	if cfg.Transforms.Validation || cfg.Transforms.Serialization {
+		var neededTypes map[string]bool
+		if len(cfg.Transforms.Include) == 0 {
+			neededTypes = collectNeededTypes(controllers, markerCalls, cfg.Transforms.Exclude)
+		}
+		if neededTypes == nil || len(neededTypes) > 0 {
+			walkCompanionTypes(program, cfg, sourceToOutput, checker, walker, skipFiles, neededTypes, rewrite.CollectOnDemandMarkers(markerCalls))
+		}
+	}
+	return namer.Restrict()
+}
+
 // generateCompanionsInMemory generates companion file content in memory without writing to disk.
 // Returns both the companion files and a map of source file → type names found in that file.
 // Only generates companions for types in the neededTypes set.
@@ -132,13 +296,159 @@ type fileTypeInfo struct {
 	sourceName string
 	outputBase string
 	types      map[string]*metadata.Metadata
+	// classes are the walked class declarations of types.
+	classes map[string]bool
 }
 
-func generateCompanionsInMemory(program *shimcompiler.Program, cfg *config.Config, sourceToOutput map[string]string, checker *shimchecker.Checker, walker *analyzer.TypeWalker, skipFiles map[string]bool, moduleFormat string, neededTypes map[string]bool, coercionTypes map[string]bool) ([]codegen.CompanionFile, map[string][]string, error) {
+// bodyTypes holds the type sets derived during companion generation that
+// change how controllers check request bodies (see rewrite.RewriteContext).
+type bodyTypes struct {
+	hydrate map[string]bool // transforms.hydrate: classes built with hydrateRequest<Type>()
+	async   map[string]bool // async custom validators: assertAsyncRequest<Type>(), assertAsync<Type>()
+	request map[string]bool // read-only, deprecated or JSON-named properties: assertRequest<Type>()
+}
+
+// companionMarkers returns markers with the functions of the derived body
+// types added. markers itself is left unchanged.
+func companionMarkers(markers map[string]map[string]bool, body bodyTypes) map[string]map[string]bool {
+	out := make(map[string]map[string]bool, len(markers))
+	for name, fns := range markers {
+		out[name] = make(map[string]bool, len(fns))
+		for fn := range fns {
+			out[name][fn] = true
+		}
+	}
+	add := func(types map[string]bool, fn string) {
+		for name := range types {
+			if out[name] == nil {
+				out[name] = make(map[string]bool)
+			}
+			out[name][fn] = true
+		}
+	}
+	// Bodies are checked by the request functions, built on assertRequest
+	// (see rewrite.RewriteContext); whole-object parameters by assertAsync.
+	add(body.hydrate, "hydrate")
+	add(body.hydrate, "hydrateRequest")
+	add(body.async, "assertAsync")
+	add(body.async, "assertAsyncRequest")
+	add(body.request, "assertRequest")
+	return out
+}
+
+func generateCompanionsInMemory(program *shimcompiler.Program, cfg *config.Config, sourceToOutput map[string]string, checker *shimchecker.Checker, walker *analyzer.TypeWalker, skipFiles map[string]bool, moduleFormat string, neededTypes map[string]bool, coercionTypes map[string]bool, markers map[string]map[string]bool, views map[string][]string, settings codegen.Settings) ([]codegen.CompanionFile, map[string][]string, bodyTypes, error) {
 	typesByFile := make(map[string][]string)
+	body := bodyTypes{
+		hydrate: make(map[string]bool),
+		async:   make(map[string]bool),
+		request: make(map[string]bool),
+	}
 
 	// ── Phase 1: Walk types (sequential — uses shared checker) ──────────
 	walkStart := time.Now()
+	for _, sf := range program.GetSourceFiles() {
+		if skipFiles[sf.FileName()] && !sf.IsDeclarationFile {
+			fmt.Fprintf(os.Stderr, "warning: skipping companion generation for %s (syntax errors)\n", filepath.Base(sf.FileName()))
+		}
+	}
+	fileInfos := walkCompanionTypes(program, cfg, sourceToOutput, checker, walker, skipFiles, neededTypes, markers)
+	for _, fi := range fileInfos {
+		// Track type names per source file for companion map building
+		for name := range fi.types {
+			typesByFile[fi.sourceName] = append(typesByFile[fi.sourceName], name)
+			if cfg.Transforms.Hydrate && fi.classes[name] {
+				body.hydrate[name] = true
+			}
+		}
+	}
+	walkDuration := time.Since(walkStart)
+
+	// Enable string→number/boolean coercion on registry entries for query/param DTOs.
+	// This must happen after Phase 1 (types walked into registry) and before Phase 2 (codegen).
+	if len(coercionTypes) > 0 {
+		registry := walker.Registry()
+		for typeName := range coercionTypes {
+			if m, ok := registry.Types[typeName]; ok {
+				analyzer.AutoEnableCoercion(m)
+			}
+		}
+	}
+
+	// ── Phase 2: Generate companion code (parallel) ──────────────────────
+	codegenStart := time.Now()
+	registry := walker.Registry()
+
+	// Types with async custom validators get assertAsync, awaited by the
+	// controller rewrite before the handler runs.
+	for _, fi := range fileInfos {
+		for name, m := range fi.types {
+			if codegen.HasAsyncValidators(m, registry) {
+				body.async[name] = true
+			}
+		}
+	}
+
+	// transforms.readOnly / onDeprecated / JSON names: types with read-only,
+	// deprecated or renamed properties get assertRequest, which handles them in
+	// controller request bodies.
+	for _, fi := range fileInfos {
+		for name, m := range fi.types {
+			if codegen.NeedsAssertRequest(m, registry, &settings) {
+				body.request[name] = true
+			}
+		}
+	}
+
+	companionOpts := codegen.CompanionOptions{
+		ModuleFormat:      moduleFormat,
+		StandardSchema:    cfg.Transforms.StandardSchema,
+		ResponseTypeCheck: cfg.Transforms.ResponseTypeCheck,
+		Markers:           companionMarkers(markers, body),
+		Views:             views,
+		SourceToOutput:    sourceToOutput,
+		Settings:          settings,
+	}
+
+	type codegenResult struct {
+		companions []codegen.CompanionFile
+	}
+	results := make([]codegenResult, len(fileInfos))
+
+	var wg sync.WaitGroup
+	// Use a semaphore to limit concurrency to available CPUs
+	sem := make(chan struct{}, runtime.NumCPU())
+
+	for i, fi := range fileInfos {
+		wg.Add(1)
+		go func(idx int, info fileTypeInfo) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[idx] = codegenResult{
+				companions: codegen.GenerateCompanionFiles(info.outputBase, info.types, registry, companionOpts),
+			}
+		}(i, fi)
+	}
+	wg.Wait()
+
+	// Collect results
+	var allCompanions []codegen.CompanionFile
+	for _, r := range results {
+		allCompanions = append(allCompanions, r.companions...)
+	}
+	codegenDuration := time.Since(codegenStart)
+
+	if os.Getenv("TSGONEST_DEBUG_COMPANIONS") == "1" {
+		fmt.Fprintf(os.Stderr, "companion stats: files=%d companions=%d walk=%s codegen=%s\n",
+			len(fileInfos), len(allCompanions), walkDuration, codegenDuration)
+	}
+
+	return allCompanions, typesByFile, body, nil
+}
+
+// walkCompanionTypes walks the types that get companions into the walker's
+// registry, per source file. Files with syntax errors are skipped.
+func walkCompanionTypes(program *shimcompiler.Program, cfg *config.Config, sourceToOutput map[string]string, checker *shimchecker.Checker, walker *analyzer.TypeWalker, skipFiles map[string]bool, neededTypes map[string]bool, markers map[string]map[string]bool) []fileTypeInfo {
 	var fileInfos []fileTypeInfo
 
 	for _, sf := range program.GetSourceFiles() {
@@ -146,7 +456,6 @@ func generateCompanionsInMemory(program *shimcompiler.Program, cfg *config.Confi
 			continue
 		}
 		if skipFiles[sf.FileName()] {
-			fmt.Fprintf(os.Stderr, "warning: skipping companion generation for %s (syntax errors)\n", filepath.Base(sf.FileName()))
 			continue
 		}
 		if len(cfg.Transforms.Include) > 0 {
@@ -160,6 +469,7 @@ func generateCompanionsInMemory(program *shimcompiler.Program, cfg *config.Confi
 		}
 
 		types := make(map[string]*metadata.Metadata)
+		classes := make(map[string]bool)
 		for _, stmt := range sf.Statements.Nodes {
 			switch stmt.Kind {
 			case ast.KindTypeAliasDeclaration:
@@ -169,6 +479,7 @@ func generateCompanionsInMemory(program *shimcompiler.Program, cfg *config.Confi
 				if len(cfg.Transforms.Exclude) > 0 && analyzer.MatchesTypeNamePattern(name, cfg.Transforms.Exclude) {
 					continue
 				}
+				name = companionSchemaName(walker, stmt, name)
 				// Only walk types referenced by controllers or marker calls.
 				// Sub-field type aliases (e.g., Address inside UserDto) are
 				// discovered and registered on-the-fly by the walker's
@@ -176,6 +487,7 @@ func generateCompanionsInMemory(program *shimcompiler.Program, cfg *config.Confi
 				if neededTypes != nil && !neededTypes[name] {
 					continue
 				}
+				walker.SchemaNamer().Use(stmt)
 				line := shimscanner.GetECMALineOfPosition(sf, decl.Name().Pos())
 				walker.SetRootContext(fmt.Sprintf("%s (%s:%d)", name, sf.FileName(), line+1))
 				resolvedType := shimchecker.Checker_getTypeFromTypeNode(checker, decl.Type)
@@ -189,9 +501,11 @@ func generateCompanionsInMemory(program *shimcompiler.Program, cfg *config.Confi
 				if len(cfg.Transforms.Exclude) > 0 && analyzer.MatchesTypeNamePattern(name, cfg.Transforms.Exclude) {
 					continue
 				}
+				name = companionSchemaName(walker, stmt, name)
 				if neededTypes != nil && !neededTypes[name] {
 					continue
 				}
+				walker.SchemaNamer().Use(stmt)
 				line := shimscanner.GetECMALineOfPosition(sf, decl.Name().Pos())
 				walker.SetRootContext(fmt.Sprintf("%s (%s:%d)", name, sf.FileName(), line+1))
 				sym := checker.GetSymbolAtLocation(decl.Name())
@@ -201,6 +515,36 @@ func generateCompanionsInMemory(program *shimcompiler.Program, cfg *config.Confi
 					types[name] = &m
 				}
 				walker.SetRootContext("")
+			case ast.KindClassDeclaration:
+				decl := stmt.AsClassDeclaration()
+				if decl.Name() == nil {
+					continue
+				}
+				name := decl.Name().Text()
+				// Skip types matching exclude patterns
+				if len(cfg.Transforms.Exclude) > 0 && analyzer.MatchesTypeNamePattern(name, cfg.Transforms.Exclude) {
+					continue
+				}
+				name = companionSchemaName(walker, stmt, name)
+				if neededTypes != nil && !neededTypes[name] {
+					continue
+				}
+				// Class DTOs only get companions when they are hydrated into
+				// instances, explicitly or for every @Body() (transforms.hydrate).
+				if !cfg.Transforms.Hydrate && !markers[name]["hydrate"] {
+					continue
+				}
+				walker.SchemaNamer().Use(stmt)
+				line := shimscanner.GetECMALineOfPosition(sf, decl.Name().Pos())
+				walker.SetRootContext(fmt.Sprintf("%s (%s:%d)", name, sf.FileName(), line+1))
+				sym := checker.GetSymbolAtLocation(decl.Name())
+				if sym != nil {
+					resolvedType := shimchecker.Checker_getDeclaredTypeOfSymbol(checker, sym)
+					m := walker.WalkType(resolvedType)
+					types[name] = &m
+					classes[name] = true
+				}
+				walker.SetRootContext("")
 			}
 		}
 
@@ -208,74 +552,12 @@ func generateCompanionsInMemory(program *shimcompiler.Program, cfg *config.Confi
 			continue
 		}
 
-		// Track type names per source file for companion map building
-		var fileTypeNames []string
-		for name := range types {
-			fileTypeNames = append(fileTypeNames, name)
-		}
-		typesByFile[sf.FileName()] = fileTypeNames
-
 		fileInfos = append(fileInfos, fileTypeInfo{
 			sourceName: sf.FileName(),
 			outputBase: outputBase,
 			types:      types,
+			classes:    classes,
 		})
 	}
-	walkDuration := time.Since(walkStart)
-
-	// Enable string→number/boolean coercion on registry entries for query/param DTOs.
-	// This must happen after Phase 1 (types walked into registry) and before Phase 2 (codegen).
-	if len(coercionTypes) > 0 {
-		registry := walker.Registry()
-		for typeName := range coercionTypes {
-			if m, ok := registry.Types[typeName]; ok {
-				analyzer.AutoEnableCoercion(m)
-			}
-		}
-	}
-
-	// ── Phase 2: Generate companion code (parallel) ──────────────────────
-	codegenStart := time.Now()
-	registry := walker.Registry()
-	companionOpts := codegen.CompanionOptions{
-		ModuleFormat:      moduleFormat,
-		StandardSchema:    cfg.Transforms.StandardSchema,
-		ResponseTypeCheck: cfg.Transforms.ResponseTypeCheck,
-	}
-
-	type codegenResult struct {
-		companions []codegen.CompanionFile
-	}
-	results := make([]codegenResult, len(fileInfos))
-
-	var wg sync.WaitGroup
-	// Use a semaphore to limit concurrency to available CPUs
-	sem := make(chan struct{}, runtime.NumCPU())
-
-	for i, fi := range fileInfos {
-		wg.Add(1)
-		go func(idx int, info fileTypeInfo) {
-			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
-			results[idx] = codegenResult{
-				companions: codegen.GenerateCompanionFiles(info.outputBase, info.types, registry, companionOpts),
-			}
-		}(i, fi)
-	}
-	wg.Wait()
-
-	// Collect results
-	var allCompanions []codegen.CompanionFile
-	for _, r := range results {
-		allCompanions = append(allCompanions, r.companions...)
-	}
-	codegenDuration := time.Since(codegenStart)
-
-	if os.Getenv("TSGONEST_DEBUG_COMPANIONS") == "1" {
-		fmt.Fprintf(os.Stderr, "companion stats: files=%d companions=%d walk=%s codegen=%s\n",
-			len(fileInfos), len(allCompanions), walkDuration, codegenDuration)
-	}
-
-	return allCompanions, typesByFile, nil
+	return fileInfos
 }
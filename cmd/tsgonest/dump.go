@@ -41,6 +41,8 @@ func runDumpMetadata(program *shimcompiler.Program, opts *core.CompilerOptions)
 	if opts.ExactOptionalPropertyTypes == core.TSTrue {
 		walker.SetExactOptionalPropertyTypes(true)
 	}
+	namer := analyzer.NewSchemaNamer(program.GetSourceFiles(), analyzer.SchemaNamingPath, analyzer.ProjectRootDir(program))
+	walker.SetSchemaNamer(namer)
 
 	var files []fileDump
 	for _, sf := range program.GetSourceFiles() {
@@ -54,14 +56,14 @@ func runDumpMetadata(program *shimcompiler.Program, opts *core.CompilerOptions)
 			switch stmt.Kind {
 			case ast.KindTypeAliasDeclaration:
 				decl := stmt.AsTypeAliasDeclaration()
-				name := decl.Name().Text()
+				name := namer.DeclarationName(stmt)
 				resolvedType := shimchecker.Checker_getTypeFromTypeNode(checker, decl.Type)
 				m := walker.WalkNamedType(name, resolvedType)
 				types[name] = m
 
 			case ast.KindInterfaceDeclaration:
 				decl := stmt.AsInterfaceDeclaration()
-				name := decl.Name().Text()
+				name := namer.DeclarationName(stmt)
 				sym := checker.GetSymbolAtLocation(decl.Name())
 				if sym != nil {
 					resolvedType := shimchecker.Checker_getDeclaredTypeOfSymbol(checker, sym)
@@ -71,7 +73,7 @@ func runDumpMetadata(program *shimcompiler.Program, opts *core.CompilerOptions)
 			case ast.KindClassDeclaration:
 				decl := stmt.AsClassDeclaration()
 				if decl.Name() != nil {
-					name := decl.Name().Text()
+					name := namer.DeclarationName(stmt)
 					sym := checker.GetSymbolAtLocation(decl.Name())
 					if sym != nil {
 						resolvedType := shimchecker.Checker_getDeclaredTypeOfSymbol(checker, sym)
@@ -81,7 +83,7 @@ func runDumpMetadata(program *shimcompiler.Program, opts *core.CompilerOptions)
 
 			case ast.KindEnumDeclaration:
 				decl := stmt.AsEnumDeclaration()
-				name := decl.Name().Text()
+				name := namer.DeclarationName(stmt)
 				sym := checker.GetSymbolAtLocation(decl.Name())
 				if sym != nil {
 					resolvedType := shimchecker.Checker_getDeclaredTypeOfSymbol(checker, sym)
@@ -338,6 +338,22 @@ describe("tsgonest generated JS execution", () => {
     expect(result2.success).toBe(true);
   });
 
+  it("union members should not leave their defaults on the value", async () => {
+    const validateFile = resolve(
+      FIXTURES_DIR,
+      "nestjs/dist/user.dto.SearchDto.tsgonest.js"
+    );
+    const mod = await import(validateFile);
+
+    const result = mod.validateSearchDto({ filter: { name: "Alice" } });
+    expect(result.success).toBe(true);
+    expect(result.data).toEqual({ filter: { name: "Alice" } });
+    expect(mod.assertSearchDto({ filter: { name: "Alice" } })).toEqual({ filter: { name: "Alice" } });
+
+    // The default of the matched member is kept
+    expect(mod.assertSearchDto({ filter: { cursor: 3 } })).toEqual({ filter: { cursor: 3, limit: 20 } });
+  });
+
   it("optional property validation should reject wrong types", async () => {
     const validateFile = resolve(
       FIXTURES_DIR,
@@ -92,6 +92,26 @@ func (w *TypeWalker) extractBrandedConstraints(rawPhantomTypes []*shimchecker.Ty
 							found = true
 						}
 					}
+				} else if constraintKey == "validateAsync" {
+					// Same as validate, for ValidateAsync<typeof fn>.
+					if phantomIdx < len(rawPhantomTypes) {
+						if fnName, module, ok := w.resolvePhantomFunction(rawPhantomTypes[phantomIdx], name); ok {
+							c.ValidateAsyncFn = &fnName
+							if module != "" {
+								c.ValidateAsyncModule = &module
+							}
+							found = true
+						}
+					}
+				} else if constraintKey == "transform" {
+					// Transform<typeof fn>: each tag is its own phantom member, so
+					// chained transforms are appended in declaration order.
+					if phantomIdx < len(rawPhantomTypes) {
+						if fnName, module, ok := w.resolvePhantomFunction(rawPhantomTypes[phantomIdx], name); ok {
+							c.TransformFns = append(c.TransformFns, metadata.FunctionRef{Name: fnName, Module: module})
+							found = true
+						}
+					}
 				} else if extractConstraintValue(&c, constraintKey, &prop.Type) {
 					found = true
 				}
@@ -130,27 +150,37 @@ func (w *TypeWalker) extractBrandedConstraints(rawPhantomTypes []*shimchecker.Ty
 // the __tsgonest_validate property. propName is the full property name.
 // Extracts the function's symbol name and source file path into c.ValidateFn and c.ValidateModule.
 func (w *TypeWalker) extractValidateFnConstraint(c *metadata.Constraints, rawPhantomType *shimchecker.Type, propName string) bool {
-	// Get the __tsgonest_validate property from the raw phantom type
-	validatePropSym := shimchecker.Checker_getPropertyOfType(w.checker, rawPhantomType, propName)
-	if validatePropSym == nil {
+	fnName, sourceFilePath, ok := w.resolvePhantomFunction(rawPhantomType, propName)
+	if !ok {
 		return false
 	}
+	c.ValidateFn = &fnName
+	if sourceFilePath != "" {
+		c.ValidateModule = &sourceFilePath
+	}
+	return true
+}
+
+// resolvePhantomFunction returns the name and source file path of the function
+// referenced by a phantom property typed `typeof fn`. The path is empty when the
+// function has no value declaration.
+func (w *TypeWalker) resolvePhantomFunction(rawPhantomType *shimchecker.Type, propName string) (string, string, bool) {
+	// Get the phantom property from the raw phantom type
+	propSym := shimchecker.Checker_getPropertyOfType(w.checker, rawPhantomType, propName)
+	if propSym == nil {
+		return "", "", false
+	}
 
-	// Get the type of the __tsgonest_validate property — this is the function type
-	fnType := shimchecker.Checker_getTypeOfSymbol(w.checker, validatePropSym)
+	// Get the type of the phantom property — this is the function type
+	fnType := shimchecker.Checker_getTypeOfSymbol(w.checker, propSym)
 	if fnType == nil {
-		return false
+		return "", "", false
 	}
 
 	// The function type's symbol gives us the function name
 	fnSym := fnType.Symbol()
-	if fnSym == nil {
-		return false
-	}
-
-	fnName := fnSym.Name
-	if fnName == "" {
-		return false
+	if fnSym == nil || fnSym.Name == "" {
+		return "", "", false
 	}
 
 	// Get the source file of the function's declaration
@@ -161,12 +191,7 @@ func (w *TypeWalker) extractValidateFnConstraint(c *metadata.Constraints, rawPha
 			sourceFilePath = sf.FileName()
 		}
 	}
-
-	c.ValidateFn = &fnName
-	if sourceFilePath != "" {
-		c.ValidateModule = &sourceFilePath
-	}
-	return true
+	return fnSym.Name, sourceFilePath, true
 }
 
 // extractBrandedTagConstraint extracts a constraint from a typia "typia.tag" property.
@@ -159,6 +159,27 @@ func extractConstraintValue(c *metadata.Constraints, key string, typeMeta *metad
 			c.Coerce = &b
 			return true
 		}
+
+	// Redaction of the value in validation errors
+	case "sensitive":
+		if b, ok := literalBool(typeMeta); ok && b {
+			c.Sensitive = &b
+			return true
+		}
+
+	// Name of the property in JSON bodies
+	case "jsonName":
+		if name, ok := literalString(typeMeta); ok && name != "" {
+			c.JsonName = &name
+			return true
+		}
+
+	// Serialization groups: Groups<"admin"> or Groups<"admin" | "owner">
+	case "groups":
+		if groups := literalStrings(typeMeta); len(groups) > 0 {
+			c.Groups = groups
+			return true
+		}
 	}
 	return false
 }
@@ -173,6 +194,23 @@ func literalString(m *metadata.Metadata) (string, bool) {
 	return "", false
 }
 
+// literalStrings extracts the string values of a string literal or of a union
+// of string literals, in declaration order.
+func literalStrings(m *metadata.Metadata) []string {
+	if s, ok := literalString(m); ok {
+		return []string{s}
+	}
+	var values []string
+	if m.Kind == metadata.KindUnion {
+		for i := range m.UnionMembers {
+			if s, ok := literalString(&m.UnionMembers[i]); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
 // literalFloat extracts a float64 value from a literal metadata.
 func literalFloat(m *metadata.Metadata) (float64, bool) {
 	if m.Kind == metadata.KindLiteral {
@@ -267,6 +305,9 @@ func mergeConstraints(dst, src *metadata.Constraints) {
 	if len(src.Transforms) > 0 {
 		dst.Transforms = src.Transforms
 	}
+	if len(src.TransformFns) > 0 {
+		dst.TransformFns = src.TransformFns
+	}
 	if src.MinItems != nil {
 		dst.MinItems = src.MinItems
 	}
@@ -282,12 +323,27 @@ func mergeConstraints(dst, src *metadata.Constraints) {
 	if src.Coerce != nil {
 		dst.Coerce = src.Coerce
 	}
+	if src.Sensitive != nil {
+		dst.Sensitive = src.Sensitive
+	}
+	if src.JsonName != nil {
+		dst.JsonName = src.JsonName
+	}
+	if len(src.Groups) > 0 {
+		dst.Groups = src.Groups
+	}
 	if src.ValidateFn != nil {
 		dst.ValidateFn = src.ValidateFn
 	}
 	if src.ValidateModule != nil {
 		dst.ValidateModule = src.ValidateModule
 	}
+	if src.ValidateAsyncFn != nil {
+		dst.ValidateAsyncFn = src.ValidateAsyncFn
+	}
+	if src.ValidateAsyncModule != nil {
+		dst.ValidateAsyncModule = src.ValidateAsyncModule
+	}
 	if src.ErrorMessage != nil {
 		dst.ErrorMessage = src.ErrorMessage
 	}
@@ -512,6 +568,32 @@ func (w *TypeWalker) extractJSDocConstraints(node *ast.Node) *metadata.Constrain
 			b := true
 			c.Coerce = &b
 			found = true
+
+		// --- Redaction ---
+		case "sensitive":
+			b := true
+			c.Sensitive = &b
+			found = true
+
+		// --- JSON name ---
+		case "jsonname":
+			s := stripQuotes(strings.TrimSpace(comment))
+			if s != "" {
+				c.JsonName = &s
+				found = true
+			}
+
+		// --- Serialization groups ---
+		case "groups":
+			// @groups admin owner (or comma-separated)
+			groups := strings.Fields(strings.ReplaceAll(comment, ",", " "))
+			for i := range groups {
+				groups[i] = stripQuotes(groups[i])
+			}
+			if len(groups) > 0 {
+				c.Groups = groups
+				found = true
+			}
 		}
 	}
 
@@ -654,14 +736,18 @@ func stripQuotes(s string) string {
 // These are separate from validation constraints — they only affect the schema output.
 type propertyAnnotations struct {
 	Description string
+	ReadOnly    bool
 	WriteOnly   bool
 	Example     *string
+	Deprecated  bool
 }
 
 // extractPropertyAnnotations extracts OpenAPI-relevant JSDoc annotations from a property declaration:
 //   - @description <text> — property description in the schema
+//   - @readOnly — marks the property as read-only, like the readonly modifier
 //   - @writeOnly — marks the property as write-only in the schema
 //   - @example <value> — example value in the schema
+//   - @deprecated — marks the property as deprecated
 //
 // Only explicit tags are used — JSDoc body text is NOT extracted.
 func extractPropertyAnnotations(node *ast.Node) propertyAnnotations {
@@ -680,6 +766,8 @@ func extractPropertyAnnotations(node *ast.Node) propertyAnnotations {
 					switch strings.ToLower(tagName) {
 					case "description":
 						ann.Description = strings.TrimSpace(comment)
+					case "readonly":
+						ann.ReadOnly = true
 					case "writeonly":
 						ann.WriteOnly = true
 					case "example":
@@ -687,6 +775,8 @@ func extractPropertyAnnotations(node *ast.Node) propertyAnnotations {
 						if v != "" {
 							ann.Example = &v
 						}
+					case "deprecated":
+						ann.Deprecated = true
 					}
 				}
 			}
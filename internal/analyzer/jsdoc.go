@@ -83,7 +83,7 @@ func extractClassJSDoc(classNode *ast.Node) classJSDocInfo {
 // extractMethodJSDoc extracts OpenAPI-relevant JSDoc tags from a method declaration.
 // Returns summary, description, deprecated, hidden, tags, security, error responses, content type,
 // operationID override, isPublic, paramDescriptions, extensions, and a set of ignored warning kinds.
-func extractMethodJSDoc(node *ast.Node) (summary string, description string, deprecated bool, hidden bool, tags []string, security []SecurityRequirement, errorResponses []ErrorResponse, contentType string, operationIDOverride string, isPublic bool, paramDescriptions map[string]string, extensions map[string]string, ignoreWarnings map[string]bool) {
+func extractMethodJSDoc(node *ast.Node) (summary string, description string, deprecated bool, hidden bool, tags []string, security []SecurityRequirement, errorResponses []ErrorResponse, contentType string, operationIDOverride string, isPublic bool, paramDescriptions map[string]string, extensions map[string]string, ignoreWarnings map[string]bool, view string) {
 	if node == nil {
 		return
 	}
@@ -167,6 +167,8 @@ func extractMethodJSDoc(node *ast.Node) (summary string, description string, dep
 			isPublic = true
 		case "contenttype":
 			contentType = strings.TrimSpace(comment)
+		case "view":
+			view = stripQuotes(strings.TrimSpace(comment))
 		case "extension":
 			// @extension x-key value
 			parts := strings.SplitN(strings.TrimSpace(comment), " ", 2)
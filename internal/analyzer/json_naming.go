@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// JSON naming policies of transforms.jsonNaming.
+const (
+	JSONNamingCamel  = "camelCase"
+	JSONNamingPascal = "PascalCase"
+	JSONNamingSnake  = "snake_case"
+	JSONNamingKebab  = "kebab-case"
+)
+
+// JSONName returns the JSON name of the property name under the naming policy,
+// or name itself when policy is empty or unknown. Words are split at "_", "-"
+// and case changes ("userID2FA" → user, ID2, FA); leading "_" and "$" are kept.
+func JSONName(name string, policy string) string {
+	var sep string
+	switch policy {
+	case JSONNamingSnake:
+		sep = "_"
+	case JSONNamingKebab:
+		sep = "-"
+	case JSONNamingCamel, JSONNamingPascal:
+	default:
+		return name
+	}
+
+	rest := strings.TrimLeft(name, "_$")
+	prefix := name[:len(name)-len(rest)]
+	words := splitWords(rest)
+	if len(words) == 0 {
+		return name
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	for i, word := range words {
+		switch policy {
+		case JSONNamingSnake, JSONNamingKebab:
+			if i > 0 {
+				b.WriteString(sep)
+			}
+			b.WriteString(strings.ToLower(word))
+		case JSONNamingCamel:
+			if i == 0 {
+				b.WriteString(strings.ToLower(word))
+			} else {
+				b.WriteString(capitalize(word))
+			}
+		case JSONNamingPascal:
+			b.WriteString(capitalize(word))
+		}
+	}
+	return b.String()
+}
+
+// splitWords splits an identifier into words at "_", "-" and case changes.
+// Acronyms stay one word, and digits belong to the preceding word.
+func splitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+	for i, r := range runes {
+		if r == '_' || r == '-' {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		prev := runes[i-1]
+		boundary := unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+			(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])))
+		if boundary {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// capitalize upper-cases the first letter of word and lower-cases the rest.
+func capitalize(word string) string {
+	runes := []rune(strings.ToLower(word))
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
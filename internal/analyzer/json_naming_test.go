@@ -0,0 +1,32 @@
+package analyzer
+
+import "testing"
+
+func TestJSONName(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		want   string
+	}{
+		{"firstName", JSONNamingSnake, "first_name"},
+		{"firstName", JSONNamingKebab, "first-name"},
+		{"firstName", JSONNamingPascal, "FirstName"},
+		{"first_name", JSONNamingCamel, "firstName"},
+		{"userID", JSONNamingSnake, "user_id"},
+		{"HTTPServer", JSONNamingSnake, "http_server"},
+		{"userID2FA", JSONNamingSnake, "user_id2_fa"},
+		{"address1Line", JSONNamingSnake, "address1_line"},
+		{"_id", JSONNamingSnake, "_id"},
+		{"$ref", JSONNamingPascal, "$Ref"},
+		{"user_id", JSONNamingCamel, "userId"},
+		{"id", JSONNamingSnake, "id"},
+		{"firstName", "", "firstName"},
+		{"firstName", "SCREAMING_CASE", "firstName"},
+		{"__", JSONNamingSnake, "__"},
+	}
+	for _, tt := range tests {
+		if got := JSONName(tt.name, tt.policy); got != tt.want {
+			t.Errorf("JSONName(%q, %q) = %q, want %q", tt.name, tt.policy, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package analyzer
+
+import (
+	"github.com/microsoft/typescript-go/shim/ast"
+	shimchecker "github.com/microsoft/typescript-go/shim/checker"
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// mappedTypeModules lists the packages exporting the NestJS mapped-type helpers.
+// @nestjs/swagger and @nestjs/graphql re-export them with the same semantics.
+var mappedTypeModules = map[string]bool{
+	"@nestjs/mapped-types": true,
+	"@nestjs/swagger":      true,
+	"@nestjs/graphql":      true,
+}
+
+// mappedTypeBaseProperties derives the inherited property set of a class declared as
+//
+//	class UpdateUserDto extends PartialType(CreateUserDto) {}
+//
+// PartialType, PickType, OmitType and IntersectionType are runtime mixins whose
+// checker types are often opaque (or lose the base class JSDoc), so the property
+// set is rebuilt from the call arguments the same way Partial<T>/Pick<T,K>/Omit<T,K>
+// would be, keeping branded and JSDoc constraints from the base classes.
+// Returns the class declaration and derived properties, or nil when the class
+// does not extend a mapped-type call.
+func (w *TypeWalker) mappedTypeBaseProperties(t *shimchecker.Type) (*ast.Node, []metadata.Property) {
+	sym := t.Symbol()
+	if sym == nil || sym.ValueDeclaration == nil || sym.ValueDeclaration.Kind != ast.KindClassDeclaration {
+		return nil, nil
+	}
+	classDecl := sym.ValueDeclaration
+	heritage := ast.GetClassExtendsHeritageElement(classDecl)
+	if heritage == nil {
+		return nil, nil
+	}
+	expr := heritage.AsExpressionWithTypeArguments().Expression
+	if w.mappedTypeCallName(expr) == "" {
+		return nil, nil
+	}
+	props, ok := w.walkMappedTypeExpr(expr)
+	if !ok {
+		return nil, nil
+	}
+	return classDecl, props
+}
+
+// mappedTypeCallName returns the mapped-type helper name if expr is a call to
+// PartialType/PickType/OmitType/IntersectionType, or "" otherwise.
+// Calls resolved to an import from an unrelated module are ignored.
+func (w *TypeWalker) mappedTypeCallName(expr *ast.Node) string {
+	expr = skipMappedTypeOuterExpressions(expr)
+	if expr.Kind != ast.KindCallExpression {
+		return ""
+	}
+	callee := expr.AsCallExpression().Expression
+	name := getDecoratorExprName(callee)
+	switch name {
+	case "PartialType", "PickType", "OmitType", "IntersectionType":
+	default:
+		return ""
+	}
+
+	var origin *DecoratorOrigin
+	switch callee.Kind {
+	case ast.KindIdentifier:
+		origin = resolveIdentifierOrigin(callee, w.checker)
+	case ast.KindPropertyAccessExpression:
+		origin = resolvePropertyAccessOrigin(callee, w.checker)
+	}
+	if origin != nil && origin.ModuleSpecifier != "" && !mappedTypeModules[origin.ModuleSpecifier] {
+		return ""
+	}
+	if origin != nil && origin.Name != "" {
+		name = origin.Name
+	}
+	return name
+}
+
+// walkMappedTypeExpr resolves a mapped-type argument to its property list.
+// Handles class references and nested helper calls, e.g. PartialType(PickType(A, ['x'])).
+func (w *TypeWalker) walkMappedTypeExpr(expr *ast.Node) ([]metadata.Property, bool) {
+	expr = skipMappedTypeOuterExpressions(expr)
+
+	name := w.mappedTypeCallName(expr)
+	if name == "" {
+		return w.walkMappedTypeClassRef(expr)
+	}
+
+	call := expr.AsCallExpression()
+	if call.Arguments == nil || len(call.Arguments.Nodes) == 0 {
+		return nil, false
+	}
+	args := call.Arguments.Nodes
+
+	switch name {
+	case "PartialType":
+		base, ok := w.walkMappedTypeExpr(args[0])
+		if !ok {
+			return nil, false
+		}
+		result := make([]metadata.Property, len(base))
+		for i, prop := range base {
+			prop.Required = false
+			prop.Type.Optional = true
+			prop.ExactOptional = w.exactOptionalPropertyTypes
+			result[i] = prop
+		}
+		return result, true
+
+	case "PickType", "OmitType":
+		if len(args) < 2 {
+			return nil, false
+		}
+		base, ok := w.walkMappedTypeExpr(args[0])
+		if !ok {
+			return nil, false
+		}
+		keys := extractArrayStringLiterals(skipMappedTypeOuterExpressions(args[1]))
+		if keys == nil {
+			return nil, false
+		}
+		keySet := make(map[string]bool, len(keys))
+		for _, k := range keys {
+			keySet[k] = true
+		}
+		pick := name == "PickType"
+		var result []metadata.Property
+		for _, prop := range base {
+			if keySet[prop.Name] == pick {
+				result = append(result, prop)
+			}
+		}
+		return result, true
+
+	case "IntersectionType":
+		var all []metadata.Property
+		for _, arg := range args {
+			props, ok := w.walkMappedTypeExpr(arg)
+			if !ok {
+				return nil, false
+			}
+			all = append(all, props...)
+		}
+		return mergeProperties(all), true
+	}
+
+	return nil, false
+}
+
+// walkMappedTypeClassRef walks a class reference argument (e.g. CreateUserDto)
+// and returns a copy of its properties.
+func (w *TypeWalker) walkMappedTypeClassRef(expr *ast.Node) ([]metadata.Property, bool) {
+	if expr.Kind != ast.KindIdentifier && expr.Kind != ast.KindPropertyAccessExpression {
+		return nil, false
+	}
+	sym := w.checker.GetSymbolAtLocation(expr)
+	if sym == nil {
+		return nil, false
+	}
+	if sym.Flags&ast.SymbolFlagsAlias != 0 {
+		sym = w.checker.GetAliasedSymbol(sym)
+		if sym == nil {
+			return nil, false
+		}
+	}
+	if sym.Flags&ast.SymbolFlagsClass == 0 {
+		return nil, false
+	}
+	instanceType := shimchecker.Checker_getDeclaredTypeOfSymbol(w.checker, sym)
+	if instanceType == nil {
+		return nil, false
+	}
+	m := w.WalkType(instanceType)
+	props := w.resolveToObjectProperties(&m)
+	if props == nil {
+		return nil, false
+	}
+	return append([]metadata.Property(nil), props...), true
+}
+
+// skipMappedTypeOuterExpressions unwraps parentheses and `as const` / `satisfies`
+// wrappers around mapped-type arguments.
+func skipMappedTypeOuterExpressions(node *ast.Node) *ast.Node {
+	for node != nil {
+		switch node.Kind {
+		case ast.KindParenthesizedExpression:
+			node = node.AsParenthesizedExpression().Expression
+		case ast.KindAsExpression:
+			node = node.AsAsExpression().Expression
+		case ast.KindSatisfiesExpression:
+			node = node.AsSatisfiesExpression().Expression
+		default:
+			return node
+		}
+	}
+	return node
+}
+
+// isOwnClassMember reports whether a property symbol is declared directly in classDecl
+// (including constructor parameter properties), as opposed to being inherited.
+func isOwnClassMember(prop *ast.Symbol, classDecl *ast.Node) bool {
+	for _, decl := range prop.Declarations {
+		for n := decl.Parent; n != nil; n = n.Parent {
+			if n.Kind == ast.KindClassDeclaration || n.Kind == ast.KindClassExpression {
+				if n == classDecl {
+					return true
+				}
+				break
+			}
+		}
+	}
+	return false
+}
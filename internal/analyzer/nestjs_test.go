@@ -289,7 +289,7 @@ func TestControllerAnalyzer_BasicController(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -385,7 +385,7 @@ func TestControllerAnalyzer_HttpCode(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -428,7 +428,7 @@ func TestControllerAnalyzer_QueryParams(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -458,7 +458,7 @@ func TestControllerAnalyzer_NoController(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -498,7 +498,7 @@ func TestControllerAnalyzer_MultipleHTTPMethods(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -550,7 +550,7 @@ func TestControllerAnalyzer_ReturnTypeResolution(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -632,7 +632,7 @@ func TestControllerAnalyzer_JSDocSummaryDescription(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -665,7 +665,7 @@ func TestControllerAnalyzer_JSDocDeprecated(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -696,7 +696,7 @@ func TestControllerAnalyzer_JSDocTags(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -736,7 +736,7 @@ func TestControllerAnalyzer_ClassLevelTag(t *testing.T) {
 		`)
 		defer env.release()
 
-		ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+		ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 		defer caRelease()
 
 		controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -777,7 +777,7 @@ func TestControllerAnalyzer_ClassLevelTag(t *testing.T) {
 		`)
 		defer env.release()
 
-		ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+		ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 		defer caRelease()
 
 		controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -819,7 +819,7 @@ func TestControllerAnalyzer_ClassLevelTag(t *testing.T) {
 		`)
 		defer env.release()
 
-		ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+		ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 		defer caRelease()
 
 		controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -860,7 +860,7 @@ func TestControllerAnalyzer_ClassLevelSecurity(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -895,7 +895,7 @@ func TestControllerAnalyzer_ClassLevelHidden(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -926,7 +926,7 @@ func TestControllerAnalyzer_ClassLevelPublic(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -958,7 +958,7 @@ func TestControllerAnalyzer_JSDocSecurity(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -993,7 +993,7 @@ func TestControllerAnalyzer_JSDocThrows(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1043,7 +1043,7 @@ func TestControllerAnalyzer_VersionDecorator(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1101,7 +1101,7 @@ func TestControllerAnalyzer_CustomDecoratorIn_Param(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1150,7 +1150,7 @@ func TestControllerAnalyzer_CustomDecoratorIn_Query(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1196,7 +1196,7 @@ func TestControllerAnalyzer_CustomDecoratorIn_Body(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1236,7 +1236,7 @@ func TestControllerAnalyzer_CustomDecoratorIn_Headers(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1280,7 +1280,7 @@ func TestControllerAnalyzer_CustomDecorator_NoIn_Skipped(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1328,7 +1328,7 @@ func TestControllerAnalyzer_MixedBuiltinAndCustomDecorators(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1376,7 +1376,7 @@ func TestControllerAnalyzer_HigherOrderDecoratorFactory(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1414,7 +1414,7 @@ func TestControllerAnalyzer_InferReturnType_InlineObject(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1462,7 +1462,7 @@ func TestControllerAnalyzer_InferReturnType_ServiceDelegation(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1498,7 +1498,7 @@ func TestControllerAnalyzer_InferReturnType_Primitive(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1530,7 +1530,7 @@ func TestControllerAnalyzer_InferReturnType_AsyncNoAnnotation(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1568,7 +1568,7 @@ func TestControllerAnalyzer_InferReturnType_NoSlowWarning(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	ca.AnalyzeSourceFile(env.sourceFile)
@@ -1598,7 +1598,7 @@ func TestControllerAnalyzer_InferReturnType_ExplicitAnnotation_StillWorks(t *tes
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1630,7 +1630,7 @@ func TestControllerAnalyzer_ResDecorator_ForcesVoid(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1660,6 +1660,64 @@ func TestControllerAnalyzer_ResDecorator_ForcesVoid(t *testing.T) {
 	}
 }
 
+func TestControllerAnalyzer_ResPassthrough_KeepsReturnType(t *testing.T) {
+	env := setupWalker(t, `
+		function Controller(path: string): ClassDecorator { return (target) => target; }
+		function Get(path?: string): MethodDecorator { return (t, k, d) => d; }
+		function Res(options?: { passthrough?: boolean }): ParameterDecorator { return () => {}; }
+
+		interface UserDto { id: string; name: string; }
+
+		@Controller("users")
+		export class UserController {
+			@Get(":id")
+			getUser(@Res({ passthrough: true }) res: any): UserDto {
+				res.setHeader("X-Trace", "1");
+				return { id: "1", name: "a" };
+			}
+
+			@Get("raw")
+			getRaw(@Res({ passthrough: false }) res: any): UserDto {
+				return { id: "1", name: "a" };
+			}
+		}
+	`)
+	defer env.release()
+
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
+	defer caRelease()
+
+	controllers := ca.AnalyzeSourceFile(env.sourceFile)
+	if len(controllers) != 1 || len(controllers[0].Routes) != 2 {
+		t.Fatalf("expected 1 controller with 2 routes")
+	}
+
+	passthrough := controllers[0].Routes[0]
+	if passthrough.UsesRawResponse {
+		t.Error("expected UsesRawResponse=false for @Res({ passthrough: true })")
+	}
+	if passthrough.ReturnType.Kind == metadata.KindVoid {
+		t.Error("expected inferred return type for @Res({ passthrough: true }), got void")
+	}
+	if len(passthrough.Parameters) != 0 {
+		t.Errorf("expected @Res() parameter to be excluded, got %d parameters", len(passthrough.Parameters))
+	}
+
+	raw := controllers[0].Routes[1]
+	if !raw.UsesRawResponse {
+		t.Error("expected UsesRawResponse=true for @Res({ passthrough: false })")
+	}
+	if raw.ReturnType.Kind != metadata.KindVoid {
+		t.Errorf("expected void return type for @Res({ passthrough: false }), got %q", raw.ReturnType.Kind)
+	}
+
+	for _, w := range ca.Warnings() {
+		if w.Kind == "uses-raw-response" && strings.Contains(w.Message, "getUser") {
+			t.Errorf("unexpected uses-raw-response warning for passthrough route: %s", w.Message)
+		}
+	}
+}
+
 func TestControllerAnalyzer_ResponseDecorator_ForcesVoid(t *testing.T) {
 	env := setupWalker(t, `
 		function Controller(path: string): ClassDecorator { return (target) => target; }
@@ -1674,7 +1732,7 @@ func TestControllerAnalyzer_ResponseDecorator_ForcesVoid(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1691,6 +1749,116 @@ func TestControllerAnalyzer_ResponseDecorator_ForcesVoid(t *testing.T) {
 	}
 }
 
+func TestControllerAnalyzer_StreamableFile_BinaryResponse(t *testing.T) {
+	env := setupWalkerMultiFile(t, map[string]string{
+		"node_modules/@nestjs/common/index.d.ts": `
+			export declare class StreamableFile { constructor(data: Uint8Array); getHeaders(): object; }
+		`,
+		"node_modules/@types/node/index.d.ts": `
+			declare module "stream" {
+				class Readable { read(size?: number): any; pipe(dest: any): any; }
+			}
+			declare module "buffer" {
+				global {
+					interface Buffer extends Uint8Array { toJSON(): { type: "Buffer"; data: number[] }; }
+				}
+			}
+		`,
+		"file.controller.ts": `
+		/// <reference path="./node_modules/@types/node/index.d.ts" />
+		import { StreamableFile } from "@nestjs/common";
+		import { Readable } from "stream";
+		function Controller(path: string): ClassDecorator { return (target) => target; }
+		function Get(path?: string): MethodDecorator { return (t, k, d) => d; }
+		function Header(name: string, value: string): MethodDecorator { return (t, k, d) => d; }
+
+		@Controller("files")
+		export class FileController {
+			@Get("report.csv")
+			@Header("Content-Type", "text/csv")
+			report(): StreamableFile {
+				return null as any;
+			}
+
+			@Get("raw")
+			async raw(): Promise<Buffer> {
+				return null as any;
+			}
+
+			@Get("stream")
+			stream(): Readable {
+				return null as any;
+			}
+		}
+	`,
+	}, "file.controller.ts")
+	defer env.release()
+
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
+	defer caRelease()
+
+	controllers := ca.AnalyzeSourceFile(env.sourceFile)
+	if len(controllers) != 1 || len(controllers[0].Routes) != 3 {
+		t.Fatalf("expected 1 controller with 3 routes")
+	}
+
+	expected := map[string]struct {
+		native      string
+		contentType string
+	}{
+		"report": {"StreamableFile", "text/csv"},
+		"raw":    {"Buffer", "application/octet-stream"},
+		"stream": {"Readable", "application/octet-stream"},
+	}
+	for _, r := range controllers[0].Routes {
+		want, ok := expected[r.MethodName]
+		if !ok {
+			t.Fatalf("unexpected route %q", r.MethodName)
+		}
+		if r.ReturnType.Kind != metadata.KindNative || r.ReturnType.NativeType != want.native {
+			t.Errorf("%s: expected native %s return type, got kind=%q native=%q", r.MethodName, want.native, r.ReturnType.Kind, r.ReturnType.NativeType)
+		}
+		if !r.IsBinaryResponse {
+			t.Errorf("%s: expected IsBinaryResponse=true", r.MethodName)
+		}
+		if r.ResponseContentType != want.contentType {
+			t.Errorf("%s: expected ResponseContentType=%q, got %q", r.MethodName, want.contentType, r.ResponseContentType)
+		}
+	}
+}
+
+func TestControllerAnalyzer_UserTypeNamedReadable_NotBinary(t *testing.T) {
+	env := setupWalker(t, `
+		function Controller(path: string): ClassDecorator { return (target) => target; }
+		function Get(path?: string): MethodDecorator { return (t, k, d) => d; }
+		export interface Readable { title: string; }
+
+		@Controller("books")
+		export class BookController {
+			@Get("readable")
+			readable(): Readable {
+				return null as any;
+			}
+		}
+	`)
+	defer env.release()
+
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
+	defer caRelease()
+
+	controllers := ca.AnalyzeSourceFile(env.sourceFile)
+	if len(controllers) != 1 || len(controllers[0].Routes) != 1 {
+		t.Fatalf("expected 1 controller with 1 route")
+	}
+	r := controllers[0].Routes[0]
+	if r.ReturnType.Kind == metadata.KindNative {
+		t.Errorf("a user type named Readable should not be native, got native=%q", r.ReturnType.NativeType)
+	}
+	if r.IsBinaryResponse {
+		t.Error("a user type named Readable should not be a binary response")
+	}
+}
+
 // --- Phase 4: Custom Decorator Warning Tests ---
 
 func TestControllerAnalyzer_CustomDecorator_NoIn_WithType_Warns(t *testing.T) {
@@ -1713,7 +1881,7 @@ func TestControllerAnalyzer_CustomDecorator_NoIn_WithType_Warns(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	ca.AnalyzeSourceFile(env.sourceFile)
@@ -1746,7 +1914,7 @@ func TestControllerAnalyzer_CustomDecorator_NoIn_NoType_Silent(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	ca.AnalyzeSourceFile(env.sourceFile)
@@ -1784,7 +1952,7 @@ func TestControllerAnalyzer_AliasedBodyDecorator(t *testing.T) {
 	}, "controller.ts")
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1832,7 +2000,7 @@ func TestControllerAnalyzer_AliasedQueryDecorator(t *testing.T) {
 	}, "controller.ts")
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1868,7 +2036,7 @@ func TestControllerAnalyzer_AliasedControllerDecorator(t *testing.T) {
 	}, "controller.ts")
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1909,7 +2077,7 @@ func TestControllerAnalyzer_DestructuredBodyParam(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -1961,7 +2129,7 @@ func TestControllerAnalyzer_QualifiedNameReturnType(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	// Should not panic on QualifiedName in return type
@@ -1998,7 +2166,7 @@ func TestControllerAnalyzer_QualifiedNameParamType(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	// Should not panic on QualifiedName in parameter type annotation
@@ -2041,7 +2209,7 @@ func TestControllerAnalyzer_RuntimeGeneratedController_WarnsAndSkips(t *testing.
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2079,7 +2247,7 @@ func TestControllerAnalyzer_DynamicControllerPath_WarnsAndSkipsController(t *tes
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2117,7 +2285,7 @@ func TestControllerAnalyzer_DynamicRoutePath_WarnsAndSkipsRoute(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2163,7 +2331,7 @@ func TestControllerAnalyzer_ControllerObjectForm_PathAndVersion(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2202,7 +2370,7 @@ func TestControllerAnalyzer_ControllerObjectForm_PathOnly(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2247,7 +2415,7 @@ func TestControllerAnalyzer_ControllerObjectForm_VersionAppliedAsDefault(t *test
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2285,7 +2453,7 @@ func TestControllerAnalyzer_ControllerObjectForm_MethodVersionOverridesControlle
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2324,7 +2492,7 @@ func TestControllerAnalyzer_AllDecorator(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2362,7 +2530,7 @@ func TestControllerAnalyzer_AllDecoratorWithPath(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2395,7 +2563,7 @@ func TestControllerAnalyzer_AllDecoratorDefaultStatusCodes(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2428,7 +2596,7 @@ func TestControllerAnalyzer_ArrayPathOnMethod(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2468,7 +2636,7 @@ func TestControllerAnalyzer_ArrayPathOnController(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2517,7 +2685,7 @@ func TestControllerAnalyzer_HeaderDecorator(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2555,7 +2723,7 @@ func TestControllerAnalyzer_RedirectDecorator(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2590,7 +2758,7 @@ func TestControllerAnalyzer_RedirectDecoratorDefaultStatus(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2625,7 +2793,7 @@ func TestControllerAnalyzer_VersionArrayDecorator(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2673,7 +2841,7 @@ func TestControllerAnalyzer_HeadMethod(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2709,7 +2877,7 @@ func TestControllerAnalyzer_OptionsMethod(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2748,7 +2916,7 @@ func TestControllerAnalyzer_OptionalBodyParam(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2784,7 +2952,7 @@ func TestControllerAnalyzer_NullablePathParam(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2822,7 +2990,7 @@ func TestControllerAnalyzer_MultipleSecuritySchemes(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2859,7 +3027,7 @@ func TestControllerAnalyzer_CompositePathParams(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2901,7 +3069,7 @@ func TestControllerAnalyzer_HeadersParamObjectType(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2932,7 +3100,7 @@ func TestControllerAnalyzer_ControllerNoPath(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -2975,7 +3143,7 @@ func TestControllerAnalyzer_MultipleQueryAndParamDecorators(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -3038,7 +3206,7 @@ func TestControllerAnalyzer_EventStreamDecorator_DiscriminatedUnion(t *testing.T
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -3110,7 +3278,7 @@ func TestControllerAnalyzer_EventStreamDecorator_SingleEvent(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -3160,7 +3328,7 @@ func TestControllerAnalyzer_EventStreamDecorator_GenericString(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -3206,7 +3374,7 @@ func TestControllerAnalyzer_EventStreamDecorator_NoPath(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -3254,7 +3422,7 @@ func TestControllerAnalyzer_EventStreamDecorator_MixedWithRegularRoutes(t *testi
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -3324,7 +3492,7 @@ func TestControllerAnalyzer_Returns_DirectImport(t *testing.T) {
 	}, "controller.ts")
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -3367,7 +3535,7 @@ func TestControllerAnalyzer_Returns_AliasedImport(t *testing.T) {
 	}, "controller.ts")
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -3409,7 +3577,7 @@ func TestControllerAnalyzer_Returns_NamespaceImport(t *testing.T) {
 	}, "controller.ts")
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -3451,7 +3619,7 @@ func TestControllerAnalyzer_Returns_NonTsgonestModule(t *testing.T) {
 	}, "controller.ts")
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -3488,7 +3656,7 @@ func TestControllerAnalyzer_Returns_LocalFunction(t *testing.T) {
 	}, "controller.ts")
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -3531,7 +3699,7 @@ func TestControllerAnalyzer_Returns_TsgonestPackage(t *testing.T) {
 	}, "controller.ts")
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -3549,6 +3717,73 @@ func TestControllerAnalyzer_Returns_TsgonestPackage(t *testing.T) {
 
 // TestControllerAnalyzer_NamespaceImport_Get verifies that @nest.Get() from
 // import * as nest from "./decorators" is correctly resolved as a GET route.
+// TestControllerAnalyzer_View verifies that the serialization view of a route
+// comes from @view or @Returns({ view }), and that invalid names are ignored.
+func TestControllerAnalyzer_View(t *testing.T) {
+	env := setupWalkerMultiFile(t, map[string]string{
+		"tsgonest-runtime.d.ts": `
+			declare module "@tsgonest/runtime" {
+				export function Returns<T>(options?: { contentType?: string; description?: string; status?: number; view?: string }): MethodDecorator;
+			}
+		`,
+		"controller.ts": `
+			function Controller(path: string): ClassDecorator { return (target) => target; }
+			function Get(path?: string): MethodDecorator { return (t, k, d) => d; }
+
+			import { Returns } from "@tsgonest/runtime";
+
+			interface UserDto { id: string; email: string & { readonly __tsgonest_groups?: "admin" }; }
+
+			@Controller("users")
+			export class UserController {
+				/** @view admin */
+				@Get("admin")
+				async forAdmin(): Promise<UserDto> { return null as any; }
+
+				/** @view admin */
+				@Returns<UserDto>({ view: "owner" })
+				@Get("owner")
+				async forOwner(): Promise<UserDto> { return null as any; }
+
+				/** @view not-a-name */
+				@Get("invalid")
+				async invalid(): Promise<UserDto> { return null as any; }
+			}
+		`,
+	}, "controller.ts")
+	defer env.release()
+
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
+	defer caRelease()
+
+	controllers := ca.AnalyzeSourceFile(env.sourceFile)
+	if len(controllers) != 1 || len(controllers[0].Routes) != 3 {
+		t.Fatalf("expected 1 controller with 3 routes, got %d controllers", len(controllers))
+	}
+	views := map[string]string{}
+	for _, r := range controllers[0].Routes {
+		views[r.MethodName] = r.View
+	}
+	if views["forAdmin"] != "admin" {
+		t.Errorf("expected view admin from @view, got %q", views["forAdmin"])
+	}
+	if views["forOwner"] != "owner" {
+		t.Errorf("expected @Returns view owner to override @view, got %q", views["forOwner"])
+	}
+	if views["invalid"] != "" {
+		t.Errorf("expected invalid view to be ignored, got %q", views["invalid"])
+	}
+	found := false
+	for _, w := range ca.Warnings() {
+		if w.Kind == "invalid-view" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected an invalid-view warning")
+	}
+}
+
 func TestControllerAnalyzer_NamespaceImport_Get(t *testing.T) {
 	env := setupWalkerMultiFile(t, map[string]string{
 		"decorators.ts": `
@@ -3567,7 +3802,7 @@ func TestControllerAnalyzer_NamespaceImport_Get(t *testing.T) {
 	}, "controller.ts")
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -3611,7 +3846,7 @@ func TestControllerAnalyzer_NamespaceImport_Body(t *testing.T) {
 	}, "controller.ts")
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
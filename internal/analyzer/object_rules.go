@@ -0,0 +1,251 @@
+package analyzer
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/shim/ast"
+	shimchecker "github.com/microsoft/typescript-go/shim/checker"
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// splitObjectRules takes phantom members carrying cross-field rules out of an
+// intersection with an object type (e.g. `Dto & RequireOneOf<["email", "phone"]>`)
+// and returns the rules they declare with the remaining members. The members are
+// returned unchanged when the intersection has no object member or no rule phantom.
+func (w *TypeWalker) splitObjectRules(rawTypes []*shimchecker.Type, members []metadata.Metadata) (*metadata.ObjectRules, []metadata.Metadata) {
+	hasObject := false
+	for i := range members {
+		if !isPhantomObject(&members[i]) && w.resolveToObjectProperties(&members[i]) != nil {
+			hasObject = true
+			break
+		}
+	}
+	if !hasObject {
+		return nil, members
+	}
+
+	var rules *metadata.ObjectRules
+	var rest []metadata.Metadata
+	for i := range members {
+		m := &members[i]
+		if m.Kind != metadata.KindObject || !isPhantomObject(m) || !isObjectRulePhantom(m) || i >= len(rawTypes) {
+			rest = append(rest, *m)
+			continue
+		}
+		if rules == nil {
+			rules = &metadata.ObjectRules{}
+		}
+		w.extractObjectRules(rules, rawTypes[i], m)
+	}
+	return rules, rest
+}
+
+// isObjectRulePhantom reports whether a phantom object declares an object-level rule.
+func isObjectRulePhantom(m *metadata.Metadata) bool {
+	for _, prop := range m.Properties {
+		switch prop.Name {
+		case "__tsgonest_validate", "__tsgonest_requireOneOf", "__tsgonest_dependentRequired":
+			return true
+		}
+	}
+	return false
+}
+
+// extractObjectRules adds the rules declared by a phantom object to rules:
+//   - __tsgonest_validate (+ _error): predicate called with the whole object
+//   - __tsgonest_requireOneOf (+ _error): tuple of keys, exactly one present
+//   - __tsgonest_dependentRequired: object mapping a key to a tuple of keys
+func (w *TypeWalker) extractObjectRules(rules *metadata.ObjectRules, rawPhantomType *shimchecker.Type, phantom *metadata.Metadata) {
+	var oneOf metadata.KeyGroup
+	for _, prop := range phantom.Properties {
+		switch prop.Name {
+		case "__tsgonest_validate":
+			var c metadata.Constraints
+			if w.extractValidateFnConstraint(&c, rawPhantomType, prop.Name) {
+				rules.ValidateFn = *c.ValidateFn
+				if c.ValidateModule != nil {
+					rules.ValidateModule = *c.ValidateModule
+				}
+			}
+		case "__tsgonest_validate_error":
+			if s, ok := literalString(&prop.Type); ok {
+				rules.ValidateError = s
+			}
+		case "__tsgonest_requireOneOf":
+			oneOf.Keys = literalStrings(&prop.Type)
+		case "__tsgonest_requireOneOf_error":
+			if s, ok := literalString(&prop.Type); ok {
+				oneOf.Error = s
+			}
+		case "__tsgonest_dependentRequired":
+			if prop.Type.Kind != metadata.KindObject {
+				continue
+			}
+			for _, dep := range prop.Type.Properties {
+				addDependentRequired(rules, dep.Name, literalStrings(&dep.Type))
+			}
+		}
+	}
+	if len(oneOf.Keys) > 0 {
+		rules.RequireOneOf = append(rules.RequireOneOf, oneOf)
+	}
+}
+
+// literalStrings returns the values of a tuple of string literals, or nil.
+func literalStrings(m *metadata.Metadata) []string {
+	if m.Kind != metadata.KindTuple {
+		return nil
+	}
+	var values []string
+	for i := range m.Elements {
+		s, ok := literalString(&m.Elements[i].Type)
+		if !ok || m.Elements[i].Rest {
+			return nil
+		}
+		values = append(values, s)
+	}
+	return values
+}
+
+// addDependentRequired records that keys are required whenever key is present.
+func addDependentRequired(rules *metadata.ObjectRules, key string, keys []string) {
+	if key == "" || len(keys) == 0 {
+		return
+	}
+	if rules.DependentRequired == nil {
+		rules.DependentRequired = make(map[string][]string)
+	}
+	for _, k := range keys {
+		if !slices.Contains(rules.DependentRequired[key], k) {
+			rules.DependentRequired[key] = append(rules.DependentRequired[key], k)
+		}
+	}
+}
+
+// mergeObjectRules combines the rules of two intersected objects. A predicate in
+// src replaces the one of dst, like later properties win in mergeProperties.
+func mergeObjectRules(dst, src *metadata.ObjectRules) *metadata.ObjectRules {
+	if src == nil {
+		return dst
+	}
+	merged := &metadata.ObjectRules{}
+	if dst != nil {
+		*merged = *dst
+		merged.RequireOneOf = slices.Clone(dst.RequireOneOf)
+		merged.DependentRequired = nil
+		for key, keys := range dst.DependentRequired {
+			addDependentRequired(merged, key, keys)
+		}
+	}
+	if src.ValidateFn != "" {
+		merged.ValidateFn = src.ValidateFn
+		merged.ValidateModule = src.ValidateModule
+		merged.ValidateError = src.ValidateError
+	}
+	merged.RequireOneOf = append(merged.RequireOneOf, src.RequireOneOf...)
+	for key, keys := range src.DependentRequired {
+		addDependentRequired(merged, key, keys)
+	}
+	return merged
+}
+
+// resolveObjectRules returns the rules of an object (or a ref resolving to one).
+func (w *TypeWalker) resolveObjectRules(m *metadata.Metadata) *metadata.ObjectRules {
+	switch m.Kind {
+	case metadata.KindObject:
+		return m.Rules
+	case metadata.KindRef:
+		if resolved, ok := w.registry.Types[m.Ref]; ok && resolved.Kind == metadata.KindObject {
+			return resolved.Rules
+		}
+	}
+	return nil
+}
+
+// extractJSDocObjectRules parses the JSDoc equivalents of the rule tags on the
+// declarations of an object type:
+//   - @validate <fn> — exported predicate in scope of the declaration
+//   - @requireOneOf <key> <key>... — exactly one of the keys must be present
+//   - @dependentRequired <key> <key>... — the other keys are required with the first
+//
+// Keys may be separated by spaces or commas. Type literals use the JSDoc of
+// their type alias.
+func (w *TypeWalker) extractJSDocObjectRules(sym *ast.Symbol) *metadata.ObjectRules {
+	if sym == nil {
+		return nil
+	}
+	var rules metadata.ObjectRules
+	found := false
+	for _, decl := range sym.Declarations {
+		if decl.Kind == ast.KindTypeLiteral && decl.Parent != nil && decl.Parent.Kind == ast.KindTypeAliasDeclaration {
+			decl = decl.Parent
+		}
+		jsdocs := decl.JSDoc(nil)
+		if len(jsdocs) == 0 {
+			continue
+		}
+		jsdoc := jsdocs[len(jsdocs)-1].AsJSDoc()
+		if jsdoc.Tags == nil {
+			continue
+		}
+		for _, tagNode := range jsdoc.Tags.Nodes {
+			tagName, comment := extractJSDocTagInfo(tagNode)
+			keys := strings.FieldsFunc(comment, func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+			})
+			switch strings.ToLower(tagName) {
+			case "validate":
+				if len(keys) == 0 {
+					continue
+				}
+				if fn, module, ok := w.resolveJSDocFunction(decl, keys[0]); ok {
+					rules.ValidateFn = fn
+					rules.ValidateModule = module
+					found = true
+				}
+			case "requireoneof":
+				if len(keys) > 0 {
+					rules.RequireOneOf = append(rules.RequireOneOf, metadata.KeyGroup{Keys: keys})
+					found = true
+				}
+			case "dependentrequired":
+				if len(keys) > 1 {
+					addDependentRequired(&rules, keys[0], keys[1:])
+					found = true
+				}
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &rules
+}
+
+// resolveJSDocFunction looks up a function by name in the file of decl, following
+// imports, and returns its exported name and source file.
+func (w *TypeWalker) resolveJSDocFunction(decl *ast.Node, name string) (string, string, bool) {
+	sf := ast.GetSourceFileOfNode(decl)
+	if sf == nil {
+		return "", "", false
+	}
+	sym := sf.Locals[name]
+	if sym == nil {
+		return "", "", false
+	}
+	if sym.ExportSymbol != nil {
+		sym = sym.ExportSymbol
+	}
+	if sym.Flags&ast.SymbolFlagsAlias != 0 {
+		sym = w.checker.GetAliasedSymbol(sym)
+	}
+	if sym == nil || sym.Flags&(ast.SymbolFlagsFunction|ast.SymbolFlagsVariable) == 0 || sym.ValueDeclaration == nil {
+		return "", "", false
+	}
+	fnFile := ast.GetSourceFileOfNode(sym.ValueDeclaration)
+	if fnFile == nil {
+		return "", "", false
+	}
+	return sym.Name, fnFile.FileName(), true
+}
@@ -81,6 +81,7 @@ type Route struct {
 	SSEEventVariants []SSEEventVariant
 	// UsesRawResponse indicates a parameter uses @Res()/@Response(), meaning
 	// the developer handles the response manually. Return type is meaningless.
+	// False for @Res({ passthrough: true }), where Nest still sends the return value.
 	UsesRawResponse bool
 	// ResponseContentType overrides the content type for the success response in OpenAPI.
 	// Defaults to "application/json". Set by @Returns<T>({ contentType: 'application/pdf' }).
@@ -88,6 +89,14 @@ type Route struct {
 	// ResponseDescription overrides the response description in OpenAPI.
 	// Set by @Returns<T>({ description: 'PDF invoice' }).
 	ResponseDescription string
+	// View is the serialization view of the success response, from @view JSDoc
+	// or @Returns<T>({ view: 'admin' }). Properties with groups (Groups<G>) are
+	// only serialized in the views named by their groups.
+	View string
+	// IsBinaryResponse indicates the handler returns a binary payload
+	// (StreamableFile, Buffer, or Readable). Such routes are documented with a
+	// binary schema and are never wrapped with serialization.
+	IsBinaryResponse bool
 	// IsPublic indicates the route explicitly opts out of global/controller security.
 	// Set by @public JSDoc on the method or controller.
 	IsPublic bool
@@ -191,6 +200,54 @@ type RouteParameter struct {
 	Description string
 }
 
+// ValidatedTypeName returns the name of the type the build validates the
+// parameter against as a whole: a named @Body() type (except
+// multipart/form-data), or a named whole-object @Query()/@Param()/@Headers()
+// type bound to a local variable. It returns "" for other parameters.
+func (p *RouteParameter) ValidatedTypeName() string {
+	name := p.Type.Name
+	if name == "" {
+		name = p.Type.Ref
+	}
+	switch p.Category {
+	case "body":
+		if p.ContentType != "multipart/form-data" {
+			return name
+		}
+	case "query", "headers", "param":
+		if p.Name == "" && p.TypeName != "" && p.LocalName != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// CoercesNumber reports whether the build coerces the parameter to a number:
+// a numeric @Query(name) or @Param(name).
+func (p *RouteParameter) CoercesNumber() bool {
+	return (p.Category == "query" || p.Category == "param") && p.Name != "" &&
+		p.Type.Kind == metadata.KindAtomic && p.Type.Atomic == "number"
+}
+
+// ValidatesRequest reports whether the build injects request validation or
+// coercion that can throw a validation error into the route. hasCompanion
+// reports whether a type has a companion to validate with (nil: every type).
+func (r *Route) ValidatesRequest(hasCompanion func(typeName string) bool) bool {
+	if r.UsesRawResponse {
+		return false
+	}
+	for i := range r.Parameters {
+		param := &r.Parameters[i]
+		if name := param.ValidatedTypeName(); name != "" && (hasCompanion == nil || hasCompanion(name)) {
+			return true
+		}
+		if param.CoercesNumber() {
+			return true
+		}
+	}
+	return false
+}
+
 // ControllerAnalyzer extracts NestJS controller information from source files.
 type ControllerAnalyzer struct {
 	program  *shimcompiler.Program
@@ -200,10 +257,12 @@ type ControllerAnalyzer struct {
 	warnings *WarningCollector
 }
 
-// NewControllerAnalyzer creates a new controller analyzer.
-func NewControllerAnalyzer(program *shimcompiler.Program) (*ControllerAnalyzer, func()) {
+// NewControllerAnalyzer creates a new controller analyzer that names colliding
+// schemas with the given strategy (see SchemaNamingPath).
+func NewControllerAnalyzer(program *shimcompiler.Program, strategy string) (*ControllerAnalyzer, func()) {
 	checker, release := shimcompiler.Program_GetTypeChecker(program, context.Background())
 	walker := NewTypeWalker(checker)
+	walker.SetSchemaNamer(NewSchemaNamer(program.GetSourceFiles(), strategy, ProjectRootDir(program)))
 
 	return &ControllerAnalyzer{
 		program:  program,
@@ -631,6 +690,18 @@ func (a *ControllerAnalyzer) analyzeMethod(methodNode *ast.Node, controllerPath
 		returnType = a.extractReturnType(methodNode, className, operationID, sourceFile)
 	}
 
+	// Binary downloads (StreamableFile, Buffer, Readable): the content type comes from
+	// @Returns contentType > @Header('Content-Type', ...) > application/octet-stream.
+	isBinaryResponse := IsBinaryResponseType(&returnType)
+	if isBinaryResponse && responseContentType == "" {
+		responseContentType = "application/octet-stream"
+		for _, h := range responseHeaders {
+			if strings.EqualFold(h.Name, "Content-Type") && h.Value != "" {
+				responseContentType = h.Value
+			}
+		}
+	}
+
 	// For @EventStream routes, extract SSE event variants (discriminated event types).
 	// The returnType at this point has been unwrapped through AsyncGenerator → SseEvent level.
 	// We need the pre-unwrapped type to detect SseEvent<E, T> structure.
@@ -645,12 +716,19 @@ func (a *ControllerAnalyzer) analyzeMethod(methodNode *ast.Node, controllerPath
 	}
 
 	// Extract JSDoc metadata from the method
-	summary, description, deprecated, hidden, jsdocTags, security, errorResponses, contentType, operationIDOverride, isPublic, paramDescs, methodExtensions, ignoreWarnings := extractMethodJSDoc(methodNode)
+	summary, description, deprecated, hidden, jsdocTags, security, errorResponses, contentType, operationIDOverride, isPublic, paramDescs, methodExtensions, ignoreWarnings, view := extractMethodJSDoc(methodNode)
 
 	// @hidden or @exclude — skip this route from OpenAPI generation
 	if hidden {
 		return nil
 	}
+
+	// Serialization view: @Returns<T>({ view }) overrides @view
+	if len(returnsDecoratorInfos) > 0 {
+		if v, ok := returnsDecoratorInfos[0].ObjectLiteralArg["view"]; ok && v.Kind == ast.KindStringLiteral {
+			view = v.AsStringLiteral().Text
+		}
+	}
 	if len(jsdocTags) > 0 {
 		tags = jsdocTags // Override controller-derived tags
 	}
@@ -722,6 +800,13 @@ func (a *ControllerAnalyzer) analyzeMethod(methodNode *ast.Node, controllerPath
 			ValidateParameterType(&params[i], a.warnings, sourceFile, warnLocation)
 		}
 
+		// View names are part of generated function and schema names
+		if view != "" && !isViewName(view) {
+			a.warnings.Add(sourceFile, "invalid-view",
+				fmt.Sprintf("%s — view %q is not a valid identifier and is ignored. "+
+					"Use letters, digits and underscores, e.g. @view admin", warnLocation, view))
+		}
+
 		// Warn when @Res()/@Response() is used WITHOUT @Returns — return type cannot be determined statically.
 		// When @Returns<T>() is present, we have the type info and no warning is needed.
 		// When @tsgonest-ignore uses-raw-response is in JSDoc, suppress the warning.
@@ -733,6 +818,10 @@ func (a *ControllerAnalyzer) analyzeMethod(methodNode *ast.Node, controllerPath
 		}
 	}
 
+	if !isViewName(view) {
+		view = ""
+	}
+
 	// Extract @throws error responses — resolve their types
 	var resolvedErrors []ErrorResponse
 	for _, er := range errorResponses {
@@ -765,6 +854,8 @@ func (a *ControllerAnalyzer) analyzeMethod(methodNode *ast.Node, controllerPath
 		UsesRawResponse:     usesRawResponse,
 		ResponseContentType: responseContentType,
 		ResponseDescription: responseDescription,
+		View:                view,
+		IsBinaryResponse:    isBinaryResponse,
 		IsPublic:            isPublic,
 		Extensions:          methodExtensions,
 		AdditionalResponses: additionalResponses,
@@ -1230,7 +1321,7 @@ func (a *ControllerAnalyzer) analyzeParameter(paramNode *ast.Node, className str
 	if paramDecl.Type != nil {
 		paramType = a.walker.WalkTypeNode(paramDecl.Type)
 		// Extract the type name from the type annotation (e.g., "RegisterRequest")
-		paramTypeName = resolveTypeNodeName(paramDecl.Type, a.checker)
+		paramTypeName = resolveTypeNodeName(paramDecl.Type, a.walker)
 	} else {
 		// Try to infer from checker
 		sym := a.checker.GetSymbolAtLocation(paramNode)
@@ -1276,10 +1367,11 @@ func (a *ControllerAnalyzer) analyzeParameter(paramNode *ast.Node, className str
 	return rp
 }
 
-// resolveTypeNodeName extracts the type name from a type annotation AST node.
-// For `body: RegisterRequest`, this returns "RegisterRequest".
+// resolveTypeNodeName extracts the schema name from a type annotation AST node.
+// For `body: RegisterRequest`, this returns "RegisterRequest" (or its
+// disambiguated schema name when the name collides with another declaration).
 // Returns empty string for anonymous/inline types.
-func resolveTypeNodeName(typeNode *ast.Node, checker *shimchecker.Checker) string {
+func resolveTypeNodeName(typeNode *ast.Node, w *TypeWalker) string {
 	if typeNode == nil {
 		return ""
 	}
@@ -1287,7 +1379,7 @@ func resolveTypeNodeName(typeNode *ast.Node, checker *shimchecker.Checker) strin
 	if typeNode.Kind == ast.KindTypeReference {
 		ref := typeNode.AsTypeReferenceNode()
 		if ref.TypeName != nil && ref.TypeName.Kind == ast.KindIdentifier {
-			return ref.TypeName.Text()
+			return w.referenceSchemaName(ref.TypeName)
 		}
 	}
 	// For intersections (e.g., `Type & tags.Something`), check the first constituent
@@ -1296,7 +1388,7 @@ func resolveTypeNodeName(typeNode *ast.Node, checker *shimchecker.Checker) strin
 			if member.Kind == ast.KindTypeReference {
 				ref := member.AsTypeReferenceNode()
 				if ref.TypeName != nil && ref.TypeName.Kind == ast.KindIdentifier {
-					return ref.TypeName.Text()
+					return w.referenceSchemaName(ref.TypeName)
 				}
 			}
 		}
@@ -1341,17 +1433,41 @@ func AutoEnableCoercion(m *metadata.Metadata) {
 	}
 }
 
+// IsBinaryResponseType reports whether a return type is a binary download
+// (StreamableFile, Buffer, or Readable).
+func IsBinaryResponseType(m *metadata.Metadata) bool {
+	if m == nil || m.Kind != metadata.KindNative {
+		return false
+	}
+	switch m.NativeType {
+	case "StreamableFile", "Buffer", "Readable":
+		return true
+	}
+	return false
+}
+
 // hasResponseDecorator checks if a parameter node has @Res() or @Response() decorator.
+// @Res({ passthrough: true }) is not counted: Nest still sends the returned value,
+// so the route keeps its return type and serialization.
 func hasResponseDecorator(paramNode *ast.Node) bool {
 	for _, dec := range paramNode.Decorators() {
 		info := ParseDecorator(dec)
-		if info != nil && (info.Name == "Res" || info.Name == "Response") {
+		if info != nil && (info.Name == "Res" || info.Name == "Response") && !isPassthroughResponse(info) {
 			return true
 		}
 	}
 	return false
 }
 
+// isPassthroughResponse checks for the { passthrough: true } option on @Res()/@Response().
+func isPassthroughResponse(info *DecoratorInfo) bool {
+	if info.ObjectLiteralArg == nil {
+		return false
+	}
+	node, ok := info.ObjectLiteralArg["passthrough"]
+	return ok && node != nil && node.Kind == ast.KindTrueKeyword
+}
+
 // resolveDecoratorIn resolves a custom decorator's parameter category by reading
 // the @in JSDoc tag on the decorator's declaration site.
 //
@@ -1483,7 +1599,7 @@ func (a *ControllerAnalyzer) extractReturnType(methodNode *ast.Node, className s
 		result := a.walker.WalkTypeNode(methodDecl.Type)
 		// Preserve the inner type name for Promise<T> / Observable<T>
 		if result.Name == "" {
-			result.Name = resolveInnerTypeName(methodDecl.Type)
+			result.Name = resolveInnerTypeName(methodDecl.Type, a.walker)
 		}
 		return result
 	}
@@ -1494,7 +1610,7 @@ func (a *ControllerAnalyzer) extractReturnType(methodNode *ast.Node, className s
 
 // resolveInnerTypeName extracts the type name from a type node, unwrapping
 // Promise<T> and Observable<T> wrappers to get the inner type name.
-func resolveInnerTypeName(typeNode *ast.Node) string {
+func resolveInnerTypeName(typeNode *ast.Node, w *TypeWalker) string {
 	if typeNode == nil || typeNode.Kind != ast.KindTypeReference {
 		return ""
 	}
@@ -1514,7 +1630,7 @@ func resolveInnerTypeName(typeNode *ast.Node) string {
 	if name == "Promise" || name == "Observable" ||
 		name == "AsyncGenerator" || name == "AsyncIterable" || name == "AsyncIterableIterator" {
 		if ref.TypeArguments != nil && len(ref.TypeArguments.Nodes) > 0 {
-			return resolveInnerTypeName(ref.TypeArguments.Nodes[0])
+			return resolveInnerTypeName(ref.TypeArguments.Nodes[0], w)
 		}
 		return ""
 	}
@@ -1524,7 +1640,7 @@ func resolveInnerTypeName(typeNode *ast.Node) string {
 		return ""
 	}
 
-	return name
+	return w.referenceSchemaName(ref.TypeName)
 }
 
 // inferReturnType uses the checker to resolve the return type of a method that
@@ -1730,6 +1846,22 @@ func extractSseEventVariantFromObject(m metadata.Metadata, registry *metadata.Ty
 	}
 }
 
+// isViewName reports whether view can name a serialization view: an identifier
+// of letters, digits and underscores, as it is appended to generated function
+// and schema names.
+func isViewName(view string) bool {
+	if view == "" {
+		return false
+	}
+	for i, r := range view {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
 // httpMethodForDecorator maps a decorator name to its HTTP method.
 func httpMethodForDecorator(name string) string {
 	switch name {
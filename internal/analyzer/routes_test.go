@@ -0,0 +1,40 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+func TestRouteValidatesRequest(t *testing.T) {
+	dto := metadata.Metadata{Kind: metadata.KindRef, Ref: "CreateUserDto"}
+	number := metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}
+	tests := []struct {
+		name  string
+		param RouteParameter
+		want  bool
+	}{
+		{"typed body", RouteParameter{Category: "body", Type: dto}, true},
+		{"multipart body", RouteParameter{Category: "body", Type: dto, ContentType: "multipart/form-data"}, false},
+		{"untyped body", RouteParameter{Category: "body", Type: metadata.Metadata{Kind: metadata.KindAny}}, false},
+		{"whole query", RouteParameter{Category: "query", TypeName: "CreateUserDto", LocalName: "query", Type: dto}, true},
+		{"whole query without local", RouteParameter{Category: "query", TypeName: "CreateUserDto", Type: dto}, false},
+		{"numeric param", RouteParameter{Category: "param", Name: "id", Type: number}, true},
+		{"numeric header", RouteParameter{Category: "headers", Name: "x-count", Type: number}, false},
+	}
+	for _, tt := range tests {
+		route := Route{Parameters: []RouteParameter{tt.param}}
+		if got := route.ValidatesRequest(nil); got != tt.want {
+			t.Errorf("%s: ValidatesRequest = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	route := Route{Parameters: []RouteParameter{{Category: "body", Type: dto}}}
+	if route.ValidatesRequest(func(string) bool { return false }) {
+		t.Error("expected no validation without a companion")
+	}
+	route.UsesRawResponse = true
+	if route.ValidatesRequest(nil) {
+		t.Error("expected no validation with @Res()")
+	}
+}
@@ -0,0 +1,506 @@
+package analyzer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/microsoft/typescript-go/shim/ast"
+	shimcompiler "github.com/microsoft/typescript-go/shim/compiler"
+)
+
+// Schema naming strategies for disambiguating types that share a name.
+const (
+	// SchemaNamingPath prefixes colliding names with their distinguishing
+	// directory segments: billing/user.dto.ts → BillingUserDto.
+	SchemaNamingPath = "path"
+	// SchemaNamingNamespace prefixes colliding names with their enclosing
+	// namespace chain (namespace Billing { interface UserDto }) → BillingUserDto,
+	// falling back to the path strategy for declarations outside a namespace.
+	SchemaNamingNamespace = "namespace"
+)
+
+// SchemaNamer assigns stable, unique schema names to the named type declarations
+// of a program. Names are resolved once up front from the full set of declarations,
+// so the result does not depend on the order in which types are walked:
+//   - an explicit @schemaName JSDoc tag always wins
+//   - a name declared only once keeps its bare name
+//   - colliding names are qualified according to the configured strategy
+//
+// The same name is used for registry keys, companion functions, OpenAPI
+// component schemas and (through OpenAPI) the generated SDK types.
+//
+// Collisions are first resolved across all declarations of the program. Once
+// the emitted types have been walked, Restrict resolves them again across the
+// declarations that were actually named, so that unrelated declarations do not
+// rename emitted schemas.
+type SchemaNamer struct {
+	names map[*ast.Node]string
+	// decls are the declarations the names were resolved from, qualified
+	// according to strategy with directories relative to rootDir.
+	decls    []*schemaDecl
+	strategy string
+	rootDir  string
+	// ambiguous is set when names were qualified or collide (see Restrict).
+	ambiguous bool
+	// used records the declarations named through SymbolName or Use.
+	used map[*ast.Node]bool
+	// genericAliases are type aliases with an explicit @schemaName whose target
+	// is a generic instantiation (type UserPage = Page<UserDto>). The walker
+	// uses them to name every occurrence of that instantiation.
+	genericAliases []*ast.Node
+	// genericTemplate and genericSeparator control composite names for generic
+	// instantiations (see GenericName).
+	genericTemplate  string
+	genericSeparator string
+}
+
+// Default naming of generic instantiations: Page<UserDto> → Page_UserDto.
+const (
+	DefaultGenericTemplate  = "{base}_{args}"
+	DefaultGenericSeparator = "_"
+)
+
+// schemaDecl is a named type declaration collected by NewSchemaNamer.
+type schemaDecl struct {
+	node       *ast.Node
+	name       string
+	fileName   string
+	namespaces []string
+	explicit   string
+}
+
+// identity distinguishes separate declarations from merged ones
+// (e.g. an interface declared twice in the same scope).
+func (d *schemaDecl) identity() string {
+	return d.fileName + "#" + strings.Join(d.namespaces, ".")
+}
+
+// NewSchemaNamer collects the named type declarations (interfaces, type aliases,
+// classes, enums) of the given source files and resolves their schema names.
+// Declaration files are skipped: library types keep their bare names.
+// An empty strategy defaults to SchemaNamingPath. The path strategy qualifies
+// names with directories relative to rootDir (see ProjectRootDir), so that
+// names do not depend on where the project is checked out.
+func NewSchemaNamer(files []*ast.SourceFile, strategy string, rootDir string) *SchemaNamer {
+	var decls []*schemaDecl
+	for _, sf := range files {
+		if sf == nil || sf.IsDeclarationFile {
+			continue
+		}
+		collectSchemaDecls(sf.Statements.Nodes, sf.FileName(), nil, &decls)
+	}
+	namer, _ := resolveSchemaNames(decls, strategy, rootDir)
+	return namer
+}
+
+// ProjectRootDir returns the directory schema name paths are relative to: the
+// rootDir compiler option, or else the directory of the tsconfig file.
+func ProjectRootDir(program *shimcompiler.Program) string {
+	opts := program.Options()
+	if opts.RootDir != "" {
+		if filepath.IsAbs(opts.RootDir) {
+			return opts.RootDir
+		}
+		return filepath.Join(program.GetCurrentDirectory(), opts.RootDir)
+	}
+	if opts.ConfigFilePath != "" {
+		return filepath.Dir(opts.ConfigFilePath)
+	}
+	return program.GetCurrentDirectory()
+}
+
+// resolveSchemaNames resolves the schema names of decls. The error reports the
+// first schema name given to two separate declarations: an explicit
+// @schemaName, or a qualified name, matching the name of another declaration.
+func resolveSchemaNames(decls []*schemaDecl, strategy string, rootDir string) (*SchemaNamer, error) {
+	byName := make(map[string][]*schemaDecl)
+	for _, d := range decls {
+		byName[d.name] = append(byName[d.name], d)
+	}
+
+	namer := &SchemaNamer{
+		names:    make(map[*ast.Node]string, len(decls)),
+		decls:    decls,
+		strategy: strategy,
+		rootDir:  rootDir,
+		used:     make(map[*ast.Node]bool),
+	}
+	for name, group := range byName {
+		identities := make(map[string]bool)
+		for _, d := range group {
+			identities[d.identity()] = true
+		}
+		var qualifiers map[string]string
+		if len(identities) > 1 {
+			qualifiers = qualifySchemaDecls(group, strategy, rootDir)
+			namer.ambiguous = true
+		}
+		for _, d := range group {
+			switch {
+			case d.explicit != "":
+				namer.names[d.node] = d.explicit
+				if isGenericInstantiationAlias(d.node) {
+					namer.genericAliases = append(namer.genericAliases, d.node)
+				}
+			case qualifiers != nil:
+				namer.names[d.node] = qualifiers[d.identity()] + name
+			default:
+				namer.names[d.node] = name
+			}
+		}
+	}
+
+	// Distinct declarations must not end up with the same name
+	owners := make(map[string]*schemaDecl)
+	var err error
+	for _, d := range decls {
+		name := namer.names[d.node]
+		other, ok := owners[name]
+		if !ok {
+			owners[name] = d
+			continue
+		}
+		if other.identity() == d.identity() && other.name == d.name {
+			continue // merged declarations
+		}
+		namer.ambiguous = true
+		if err == nil {
+			err = fmt.Errorf("schema name %q is used by both %s and %s; rename one of them with @schemaName", name, other.describe(rootDir), d.describe(rootDir))
+		}
+	}
+	return namer, err
+}
+
+// describe returns the declaration name with its file, relative to rootDir.
+func (d *schemaDecl) describe(rootDir string) string {
+	file := d.fileName
+	if rel, err := filepath.Rel(rootDir, file); err == nil {
+		file = filepath.ToSlash(rel)
+	}
+	return fmt.Sprintf("%s (%s)", strings.Join(append(append([]string(nil), d.namespaces...), d.name), "."), file)
+}
+
+// Ambiguous reports whether names were qualified or collide, i.e. whether
+// Restrict may resolve different names.
+func (n *SchemaNamer) Ambiguous() bool {
+	return n != nil && n.ambiguous
+}
+
+// Restrict resolves the schema names again across the declarations named
+// through SymbolName or Use so far (the walked types) and the explicitly named generic
+// aliases. It fails when two of them get the same schema name. The other
+// declarations keep their names, unless one of them matches a restricted name.
+func (n *SchemaNamer) Restrict() (*SchemaNamer, error) {
+	var decls, rest []*schemaDecl
+	for _, d := range n.decls {
+		if n.used[d.node] || (d.explicit != "" && isGenericInstantiationAlias(d.node)) {
+			decls = append(decls, d)
+		} else {
+			rest = append(rest, d)
+		}
+	}
+	namer, err := resolveSchemaNames(decls, n.strategy, n.rootDir)
+	if err != nil {
+		return nil, err
+	}
+	owners := make(map[string]*schemaDecl, len(decls))
+	for _, d := range decls {
+		owners[namer.names[d.node]] = d
+	}
+	for _, d := range rest {
+		name := n.names[d.node]
+		if other, ok := owners[name]; ok && (other.identity() != d.identity() || other.name != d.name) {
+			return nil, fmt.Errorf("schema name %q is used by both %s and %s; rename one of them with @schemaName", name, other.describe(n.rootDir), d.describe(n.rootDir))
+		}
+		namer.names[d.node] = name
+	}
+	namer.genericTemplate = n.genericTemplate
+	namer.genericSeparator = n.genericSeparator
+	return namer, nil
+}
+
+// SetGenericNaming configures the template used to name generic instantiations.
+// The template must contain {base} and {args}; type argument names are joined
+// with separator. Empty values keep the defaults ({base}_{args} and "_").
+func (n *SchemaNamer) SetGenericNaming(template, separator string) {
+	n.genericTemplate = template
+	n.genericSeparator = separator
+}
+
+// GenericName formats the schema name of a generic instantiation from its base
+// name and type argument names:
+//
+//	{base}_{args}   Page<UserDto> → Page_UserDto (default)
+//	{base}Of{args}  Page<UserDto> → PageOfUserDto
+//	{args}{base}    Page<UserDto> → UserDtoPage
+func (n *SchemaNamer) GenericName(base string, args []string) string {
+	template, separator := DefaultGenericTemplate, DefaultGenericSeparator
+	if n != nil {
+		if n.genericTemplate != "" {
+			template = n.genericTemplate
+		}
+		if n.genericSeparator != "" {
+			separator = n.genericSeparator
+		}
+	}
+	return strings.NewReplacer("{base}", base, "{args}", strings.Join(args, separator)).Replace(template)
+}
+
+// GenericAliases returns the @schemaName-tagged type aliases whose target is a
+// generic instantiation.
+func (n *SchemaNamer) GenericAliases() []*ast.Node {
+	if n == nil {
+		return nil
+	}
+	return n.genericAliases
+}
+
+// DeclarationName returns the schema name for a type declaration node,
+// or "" if the declaration is not known to the namer.
+func (n *SchemaNamer) DeclarationName(decl *ast.Node) string {
+	if n == nil || decl == nil {
+		return ""
+	}
+	return n.names[decl]
+}
+
+// SymbolName returns the schema name for a type symbol, falling back to the
+// symbol's own name for symbols declared outside the analyzed sources.
+func (n *SchemaNamer) SymbolName(sym *ast.Symbol) string {
+	if sym == nil {
+		return ""
+	}
+	if n != nil {
+		for _, decl := range sym.Declarations {
+			if name, ok := n.names[decl]; ok {
+				n.used[decl] = true
+				return name
+			}
+		}
+	}
+	return sym.Name
+}
+
+// Use records that decl is emitted under its schema name, like SymbolName does.
+func (n *SchemaNamer) Use(decl *ast.Node) {
+	if n == nil {
+		return
+	}
+	if _, ok := n.names[decl]; ok {
+		n.used[decl] = true
+	}
+}
+
+// collectSchemaDecls gathers named type declarations from a statement list,
+// descending into namespace bodies.
+func collectSchemaDecls(stmts []*ast.Node, fileName string, namespaces []string, out *[]*schemaDecl) {
+	for _, stmt := range stmts {
+		switch stmt.Kind {
+		case ast.KindInterfaceDeclaration, ast.KindTypeAliasDeclaration, ast.KindClassDeclaration, ast.KindEnumDeclaration:
+			nameNode := stmt.Name()
+			if nameNode == nil {
+				continue
+			}
+			*out = append(*out, &schemaDecl{
+				node:       stmt,
+				name:       nameNode.Text(),
+				fileName:   fileName,
+				namespaces: namespaces,
+				explicit:   extractSchemaNameTag(stmt),
+			})
+		case ast.KindModuleDeclaration:
+			collectNamespaceDecls(stmt, fileName, namespaces, out)
+		}
+	}
+}
+
+// collectNamespaceDecls handles `namespace A { ... }` and `namespace A.B { ... }`.
+func collectNamespaceDecls(node *ast.Node, fileName string, namespaces []string, out *[]*schemaDecl) {
+	decl := node.AsModuleDeclaration()
+	nameNode := decl.Name()
+	if nameNode == nil || nameNode.Kind != ast.KindIdentifier {
+		return // ambient module declarations (declare module "x") are not namespaces
+	}
+	chain := append(append([]string(nil), namespaces...), nameNode.Text())
+	body := decl.Body
+	if body == nil {
+		return
+	}
+	switch body.Kind {
+	case ast.KindModuleBlock:
+		if stmts := body.AsModuleBlock().Statements; stmts != nil {
+			collectSchemaDecls(stmts.Nodes, fileName, chain, out)
+		}
+	case ast.KindModuleDeclaration:
+		collectNamespaceDecls(body, fileName, chain, out)
+	}
+}
+
+// isGenericInstantiationAlias reports whether decl is a non-generic type alias
+// whose target is a type reference with type arguments (type UserPage = Page<UserDto>).
+func isGenericInstantiationAlias(decl *ast.Node) bool {
+	if decl.Kind != ast.KindTypeAliasDeclaration {
+		return false
+	}
+	alias := decl.AsTypeAliasDeclaration()
+	if alias.TypeParameters != nil && len(alias.TypeParameters.Nodes) > 0 {
+		return false
+	}
+	if alias.Type == nil || alias.Type.Kind != ast.KindTypeReference {
+		return false
+	}
+	ref := alias.Type.AsTypeReferenceNode()
+	return ref.TypeArguments != nil && len(ref.TypeArguments.Nodes) > 0
+}
+
+// extractSchemaNameTag reads an explicit `@schemaName Name` JSDoc tag.
+// Characters that are not valid in a JavaScript identifier are dropped,
+// since schema names are also used for companion function names.
+func extractSchemaNameTag(node *ast.Node) string {
+	jsdocs := node.JSDoc(nil)
+	if len(jsdocs) == 0 {
+		return ""
+	}
+	jsdoc := jsdocs[len(jsdocs)-1].AsJSDoc()
+	if jsdoc.Tags == nil {
+		return ""
+	}
+	for _, tagNode := range jsdoc.Tags.Nodes {
+		tagName, comment := extractJSDocTagInfo(tagNode)
+		if strings.ToLower(tagName) != "schemaname" {
+			continue
+		}
+		fields := strings.Fields(comment)
+		if len(fields) == 0 {
+			continue
+		}
+		return sanitizeSchemaName(fields[0])
+	}
+	return ""
+}
+
+// sanitizeSchemaName keeps only identifier characters and ensures the name
+// does not start with a digit.
+func sanitizeSchemaName(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	name := b.String()
+	if name != "" && unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
+
+// qualifySchemaDecls computes a distinguishing prefix per declaration identity
+// within a group of same-named declarations, from directories relative to rootDir.
+func qualifySchemaDecls(group []*schemaDecl, strategy string, rootDir string) map[string]string {
+	// One representative per identity, in a deterministic order.
+	seen := make(map[string]*schemaDecl)
+	var ids []string
+	for _, d := range group {
+		id := d.identity()
+		if _, ok := seen[id]; !ok {
+			seen[id] = d
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	result := make(map[string]string, len(ids))
+	if strategy == SchemaNamingNamespace {
+		unique := make(map[string]int)
+		for _, id := range ids {
+			unique[pascalJoin(seen[id].namespaces)]++
+		}
+		allQualified := true
+		for _, id := range ids {
+			q := pascalJoin(seen[id].namespaces)
+			if q == "" || unique[q] > 1 {
+				allQualified = false
+				break
+			}
+			result[id] = q
+		}
+		if allQualified {
+			return result
+		}
+	}
+
+	// Path strategy: use the shortest suffix of directory segments that is unique
+	// across the group, then fall back to the full directory plus file stem.
+	segments := make(map[string][]string, len(ids))
+	maxLen := 0
+	for _, id := range ids {
+		d := seen[id]
+		dir := filepath.Dir(d.fileName)
+		if rel, err := filepath.Rel(rootDir, dir); err == nil {
+			dir = rel
+		}
+		var segs []string
+		for _, s := range strings.Split(filepath.ToSlash(dir), "/") {
+			if s != "" && s != "." && s != ".." {
+				segs = append(segs, s)
+			}
+		}
+		segs = append(segs, d.namespaces...)
+		segments[id] = segs
+		if len(segs) > maxLen {
+			maxLen = len(segs)
+		}
+	}
+	for k := 1; k <= maxLen; k++ {
+		counts := make(map[string]int)
+		candidate := make(map[string]string, len(ids))
+		for _, id := range ids {
+			segs := segments[id]
+			start := len(segs) - k
+			if start < 0 {
+				start = 0
+			}
+			q := pascalJoin(segs[start:])
+			candidate[id] = q
+			counts[q]++
+		}
+		unique := true
+		for _, q := range candidate {
+			if q == "" || counts[q] > 1 {
+				unique = false
+				break
+			}
+		}
+		if unique {
+			return candidate
+		}
+	}
+
+	// Same directory: include the file stem (user.dto.ts → UserDto).
+	for _, id := range ids {
+		d := seen[id]
+		stem := strings.TrimSuffix(filepath.Base(d.fileName), filepath.Ext(d.fileName))
+		result[id] = pascalJoin(append(append([]string(nil), segments[id]...), stem))
+	}
+	return result
+}
+
+// pascalJoin converts path or namespace segments into a PascalCase prefix:
+// ["billing", "v2-api"] → "BillingV2Api".
+func pascalJoin(segments []string) string {
+	var b strings.Builder
+	for _, seg := range segments {
+		for _, word := range strings.FieldsFunc(seg, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			b.WriteString(string(runes))
+		}
+	}
+	return b.String()
+}
@@ -44,6 +44,9 @@ type TypeWalker struct {
 	// exactOptionalPropertyTypes mirrors the tsconfig flag of the same name.
 	// When true, optional properties cannot have explicit undefined values.
 	exactOptionalPropertyTypes bool
+	// jsonNaming is the naming policy of property names in JSON bodies
+	// (transforms.jsonNaming, see JSONName). Empty keeps the declared names.
+	jsonNaming string
 	// warnings collects actionable diagnostics emitted during type walking
 	// (e.g., generic types with anonymous type arguments that can't be named).
 	warnings []string
@@ -55,6 +58,12 @@ type TypeWalker struct {
 	// SetRootContext before invoking Walk*. Included in warnings so users can
 	// trace which of their types consumes a generic with anonymous args.
 	currentRootContext string
+	// namer resolves declared type names to unique schema names (see SchemaNamer).
+	// When nil, symbols keep their declared names.
+	namer *SchemaNamer
+	// genericOverrides maps generic instantiation keys (see genericInstantiationKey)
+	// to the @schemaName of the type alias naming them. Built lazily from the namer.
+	genericOverrides map[string]string
 }
 
 // NewTypeWalker creates a new TypeWalker.
@@ -75,11 +84,29 @@ func (w *TypeWalker) SetExactOptionalPropertyTypes(v bool) {
 	w.exactOptionalPropertyTypes = v
 }
 
+// SetJSONNaming configures the naming policy deriving the JSON names of
+// properties without JsonName<N> / @jsonName (see JSONName).
+func (w *TypeWalker) SetJSONNaming(policy string) {
+	w.jsonNaming = policy
+}
+
 // Registry returns the type registry with all discovered named types.
 func (w *TypeWalker) Registry() *metadata.TypeRegistry {
 	return w.registry
 }
 
+// SetSchemaNamer configures how declared type names map to registry/schema names.
+// Used to disambiguate same-named types declared in different modules.
+func (w *TypeWalker) SetSchemaNamer(n *SchemaNamer) {
+	w.namer = n
+	w.genericOverrides = nil
+}
+
+// SchemaNamer returns the configured schema namer (nil when not set).
+func (w *TypeWalker) SchemaNamer() *SchemaNamer {
+	return w.namer
+}
+
 // SetRootContext sets the context for the type currently being walked
 // (e.g., "CreateUserDto (/src/users/dto.ts:12)"). Included in warnings
 // so users can trace which of their types is the consumer. Call with ""
@@ -343,7 +370,7 @@ func (w *TypeWalker) resolveAliasName(t *shimchecker.Type) string {
 	if aliasSym == nil {
 		return ""
 	}
-	name := aliasSym.Name
+	name := w.namer.SymbolName(aliasSym)
 	if name == "" || name == "__type" || name == "__object" || (len(name) > 0 && name[0] == '\xfe') {
 		return ""
 	}
@@ -529,7 +556,7 @@ func (w *TypeWalker) walkUnion(t *shimchecker.Type) metadata.Metadata {
 		alias := shimchecker.Type_alias(t)
 		if alias != nil {
 			if aliasSym := alias.Symbol(); aliasSym != nil {
-				aliasName := aliasSym.Name
+				aliasName := w.namer.SymbolName(aliasSym)
 				if aliasName != "" && aliasName != "__type" && aliasName != "__object" && (len(aliasName) == 0 || aliasName[0] != '\xfe') {
 					registrationName := aliasName
 					aliasTypeArgs := alias.TypeArguments()
@@ -567,7 +594,7 @@ func (w *TypeWalker) getUnionEnumName(t *shimchecker.Type) string {
 	if alias != nil {
 		aliasSym := alias.Symbol()
 		if aliasSym != nil && aliasSym.Name != "" {
-			name := aliasSym.Name
+			name := w.namer.SymbolName(aliasSym)
 			// Filter out internal names
 			if name != "__type" && name != "__object" && (len(name) == 0 || name[0] != '\xfe') {
 				return name
@@ -578,7 +605,7 @@ func (w *TypeWalker) getUnionEnumName(t *shimchecker.Type) string {
 	// 2. Check for enum symbol (actual TS enum declarations)
 	sym := t.Symbol()
 	if sym != nil && sym.Name != "" {
-		name := sym.Name
+		name := w.namer.SymbolName(sym)
 		if name != "__type" && name != "__object" && (len(name) == 0 || name[0] != '\xfe') {
 			return name
 		}
@@ -808,8 +835,15 @@ func (w *TypeWalker) walkIntersection(t *shimchecker.Type) metadata.Metadata {
 		return *branded
 	}
 
+	// Cross-field rule tags (Validate, RequireOneOf, DependentRequired) intersected
+	// with an object apply to the whole object, not as properties.
+	rules, members := w.splitObjectRules(types, members)
+
 	// Try to flatten: if all members resolve to objects, merge properties.
 	result := w.tryFlattenIntersection(members)
+	if rules != nil && result.Kind == metadata.KindObject {
+		result.Rules = mergeObjectRules(result.Rules, rules)
+	}
 
 	// If the intersection has a type alias name (e.g., type ShippingAddress = Address & { ... }),
 	// register the flattened result so it becomes a $ref instead of being inlined.
@@ -818,7 +852,7 @@ func (w *TypeWalker) walkIntersection(t *shimchecker.Type) metadata.Metadata {
 		alias := shimchecker.Type_alias(t)
 		if alias != nil && w.pendingName[t.Id()] == "" {
 			if aliasSym := alias.Symbol(); aliasSym != nil {
-				aliasName := aliasSym.Name
+				aliasName := w.namer.SymbolName(aliasSym)
 				if aliasName != "" && aliasName != "__type" && aliasName != "__object" && (len(aliasName) == 0 || aliasName[0] != '\xfe') {
 					registrationName := aliasName
 					aliasTypeArgs := alias.TypeArguments()
@@ -853,6 +887,7 @@ func (w *TypeWalker) walkIntersection(t *shimchecker.Type) metadata.Metadata {
 // member is not an object.
 func (w *TypeWalker) tryFlattenIntersection(members []metadata.Metadata) metadata.Metadata {
 	var allProps []metadata.Property
+	var rules *metadata.ObjectRules
 	for _, m := range members {
 		props := w.resolveToObjectProperties(&m)
 		if props == nil {
@@ -863,6 +898,7 @@ func (w *TypeWalker) tryFlattenIntersection(members []metadata.Metadata) metadat
 			}
 		}
 		allProps = append(allProps, props...)
+		rules = mergeObjectRules(rules, w.resolveObjectRules(&m))
 	}
 
 	// Merge properties: later properties win on name conflict (matching typia behavior)
@@ -871,6 +907,7 @@ func (w *TypeWalker) tryFlattenIntersection(members []metadata.Metadata) metadat
 	return metadata.Metadata{
 		Kind:       metadata.KindObject,
 		Properties: merged,
+		Rules:      rules,
 	}
 }
 
@@ -1002,6 +1039,13 @@ func (w *TypeWalker) walkObjectType(t *shimchecker.Type) metadata.Metadata {
 			return metadata.Metadata{Kind: metadata.KindNative, NativeType: name}
 		case "File", "Blob":
 			return metadata.Metadata{Kind: metadata.KindNative, NativeType: name}
+		case "StreamableFile", "Buffer", "Readable":
+			// Binary download types: NestJS StreamableFile, Node Buffer, stream.Readable.
+			// Kept opaque so routes returning them are treated as binary responses.
+			// User types with the same name are walked as usual.
+			if isBinaryDownloadSymbol(sym) {
+				return metadata.Metadata{Kind: metadata.KindNative, NativeType: name}
+			}
 		case "Error":
 			return metadata.Metadata{Kind: metadata.KindNative, NativeType: "Error"}
 		}
@@ -1081,7 +1125,7 @@ func (w *TypeWalker) walkObjectType(t *shimchecker.Type) metadata.Metadata {
 		alias := shimchecker.Type_alias(t)
 		if alias != nil && w.pendingName[t.Id()] == "" {
 			if aliasSym := alias.Symbol(); aliasSym != nil {
-				aliasName := aliasSym.Name
+				aliasName := w.namer.SymbolName(aliasSym)
 				if aliasName != "" && aliasName != "__type" && aliasName != "__object" && (len(aliasName) == 0 || aliasName[0] != '\xfe') {
 					// For generic instantiations (e.g., PaginatedResponse<User>, Omit<Product, 'x'>),
 					// build a composite name so each instantiation gets its own schema.
@@ -1130,7 +1174,21 @@ func (w *TypeWalker) analyzeObjectProperties(t *shimchecker.Type, name string) m
 	props := shimchecker.Checker_getPropertiesOfType(w.checker, t)
 	var properties []metadata.Property
 
+	// Classes extending PartialType()/PickType()/OmitType()/IntersectionType():
+	// inherited properties come from the mapped-type call, not the opaque base type.
+	mappedClassDecl, mappedProps := w.mappedTypeBaseProperties(t)
+
+	// Class methods and getter-only accessors live on the prototype, not in the data.
+	typeSym := t.Symbol()
+	isClass := typeSym != nil && typeSym.Flags&ast.SymbolFlagsClass != 0
+
 	for _, prop := range props {
+		if mappedClassDecl != nil && !isOwnClassMember(prop, mappedClassDecl) {
+			continue
+		}
+		if isClass && (prop.Flags&ast.SymbolFlagsMethod != 0 || prop.Flags&ast.SymbolFlagsAccessor == ast.SymbolFlagsGetAccessor) {
+			continue
+		}
 		propType := shimchecker.Checker_getTypeOfSymbol(w.checker, prop)
 
 		propMeta := w.WalkType(propType)
@@ -1169,19 +1227,43 @@ func (w *TypeWalker) analyzeObjectProperties(t *shimchecker.Type, name string) m
 			ann = extractPropertyAnnotations(prop.ValueDeclaration)
 		}
 
+		// JSON name: explicit JsonName<N> / @jsonName, else the naming policy
+		jsonName := JSONName(prop.Name, w.jsonNaming)
+		if constraints != nil && constraints.JsonName != nil {
+			jsonName = *constraints.JsonName
+		}
+		if jsonName == prop.Name {
+			jsonName = ""
+		}
+
 		properties = append(properties, metadata.Property{
 			Name:          prop.Name,
 			Type:          propMeta,
 			Required:      !isOptional,
-			Readonly:      isReadonly,
+			Readonly:      isReadonly || ann.ReadOnly,
 			ExactOptional: w.exactOptionalPropertyTypes && isOptional,
 			Constraints:   constraints,
 			Description:   ann.Description,
 			WriteOnly:     ann.WriteOnly,
 			Example:       ann.Example,
+			Deprecated:    ann.Deprecated,
+			JsonName:      jsonName,
 		})
 	}
 
+	// Append mapped-type base properties not redeclared by the class itself
+	if mappedClassDecl != nil {
+		own := make(map[string]bool, len(properties))
+		for _, p := range properties {
+			own[p.Name] = true
+		}
+		for _, p := range mappedProps {
+			if !own[p.Name] {
+				properties = append(properties, p)
+			}
+		}
+	}
+
 	result := metadata.Metadata{
 		Kind:       metadata.KindObject,
 		Name:       name,
@@ -1189,7 +1271,6 @@ func (w *TypeWalker) analyzeObjectProperties(t *shimchecker.Type, name string) m
 	}
 
 	// Extract type-level annotations (@strict, @tsgonest-ignore, etc.)
-	typeSym := t.Symbol()
 	if typeSym != nil && typeSym.ValueDeclaration != nil {
 		strictness, ignore := w.extractTypeLevelAnnotations(typeSym.ValueDeclaration)
 		if strictness != "" {
@@ -1200,6 +1281,14 @@ func (w *TypeWalker) analyzeObjectProperties(t *shimchecker.Type, name string) m
 		}
 	}
 
+	// Cross-field rules from JSDoc (@validate, @requireOneOf, @dependentRequired)
+	result.Rules = w.extractJSDocObjectRules(typeSym)
+
+	// Record the declaring class, so instances can be constructed (hydrate)
+	if isClass {
+		result.ClassName, result.ClassModule = exportedClassRef(typeSym)
+	}
+
 	// Check for index signatures
 	indexInfos := shimchecker.Checker_getIndexInfosOfType(w.checker, t)
 	if len(indexInfos) > 0 {
@@ -1215,6 +1304,65 @@ func (w *TypeWalker) analyzeObjectProperties(t *shimchecker.Type, name string) m
 	return result
 }
 
+// exportedClassRef returns the name and source file of a class that can be
+// imported by name, or empty strings for local, default-exported or ambient classes.
+func exportedClassRef(sym *ast.Symbol) (string, string) {
+	decl := sym.ValueDeclaration
+	if decl == nil || decl.Kind != ast.KindClassDeclaration || decl.Parent == nil || decl.Parent.Kind != ast.KindSourceFile {
+		return "", ""
+	}
+	if !ast.HasSyntacticModifier(decl, ast.ModifierFlagsExport) || ast.HasSyntacticModifier(decl, ast.ModifierFlagsDefault|ast.ModifierFlagsAmbient) {
+		return "", ""
+	}
+	sf := ast.GetSourceFileOfNode(decl)
+	if sf == nil || sf.IsDeclarationFile {
+		return "", ""
+	}
+	return sym.Name, sf.FileName()
+}
+
+// isBinaryDownloadSymbol reports whether sym is a binary download type, by the
+// module that declares it: StreamableFile from @nestjs/common, Buffer from
+// Node's type declarations, Readable from the "stream" module.
+func isBinaryDownloadSymbol(sym *ast.Symbol) bool {
+	for _, decl := range sym.Declarations {
+		sf := ast.GetSourceFileOfNode(decl)
+		if sf == nil || !sf.IsDeclarationFile {
+			continue
+		}
+		module := ambientModuleName(decl)
+		switch sym.Name {
+		case "StreamableFile":
+			if strings.Contains(sf.FileName(), "/node_modules/@nestjs/common/") {
+				return true
+			}
+		case "Buffer":
+			if strings.Contains(sf.FileName(), "/node_modules/@types/node/") || module == "buffer" || module == "node:buffer" {
+				return true
+			}
+		case "Readable":
+			if module == "stream" || module == "node:stream" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ambientModuleName returns the name of the `declare module "name"` block
+// enclosing node, or "" outside of one.
+func ambientModuleName(node *ast.Node) string {
+	for p := node.Parent; p != nil; p = p.Parent {
+		if p.Kind != ast.KindModuleDeclaration {
+			continue
+		}
+		if name := p.AsModuleDeclaration().Name(); name != nil && name.Kind == ast.KindStringLiteral {
+			return name.Text()
+		}
+	}
+	return ""
+}
+
 // walkTupleType handles tuple types like [string, number].
 func (w *TypeWalker) walkTupleType(t *shimchecker.Type) metadata.Metadata {
 	typeArgs := shimchecker.Checker_getTypeArguments(w.checker, t)
@@ -1302,7 +1450,7 @@ func (w *TypeWalker) getTypeName(t *shimchecker.Type) string {
 	if sym == nil {
 		return ""
 	}
-	name := sym.Name
+	name := w.namer.SymbolName(sym)
 
 	// Filter out anonymous/structural types
 	if name == "" || name == "__type" || name == "__object" || name == "__function" {
@@ -1331,7 +1479,7 @@ func (w *TypeWalker) WalkTypeNode(node *ast.Node) metadata.Metadata {
 			name := ref.TypeName.Text()
 			if name != "Promise" && name != "Observable" && name != "Array" &&
 				name != "AsyncGenerator" && name != "AsyncIterable" && name != "AsyncIterableIterator" {
-				result.Name = name
+				result.Name = w.referenceSchemaName(ref.TypeName)
 			}
 		}
 	}
@@ -1339,6 +1487,25 @@ func (w *TypeWalker) WalkTypeNode(node *ast.Node) metadata.Metadata {
 	return result
 }
 
+// referenceSchemaName resolves the identifier of a type reference to its schema
+// name, following import aliases so that colliding names resolve to their
+// disambiguated form. Falls back to the identifier text.
+func (w *TypeWalker) referenceSchemaName(typeName *ast.Node) string {
+	if w.namer != nil {
+		if sym := w.checker.GetSymbolAtLocation(typeName); sym != nil {
+			if sym.Flags&ast.SymbolFlagsAlias != 0 {
+				if aliased := w.checker.GetAliasedSymbol(sym); aliased != nil {
+					sym = aliased
+				}
+			}
+			if name := w.namer.SymbolName(sym); name != "" {
+				return name
+			}
+		}
+	}
+	return typeName.Text()
+}
+
 // extractTemplateLiteralPattern converts a template literal type to a regex pattern.
 // e.g., `prefix_${string}` → "^prefix_.*$"
 // e.g., `${string}@${string}.${string}` → "^.*@.*\\..*$"
@@ -1380,22 +1547,78 @@ func (w *TypeWalker) extractTemplateLiteralPattern(t *shimchecker.Type) string {
 }
 
 // buildGenericInstantiationName creates a unique schema name for a generic type
-// instantiation by appending type argument names to the base name.
+// instantiation from the base name and type argument names, formatted with the
+// configured template (see SchemaNamer.GenericName).
 // e.g., PaginatedResponse<UserDto> → ("PaginatedResponse_UserDto", true)
 // Returns ("", false) when any type argument is anonymous/unnameable — callers
 // should inline the type rather than register it under an opaque generated name.
 func (w *TypeWalker) buildGenericInstantiationName(baseName string, typeArgs []*shimchecker.Type) (string, bool) {
-	var sb strings.Builder
-	sb.WriteString(baseName)
+	argNames := make([]string, 0, len(typeArgs))
+	for _, arg := range typeArgs {
+		argName, ok := w.deriveTypeArgName(arg)
+		if !ok {
+			return "", false
+		}
+		argNames = append(argNames, argName)
+	}
+	if name, ok := w.genericOverride(genericInstantiationKey(baseName, argNames)); ok {
+		return name, true
+	}
+	return w.namer.GenericName(baseName, argNames), true
+}
+
+// genericInstantiationKey identifies a generic instantiation independently of
+// the configured naming template.
+func genericInstantiationKey(baseName string, argNames []string) string {
+	return baseName + "<" + strings.Join(argNames, ",") + ">"
+}
+
+// genericOverride returns the explicit @schemaName for a generic instantiation,
+// e.g. `/** @schemaName UserPage */ type UserPage = Page<UserDto>` names every
+// Page<UserDto> occurrence UserPage instead of the templated composite name.
+func (w *TypeWalker) genericOverride(key string) (string, bool) {
+	if w.genericOverrides == nil {
+		// Assign before resolving: deriving argument names below re-enters
+		// buildGenericInstantiationName for nested generics.
+		w.genericOverrides = make(map[string]string)
+		for _, decl := range w.namer.GenericAliases() {
+			resolved := shimchecker.Checker_getTypeFromTypeNode(w.checker, decl.AsTypeAliasDeclaration().Type)
+			if aliasKey, ok := w.instantiationKeyOf(resolved); ok {
+				w.genericOverrides[aliasKey] = w.namer.DeclarationName(decl)
+			}
+		}
+	}
+	name, ok := w.genericOverrides[key]
+	return name, ok
+}
+
+// instantiationKeyOf computes the genericInstantiationKey for a resolved
+// generic instantiation (alias instantiation or generic interface/class reference).
+func (w *TypeWalker) instantiationKeyOf(t *shimchecker.Type) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	var base string
+	var typeArgs []*shimchecker.Type
+	if alias := shimchecker.Type_alias(t); alias != nil && alias.Symbol() != nil && len(alias.TypeArguments()) > 0 {
+		base = w.namer.SymbolName(alias.Symbol())
+		typeArgs = alias.TypeArguments()
+	} else if t.Flags()&shimchecker.TypeFlagsObject != 0 && shimchecker.Type_objectFlags(t)&shimchecker.ObjectFlagsReference != 0 && t.Symbol() != nil {
+		base = w.namer.SymbolName(t.Symbol())
+		typeArgs = shimchecker.Checker_getTypeArguments(w.checker, t)
+	}
+	if base == "" || len(typeArgs) == 0 {
+		return "", false
+	}
+	argNames := make([]string, 0, len(typeArgs))
 	for _, arg := range typeArgs {
 		argName, ok := w.deriveTypeArgName(arg)
 		if !ok {
 			return "", false
 		}
-		sb.WriteString("_")
-		sb.WriteString(argName)
+		argNames = append(argNames, argName)
 	}
-	return sb.String(), true
+	return genericInstantiationKey(base, argNames), true
 }
 
 // deriveTypeArgName returns a human-readable name for a type, for use in
@@ -1473,7 +1696,7 @@ func (w *TypeWalker) deriveTypeArgName(t *shimchecker.Type) (string, bool) {
 		// Named type — use symbol name
 		sym := t.Symbol()
 		if sym != nil && sym.Name != "" && sym.Name != "__type" && sym.Name != "__object" {
-			name := sym.Name
+			name := w.namer.SymbolName(sym)
 			if len(name) > 0 && name[0] == '\xfe' {
 				return "", false
 			}
@@ -1492,7 +1715,7 @@ func (w *TypeWalker) deriveTypeArgName(t *shimchecker.Type) (string, bool) {
 		alias := shimchecker.Type_alias(t)
 		if alias != nil {
 			if aliasSym := alias.Symbol(); aliasSym != nil && aliasSym.Name != "" {
-				name := aliasSym.Name
+				name := w.namer.SymbolName(aliasSym)
 				if name != "__type" && name != "__object" && (len(name) == 0 || name[0] != '\xfe') {
 					aliasArgs := alias.TypeArguments()
 					if len(aliasArgs) > 0 {
@@ -1512,7 +1735,7 @@ func (w *TypeWalker) deriveTypeArgName(t *shimchecker.Type) (string, bool) {
 		alias := shimchecker.Type_alias(t)
 		if alias != nil {
 			if aliasSym := alias.Symbol(); aliasSym != nil && aliasSym.Name != "" {
-				return aliasSym.Name, true
+				return w.namer.SymbolName(aliasSym), true
 			}
 		}
 		// Try to build a name from literal union members (common in Pick<T, 'a' | 'b'>).
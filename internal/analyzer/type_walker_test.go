@@ -311,6 +311,8 @@ func TestWalkObjectReadonlyProps(t *testing.T) {
 	env := setupWalker(t, `
 		interface Config {
 			readonly id: string;
+			/** @readOnly */
+			createdAt: string;
 			name: string;
 		}
 		type T = Config;
@@ -328,6 +330,11 @@ func TestWalkObjectReadonlyProps(t *testing.T) {
 				t.Error("id should be readonly")
 			}
 		}
+		if prop.Name == "createdAt" {
+			if !prop.Readonly {
+				t.Error("createdAt should be readonly (@readOnly)")
+			}
+		}
 		if prop.Name == "name" {
 			if prop.Readonly {
 				t.Error("name should not be readonly")
@@ -755,6 +762,338 @@ func TestWalkOmitType(t *testing.T) {
 	}
 }
 
+// --- NestJS mapped-types (PartialType/PickType/OmitType/IntersectionType) ---
+
+const mappedTypesDecl = `
+	declare module "@nestjs/mapped-types" {
+		export function PartialType(classRef: any): any;
+		export function PickType(classRef: any, keys: readonly string[]): any;
+		export function OmitType(classRef: any, keys: readonly string[]): any;
+		export function IntersectionType(...classRefs: any[]): any;
+	}
+`
+
+func walkMappedTypeDTO(t *testing.T, source string, typeName string) map[string]metadata.Property {
+	t.Helper()
+	env := setupWalkerMultiFile(t, map[string]string{
+		"mapped-types.d.ts": mappedTypesDecl,
+		"dto.ts":            source,
+	}, "dto.ts")
+	defer env.release()
+
+	m, reg := env.walkExportedTypeWithRegistry(t, typeName)
+	if m.Kind == metadata.KindRef {
+		resolved, ok := reg.Types[m.Ref]
+		if !ok {
+			t.Fatalf("ref %q not registered", m.Ref)
+		}
+		m = *resolved
+	}
+	if m.Kind != metadata.KindObject {
+		t.Fatalf("expected object, got %s", m.Kind)
+	}
+	props := make(map[string]metadata.Property, len(m.Properties))
+	for _, p := range m.Properties {
+		props[p.Name] = p
+	}
+	return props
+}
+
+func TestWalkMappedTypes_PartialType(t *testing.T) {
+	props := walkMappedTypeDTO(t, `
+		import { PartialType } from "@nestjs/mapped-types";
+		type MinLength<N extends number> = { readonly __tsgonest_minLength: N };
+
+		export class CreateUserDto {
+			name!: string & MinLength<2>;
+			/** @format email */
+			email!: string;
+		}
+
+		export class UpdateUserDto extends PartialType(CreateUserDto) {
+			reason!: string;
+		}
+	`, "UpdateUserDto")
+
+	if len(props) != 3 {
+		t.Fatalf("expected 3 properties, got %d", len(props))
+	}
+	if props["name"].Required || props["email"].Required {
+		t.Error("PartialType properties should be optional")
+	}
+	if !props["reason"].Required {
+		t.Error("own property 'reason' should stay required")
+	}
+	if c := props["name"].Constraints; c == nil || c.MinLength == nil || *c.MinLength != 2 {
+		t.Errorf("expected branded minLength 2 preserved, got %+v", c)
+	}
+	if c := props["email"].Constraints; c == nil || c.Format == nil || *c.Format != "email" {
+		t.Errorf("expected JSDoc format email preserved, got %+v", c)
+	}
+}
+
+func TestWalkMappedTypes_PickOmitIntersection(t *testing.T) {
+	source := `
+		import { PickType, OmitType, IntersectionType, PartialType } from "@nestjs/mapped-types";
+
+		export class UserDto { id!: string; name!: string; password!: string; }
+		export class AuditDto { createdAt!: string; }
+
+		export class PublicUserDto extends OmitType(UserDto, ["password"] as const) {}
+		export class UserRefDto extends PickType(UserDto, ["id"] as const) {}
+		export class AuditedUserDto extends IntersectionType(PickType(UserDto, ["id", "name"]), PartialType(AuditDto)) {}
+	`
+
+	public := walkMappedTypeDTO(t, source, "PublicUserDto")
+	if len(public) != 2 || public["password"].Name != "" {
+		t.Errorf("OmitType should drop 'password', got %v", public)
+	}
+
+	ref := walkMappedTypeDTO(t, source, "UserRefDto")
+	if len(ref) != 1 || !ref["id"].Required {
+		t.Errorf("PickType should keep only required 'id', got %v", ref)
+	}
+
+	audited := walkMappedTypeDTO(t, source, "AuditedUserDto")
+	if len(audited) != 3 {
+		t.Fatalf("expected 3 properties from IntersectionType, got %d", len(audited))
+	}
+	if !audited["id"].Required || !audited["name"].Required {
+		t.Error("picked properties should stay required")
+	}
+	if audited["createdAt"].Required {
+		t.Error("PartialType(AuditDto) property should be optional")
+	}
+}
+
+// --- Schema name disambiguation ---
+
+func schemaNameCollisionFiles() map[string]string {
+	return map[string]string{
+		"billing/user.dto.ts":  `export interface UserDto { invoiceId: string; }`,
+		"accounts/user.dto.ts": `export interface UserDto { email: string; }`,
+		"legacy/user.dto.ts": `
+			/** @schemaName LegacyUser */
+			export interface UserDto { id: number; }
+		`,
+		"order.dto.ts": `
+			import { UserDto as BillingUser } from "./billing/user.dto";
+			import { UserDto as AccountUser } from "./accounts/user.dto";
+			export interface OrderDto { payer: BillingUser; owner: AccountUser; }
+		`,
+	}
+}
+
+func findDeclaration(t *testing.T, sf *ast.SourceFile, name string) *ast.Node {
+	t.Helper()
+	for _, stmt := range sf.Statements.Nodes {
+		if n := stmt.Name(); n != nil && n.Text() == name {
+			return stmt
+		}
+	}
+	t.Fatalf("declaration %q not found in %s", name, sf.FileName())
+	return nil
+}
+
+// walkPropertyRefs walks an interface with the given walker and returns the
+// $ref name of each property.
+func walkPropertyRefs(t *testing.T, env *walkerEnv, walker *analyzer.TypeWalker, name string) map[string]string {
+	t.Helper()
+	decl := findDeclaration(t, env.sourceFile, name).AsInterfaceDeclaration()
+	sym := env.checker.GetSymbolAtLocation(decl.Name())
+	m := walker.WalkType(shimchecker.Checker_getDeclaredTypeOfSymbol(env.checker, sym))
+	if m.Kind == metadata.KindRef {
+		m = *walker.Registry().Types[m.Ref]
+	}
+	refs := make(map[string]string)
+	for _, p := range m.Properties {
+		refs[p.Name] = p.Type.Ref
+	}
+	return refs
+}
+
+func TestSchemaNamer_PathStrategy(t *testing.T) {
+	env := setupWalkerMultiFile(t, schemaNameCollisionFiles(), "order.dto.ts")
+	defer env.release()
+
+	namer := analyzer.NewSchemaNamer(env.program.GetSourceFiles(), analyzer.SchemaNamingPath, analyzer.ProjectRootDir(env.program))
+	tests := map[string]string{
+		"billing/user.dto.ts":  "BillingUserDto",
+		"accounts/user.dto.ts": "AccountsUserDto",
+		"legacy/user.dto.ts":   "LegacyUser",
+	}
+	for file, want := range tests {
+		decl := findDeclaration(t, env.program.GetSourceFile(file), "UserDto")
+		if got := namer.DeclarationName(decl); got != want {
+			t.Errorf("%s: expected schema name %q, got %q", file, want, got)
+		}
+	}
+	if got := namer.DeclarationName(findDeclaration(t, env.sourceFile, "OrderDto")); got != "OrderDto" {
+		t.Errorf("unique names should stay bare, got %q", got)
+	}
+}
+
+func TestSchemaNamer_NamespaceStrategy(t *testing.T) {
+	env := setupWalker(t, `
+		export namespace Billing { export interface UserDto { invoiceId: string; } }
+		export namespace Accounts { export interface UserDto { email: string; } }
+	`)
+	defer env.release()
+
+	namer := analyzer.NewSchemaNamer(env.program.GetSourceFiles(), analyzer.SchemaNamingNamespace, analyzer.ProjectRootDir(env.program))
+	for _, ns := range []string{"Billing", "Accounts"} {
+		body := findDeclaration(t, env.sourceFile, ns).AsModuleDeclaration().Body.AsModuleBlock()
+		decl := body.Statements.Nodes[0]
+		if got := namer.DeclarationName(decl); got != ns+"UserDto" {
+			t.Errorf("expected %sUserDto, got %q", ns, got)
+		}
+	}
+}
+
+func TestSchemaNamer_SameDirectoryIsRelativeToRoot(t *testing.T) {
+	env := setupWalkerMultiFile(t, map[string]string{
+		"billing/user.dto.ts":   `export interface UserDto { invoiceId: string; }`,
+		"billing/user.model.ts": `export interface UserDto { email: string; }`,
+	}, "billing/user.dto.ts")
+	defer env.release()
+
+	namer := analyzer.NewSchemaNamer(env.program.GetSourceFiles(), analyzer.SchemaNamingPath, analyzer.ProjectRootDir(env.program))
+	tests := map[string]string{
+		"billing/user.dto.ts":   "BillingUserDtoUserDto",
+		"billing/user.model.ts": "BillingUserModelUserDto",
+	}
+	for file, want := range tests {
+		decl := findDeclaration(t, env.program.GetSourceFile(file), "UserDto")
+		if got := namer.DeclarationName(decl); got != want {
+			t.Errorf("%s: expected schema name %q, got %q", file, want, got)
+		}
+	}
+}
+
+func TestSchemaNamer_RestrictToWalkedTypes(t *testing.T) {
+	env := setupWalkerMultiFile(t, map[string]string{
+		"billing/user.dto.ts": `export interface UserDto { invoiceId: string; }`,
+		"legacy/user.dto.ts":  `export interface UserDto { id: number; }`,
+		"order.dto.ts": `
+			import { UserDto } from "./billing/user.dto";
+			export interface OrderDto { payer: UserDto; }
+		`,
+	}, "order.dto.ts")
+	defer env.release()
+
+	namer := analyzer.NewSchemaNamer(env.program.GetSourceFiles(), "", analyzer.ProjectRootDir(env.program))
+	if !namer.Ambiguous() {
+		t.Fatal("expected colliding UserDto declarations to be ambiguous")
+	}
+	walker := analyzer.NewTypeWalker(env.checker)
+	walker.SetSchemaNamer(namer)
+	walkPropertyRefs(t, env, walker, "OrderDto")
+
+	restricted, err := namer.Restrict()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	walker = analyzer.NewTypeWalker(env.checker)
+	walker.SetSchemaNamer(restricted)
+	// legacy/user.dto.ts is not emitted, so it does not rename UserDto
+	if refs := walkPropertyRefs(t, env, walker, "OrderDto"); refs["payer"] != "UserDto" {
+		t.Errorf("expected bare UserDto ref, got %v", refs)
+	}
+}
+
+func TestSchemaNamer_RestrictRejectsDuplicateSchemaName(t *testing.T) {
+	env := setupWalkerMultiFile(t, map[string]string{
+		"billing/user.dto.ts": `
+			/** @schemaName AccountDto */
+			export interface UserDto { invoiceId: string; }
+		`,
+		"accounts/account.dto.ts": `export interface AccountDto { email: string; }`,
+		"order.dto.ts": `
+			import { UserDto } from "./billing/user.dto";
+			import { AccountDto } from "./accounts/account.dto";
+			export interface OrderDto { payer: UserDto; owner: AccountDto; }
+		`,
+	}, "order.dto.ts")
+	defer env.release()
+
+	namer := analyzer.NewSchemaNamer(env.program.GetSourceFiles(), "", analyzer.ProjectRootDir(env.program))
+	walker := analyzer.NewTypeWalker(env.checker)
+	walker.SetSchemaNamer(namer)
+	walkPropertyRefs(t, env, walker, "OrderDto")
+
+	_, err := namer.Restrict()
+	if err == nil || !strings.Contains(err.Error(), `schema name "AccountDto"`) {
+		t.Fatalf("expected duplicate schema name error, got %v", err)
+	}
+}
+
+func TestWalkSchemaNames_RegistryUsesDisambiguatedNames(t *testing.T) {
+	env := setupWalkerMultiFile(t, schemaNameCollisionFiles(), "order.dto.ts")
+	defer env.release()
+
+	walker := analyzer.NewTypeWalker(env.checker)
+	walker.SetSchemaNamer(analyzer.NewSchemaNamer(env.program.GetSourceFiles(), "", analyzer.ProjectRootDir(env.program)))
+
+	refs := walkPropertyRefs(t, env, walker, "OrderDto")
+	if refs["payer"] != "BillingUserDto" || refs["owner"] != "AccountsUserDto" {
+		t.Errorf("expected disambiguated refs, got %v", refs)
+	}
+	for _, name := range []string{"BillingUserDto", "AccountsUserDto"} {
+		if !walker.Registry().Has(name) {
+			t.Errorf("expected %s in registry", name)
+		}
+	}
+	if walker.Registry().Has("UserDto") {
+		t.Error("bare colliding name UserDto should not be registered")
+	}
+}
+
+func TestWalkSchemaNames_GenericTemplate(t *testing.T) {
+	source := `
+interface Page<T> { items: T[]; total: number; }
+interface Pair<A, B> { left: A; right: B; }
+interface UserDto { name: string; }
+interface OrderDto { id: string; }
+
+/** @schemaName UserOrderPair */
+type UserOrderPair = Pair<UserDto, OrderDto>;
+
+interface Listing {
+  users: Page<UserDto>;
+  orders: Page<OrderDto>;
+  pair: Pair<UserDto, OrderDto>;
+}
+`
+	tests := []struct {
+		template, separator string
+		users, orders       string
+	}{
+		{"", "", "Page_UserDto", "Page_OrderDto"},
+		{"{base}Of{args}", "And", "PageOfUserDto", "PageOfOrderDto"},
+		{"{args}{base}", "", "UserDtoPage", "OrderDtoPage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.users, func(t *testing.T) {
+			env := setupWalker(t, source)
+			defer env.release()
+
+			namer := analyzer.NewSchemaNamer(env.program.GetSourceFiles(), "", analyzer.ProjectRootDir(env.program))
+			namer.SetGenericNaming(tt.template, tt.separator)
+			walker := analyzer.NewTypeWalker(env.checker)
+			walker.SetSchemaNamer(namer)
+
+			refs := walkPropertyRefs(t, env, walker, "Listing")
+			if refs["users"] != tt.users || refs["orders"] != tt.orders {
+				t.Errorf("expected %s/%s, got %v", tt.users, tt.orders, refs)
+			}
+			// @schemaName on the alias overrides the template for that instantiation.
+			if refs["pair"] != "UserOrderPair" {
+				t.Errorf("expected pair to use @schemaName UserOrderPair, got %q", refs["pair"])
+			}
+		})
+	}
+}
+
 // --- 2.4l: Index signatures ---
 
 func TestWalkIndexSignatureStringKey(t *testing.T) {
@@ -3111,6 +3450,163 @@ interface ContactDto {
 	}
 }
 
+func TestWalkBrandedValidateAsyncFn(t *testing.T) {
+	// ValidateAsync<{fn, error}> produces __tsgonest_validateAsync (+ _error),
+	// kept apart from the synchronous ValidateFn.
+	env := setupWalker(t, `
+async function isUsernameFree(value: string): Promise<boolean> {
+  return value !== "admin";
+}
+interface SignupDto {
+  username: string & {
+    readonly __tsgonest_validateAsync: typeof isUsernameFree;
+    readonly __tsgonest_validateAsync_error: "Username is taken";
+  };
+}
+`)
+	defer env.release()
+
+	m := resolveWalkedType(t, env, "SignupDto")
+	prop := findProperty(t, m.Properties, "username")
+	if prop.Constraints == nil {
+		t.Fatal("username should have constraints")
+	}
+	if prop.Constraints.ValidateAsyncFn == nil || *prop.Constraints.ValidateAsyncFn != "isUsernameFree" {
+		t.Errorf("expected ValidateAsyncFn 'isUsernameFree', got %v", prop.Constraints.ValidateAsyncFn)
+	}
+	if prop.Constraints.ValidateAsyncModule == nil {
+		t.Error("username should have ValidateAsyncModule set")
+	}
+	if prop.Constraints.ValidateFn != nil {
+		t.Errorf("expected no synchronous ValidateFn, got %q", *prop.Constraints.ValidateFn)
+	}
+	if msg := prop.Constraints.Errors["validateAsync"]; msg != "Username is taken" {
+		t.Errorf("expected per-constraint error for validateAsync, got %q", msg)
+	}
+	assertAtomic(t, prop.Type, "string")
+}
+
+func TestWalkBrandedTransformFns(t *testing.T) {
+	// Transform<typeof fn> tags are collected in declaration order, next to
+	// the built-in transforms.
+	env := setupWalker(t, `
+function normalizePhone(value: unknown): unknown {
+  return value;
+}
+function stripPlus(value: unknown): unknown {
+  return value;
+}
+interface ContactDto {
+  phone: string
+    & { readonly __tsgonest_transform_trim?: true }
+    & { readonly __tsgonest_transform?: typeof normalizePhone }
+    & { readonly __tsgonest_transform?: typeof stripPlus };
+}
+`)
+	defer env.release()
+
+	m := resolveWalkedType(t, env, "ContactDto")
+	prop := findProperty(t, m.Properties, "phone")
+	if prop.Constraints == nil {
+		t.Fatal("phone should have constraints")
+	}
+	if len(prop.Constraints.Transforms) != 1 || prop.Constraints.Transforms[0] != "trim" {
+		t.Errorf("expected transforms [trim], got %v", prop.Constraints.Transforms)
+	}
+	fns := prop.Constraints.TransformFns
+	if len(fns) != 2 || fns[0].Name != "normalizePhone" || fns[1].Name != "stripPlus" {
+		t.Fatalf("expected TransformFns [normalizePhone stripPlus], got %v", fns)
+	}
+	if fns[0].Module == "" {
+		t.Error("normalizePhone should have its module set")
+	}
+	assertAtomic(t, prop.Type, "string")
+}
+
+func TestWalkSensitive(t *testing.T) {
+	// Both the Sensitive branded tag and the @sensitive JSDoc tag mark the
+	// property, next to its other constraints.
+	env := setupWalker(t, `
+interface LoginDto {
+  password: string
+    & { readonly __tsgonest_minLength?: 8 }
+    & { readonly __tsgonest_sensitive?: true };
+  /** @sensitive */
+  otp: string;
+  username: string;
+}
+`)
+	defer env.release()
+
+	m := resolveWalkedType(t, env, "LoginDto")
+	password := findProperty(t, m.Properties, "password")
+	if password.Constraints == nil || password.Constraints.Sensitive == nil || !*password.Constraints.Sensitive {
+		t.Fatalf("password should be sensitive, got %+v", password.Constraints)
+	}
+	if password.Constraints.MinLength == nil || *password.Constraints.MinLength != 8 {
+		t.Errorf("expected minLength 8, got %v", password.Constraints.MinLength)
+	}
+	assertAtomic(t, password.Type, "string")
+	otp := findProperty(t, m.Properties, "otp")
+	if otp.Constraints == nil || otp.Constraints.Sensitive == nil || !*otp.Constraints.Sensitive {
+		t.Errorf("otp should be sensitive, got %+v", otp.Constraints)
+	}
+	username := findProperty(t, m.Properties, "username")
+	if username.Constraints != nil && username.Constraints.Sensitive != nil {
+		t.Errorf("username should not be sensitive")
+	}
+}
+
+func TestWalkJsonName(t *testing.T) {
+	// Both the JsonName branded tag and the @jsonName JSDoc tag set the JSON
+	// name; without a naming policy, other properties keep their TS name.
+	env := setupWalker(t, `
+interface UserDto {
+  firstName: string & { readonly __tsgonest_jsonName?: "first_name" };
+  /** @jsonName last_name */
+  lastName: string;
+  age: number;
+}
+`)
+	defer env.release()
+
+	m := resolveWalkedType(t, env, "UserDto")
+	for name, want := range map[string]string{"firstName": "first_name", "lastName": "last_name", "age": ""} {
+		p := findProperty(t, m.Properties, name)
+		if p.JsonName != want {
+			t.Errorf("%s: expected JSON name %q, got %q", name, want, p.JsonName)
+		}
+	}
+	assertAtomic(t, findProperty(t, m.Properties, "firstName").Type, "string")
+}
+
+func TestWalkGroups(t *testing.T) {
+	// The Groups branded tag (one literal or a union) and the @groups JSDoc
+	// tag set the serialization groups of the property.
+	env := setupWalker(t, `
+interface UserDto {
+  email: string & { readonly __tsgonest_groups?: "admin" | "owner" };
+  /** @groups admin, support */
+  notes: string;
+  id: string;
+}
+`)
+	defer env.release()
+
+	m := resolveWalkedType(t, env, "UserDto")
+	for name, want := range map[string]string{"email": "admin,owner", "notes": "admin,support", "id": ""} {
+		p := findProperty(t, m.Properties, name)
+		var got []string
+		if p.Constraints != nil {
+			got = p.Constraints.Groups
+		}
+		if strings.Join(got, ",") != want {
+			t.Errorf("%s: expected groups %q, got %v", name, want, got)
+		}
+	}
+	assertAtomic(t, findProperty(t, m.Properties, "email").Type, "string")
+}
+
 func TestWalkBrandedValidateFn_NoConstraintOnNonFunction(t *testing.T) {
 	// If __tsgonest_validate is not a function type, it should NOT extract
 	env := setupWalker(t, `
@@ -3128,6 +3624,75 @@ interface BadDto {
 	}
 }
 
+// --- Cross-field object rules ---
+
+func TestWalkObjectRules_IntersectedTags(t *testing.T) {
+	// Rule phantoms intersected with an object apply to the object and are
+	// not merged as properties.
+	env := setupWalker(t, `
+export function isValidPeriod(value: { startDate?: string; endDate?: string }): boolean {
+  return true;
+}
+type ContactDto = { email?: string; phone?: string; startDate?: string; endDate?: string }
+  & { readonly __tsgonest_validate?: typeof isValidPeriod; readonly __tsgonest_validate_error?: "bad period" }
+  & { readonly __tsgonest_requireOneOf?: ["email", "phone"] }
+  & { readonly __tsgonest_dependentRequired?: { endDate: ["startDate"] } };
+`)
+	defer env.release()
+
+	m := resolveWalkedType(t, env, "ContactDto")
+	assertKind(t, m, metadata.KindObject)
+	if len(m.Properties) != 4 {
+		t.Fatalf("expected 4 properties (phantoms removed), got %d", len(m.Properties))
+	}
+	if m.Rules == nil {
+		t.Fatal("expected object rules")
+	}
+	if m.Rules.ValidateFn != "isValidPeriod" || m.Rules.ValidateModule == "" || m.Rules.ValidateError != "bad period" {
+		t.Errorf("unexpected validate rule: %+v", m.Rules)
+	}
+	if len(m.Rules.RequireOneOf) != 1 || strings.Join(m.Rules.RequireOneOf[0].Keys, ",") != "email,phone" {
+		t.Errorf("expected requireOneOf [email phone], got %+v", m.Rules.RequireOneOf)
+	}
+	if deps := m.Rules.DependentRequired["endDate"]; len(deps) != 1 || deps[0] != "startDate" {
+		t.Errorf("expected dependentRequired endDate → [startDate], got %v", m.Rules.DependentRequired)
+	}
+}
+
+func TestWalkObjectRules_JSDoc(t *testing.T) {
+	env := setupWalker(t, `
+export function isValidPeriod(value: RangeDto): boolean {
+  return true;
+}
+/**
+ * @validate isValidPeriod
+ * @requireOneOf email, phone
+ * @dependentRequired endDate startDate
+ */
+interface RangeDto {
+  email?: string;
+  phone?: string;
+  startDate?: string;
+  endDate?: string;
+}
+`)
+	defer env.release()
+
+	m := resolveWalkedType(t, env, "RangeDto")
+	if m.Rules == nil {
+		t.Fatal("expected object rules from JSDoc")
+	}
+	if m.Rules.ValidateFn != "isValidPeriod" {
+		t.Errorf("expected validate fn isValidPeriod, got %q", m.Rules.ValidateFn)
+	}
+	if len(m.Rules.RequireOneOf) != 1 || strings.Join(m.Rules.RequireOneOf[0].Keys, ",") != "email,phone" {
+		t.Errorf("expected requireOneOf [email phone], got %+v", m.Rules.RequireOneOf)
+	}
+	if deps := m.Rules.DependentRequired["endDate"]; len(deps) != 1 || deps[0] != "startDate" {
+		t.Errorf("expected dependentRequired endDate → [startDate], got %v", m.Rules.DependentRequired)
+	}
+}
+
 // --- Phantom Object Non-Registration in WalkNamedType ---
 
 func TestWalkNamedType_PhantomObjectNotRegistered(t *testing.T) {
@@ -7025,3 +7590,96 @@ func TestWalkType_BrandedArrayElementConstraints(t *testing.T) {
 		t.Errorf("ids element: expected pattern '^[0-9a-fA-F]{24}$', got %q", *elem.Constraints.Pattern)
 	}
 }
+
+func TestWalkType_ClassDataMembersAndClassRef(t *testing.T) {
+	env := setupWalker(t, `
+		class Internal {
+			note!: string;
+		}
+
+		export class CreateUserDto {
+			name!: string;
+			internal!: Internal;
+			private _age = 0;
+			get label(): string { return this.name; }
+			get age(): number { return this._age; }
+			set age(v: number) { this._age = v; }
+			greet(): string { return "hi " + this.name; }
+		}
+	`)
+	defer env.release()
+
+	m, reg := env.walkExportedTypeWithRegistry(t, "CreateUserDto")
+	if m.Kind == metadata.KindRef {
+		if resolved := reg.Types[m.Ref]; resolved != nil {
+			m = *resolved
+		}
+	}
+	if m.Kind != metadata.KindObject {
+		t.Fatalf("expected KindObject, got %s", m.Kind)
+	}
+	if m.ClassName != "CreateUserDto" || !strings.HasSuffix(m.ClassModule, ".ts") {
+		t.Errorf("expected class ref CreateUserDto, got %q from %q", m.ClassName, m.ClassModule)
+	}
+
+	props := make(map[string]*metadata.Property, len(m.Properties))
+	for i := range m.Properties {
+		props[m.Properties[i].Name] = &m.Properties[i]
+	}
+	for _, name := range []string{"greet", "label"} {
+		if props[name] != nil {
+			t.Errorf("%s: methods and getter-only accessors should not be properties", name)
+		}
+	}
+	if props["age"] == nil {
+		t.Error("age: accessor pairs are data and should stay a property")
+	}
+
+	internal := props["internal"]
+	if internal == nil {
+		t.Fatal("internal property not found")
+	}
+	im := internal.Type
+	if im.Kind == metadata.KindRef {
+		if resolved := reg.Types[im.Ref]; resolved != nil {
+			im = *resolved
+		}
+	}
+	if im.ClassName != "" {
+		t.Errorf("non-exported class should not be referenced, got %q", im.ClassName)
+	}
+}
+
+func TestWalkType_NestedClassMethodsNotProperties(t *testing.T) {
+	// Classes used as plain property types (not hydrated) drop their
+	// methods and getter-only accessors too.
+	env := setupWalker(t, `
+		export class Money {
+			amount!: number;
+			currency!: string;
+			format(): string { return this.amount + " " + this.currency; }
+			get cents(): number { return this.amount * 100; }
+		}
+		export interface Order {
+			total: Money;
+		}
+	`)
+	m, reg := env.walkExportedTypeWithRegistry(t, "Order")
+	if m.Kind == metadata.KindRef {
+		m = *reg.Types[m.Ref]
+	}
+	if len(m.Properties) != 1 {
+		t.Fatalf("expected 1 property, got %d", len(m.Properties))
+	}
+	money := m.Properties[0].Type
+	if money.Kind == metadata.KindRef {
+		money = *reg.Types[money.Ref]
+	}
+	var names []string
+	for _, p := range money.Properties {
+		names = append(names, p.Name)
+	}
+	if strings.Join(names, ",") != "amount,currency" {
+		t.Errorf("expected properties amount,currency, got %v", names)
+	}
+}
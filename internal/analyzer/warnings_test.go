@@ -378,7 +378,7 @@ func TestControllerAnalyzer_ContentType_StringBody(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -416,7 +416,7 @@ func TestControllerAnalyzer_ContentType_ObjectBody_Default(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -458,7 +458,7 @@ func TestControllerAnalyzer_ContentType_JSDocOverride(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -491,7 +491,7 @@ func TestControllerAnalyzer_Warnings_PathParamObject(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	ca.AnalyzeSourceFile(env.sourceFile)
@@ -527,7 +527,7 @@ func TestControllerAnalyzer_PathParamString_NoWarning(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -587,7 +587,7 @@ func TestControllerAnalyzer_PathParamString_WithGuards_NoWarning(t *testing.T) {
 	`)
 	defer env.release()
 
-	ca, caRelease := analyzer.NewControllerAnalyzer(env.program)
+	ca, caRelease := analyzer.NewControllerAnalyzer(env.program, analyzer.SchemaNamingPath)
 	defer caRelease()
 
 	controllers := ca.AnalyzeSourceFile(env.sourceFile)
@@ -22,7 +22,7 @@ func TestValidateSimpleObject(t *testing.T) {
 
 	code := GenerateCompanionSelective("CreateUserDto", meta, reg, true, false)
 
-	assertContains(t, code, "export function validateCreateUserDto(input)")
+	assertContains(t, code, "export function validateCreateUserDto(input, options)")
 	assertContains(t, code, "typeof input !== \"object\"")
 	assertContains(t, code, "typeof input.name !== \"string\"")
 	assertContains(t, code, "typeof input.age !== \"number\"")
@@ -166,6 +166,28 @@ func TestValidateNativeDate(t *testing.T) {
 	assertContains(t, code, "instanceof Date")
 }
 
+func TestValidateNativeWithoutGlobal(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	meta := &metadata.Metadata{
+		Kind: metadata.KindObject,
+		Properties: []metadata.Property{
+			{Name: "file", Type: metadata.Metadata{Kind: metadata.KindNative, NativeType: "StreamableFile"}, Required: true},
+			{Name: "stream", Type: metadata.Metadata{Kind: metadata.KindNative, NativeType: "Readable"}, Required: true},
+			{Name: "data", Type: metadata.Metadata{Kind: metadata.KindNative, NativeType: "Buffer"}, Required: true},
+		},
+	}
+
+	// StreamableFile and Readable are not globals: referencing them would throw a ReferenceError
+	code := GenerateCompanionSelective("Download", meta, reg, true, false)
+	for _, name := range []string{"StreamableFile", "Readable"} {
+		if strings.Contains(code, "instanceof "+name) {
+			t.Errorf("expected no instanceof %s, got:\n%s", name, code)
+		}
+	}
+	assertContains(t, code, `typeof input.file === "object" && input.file !== null`)
+	assertContains(t, code, "instanceof Buffer")
+}
+
 func TestValidateTuple(t *testing.T) {
 	reg := metadata.NewTypeRegistry()
 	meta := &metadata.Metadata{
@@ -783,6 +805,62 @@ func TestValidateTransformToUpperCase(t *testing.T) {
 	assertContains(t, code, "input.code = input.code.toUpperCase()")
 }
 
+func TestValidateTransformFns(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	reg.Types["ContactDto"] = &metadata.Metadata{
+		Kind: metadata.KindObject,
+		Properties: []metadata.Property{
+			{
+				Name: "phone", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true,
+				Constraints: &metadata.Constraints{
+					Transforms: []string{"trim"},
+					TransformFns: []metadata.FunctionRef{
+						{Name: "normalizePhone", Module: "/src/transforms/phone.ts"},
+						{Name: "stripPlus", Module: "/src/transforms/phone.ts"},
+					},
+				},
+			},
+			{
+				Name: "notes", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindRef, Ref: "Note"}},
+			},
+		},
+	}
+	reg.Types["Note"] = &metadata.Metadata{
+		Kind: metadata.KindObject,
+		Properties: []metadata.Property{
+			{
+				Name: "body", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true,
+				Constraints: &metadata.Constraints{TransformFns: []metadata.FunctionRef{{Name: "stripHtml", Module: "/src/transforms/html.ts"}}},
+			},
+		},
+	}
+
+	code := GenerateCompanionSelective("ContactDto", reg.Types["ContactDto"], reg, true, false)
+	assertContains(t, code, "import { normalizePhone } from \"/src/transforms/phone\"")
+	assertContains(t, code, "import { stripPlus } from \"/src/transforms/phone\"")
+	trim := strings.Index(code, "input.phone = input.phone.trim()")
+	normalize := strings.Index(code, "input.phone = normalizePhone(input.phone)")
+	strip := strings.Index(code, "input.phone = stripPlus(input.phone)")
+	if trim < 0 || normalize < trim || strip < normalize {
+		t.Errorf("expected trim, normalizePhone, stripPlus in order, got:\n%s", code)
+	}
+	// validate runs transforms before the type check too, so transforms may change the type.
+	validateFn := code[strings.Index(code, "export function validateContactDto"):]
+	if strings.Index(validateFn, "normalizePhone(input.phone)") > strings.Index(validateFn, "typeof input.phone !== \"string\"") {
+		t.Errorf("expected transforms before the type check in validate, got:\n%s", validateFn)
+	}
+
+	files := GenerateCompanionFiles("/src/contact.ts", map[string]*metadata.Metadata{"ContactDto": reg.Types["ContactDto"]}, reg, CompanionOptions{})
+	var dts string
+	for _, f := range files {
+		if strings.HasSuffix(f.Path, ".d.ts") {
+			dts = f.Content
+		}
+	}
+	assertContains(t, dts, " * - phone: trim → normalizePhone → stripPlus")
+	assertContains(t, dts, " * - notes[].body: stripHtml")
+}
+
 func TestValidateStartsWith(t *testing.T) {
 	reg := metadata.NewTypeRegistry()
 	sw := "http"
@@ -978,7 +1056,7 @@ func TestValidateCustomErrorMessage_AllConstraints(t *testing.T) {
 			propType:    metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"},
 			errMsg:      "must be a 32-bit integer",
 			contains:    "must be a 32-bit integer",
-			notContains: `"int32"`,
+			notContains: `expected: "int32"`,
 		},
 		{
 			name:        "minLength",
@@ -1034,7 +1112,7 @@ func TestValidateCustomErrorMessage_AllConstraints(t *testing.T) {
 			propType:    metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}},
 			errMsg:      "no duplicates allowed",
 			contains:    "no duplicates allowed",
-			notContains: `"uniqueItems"`,
+			notContains: `expected: "uniqueItems"`,
 		},
 	}
 
@@ -1124,6 +1202,134 @@ func TestValidateDefaultRuntime(t *testing.T) {
 	}
 }
 
+func TestDefaultRuntime_AssertAndNarrowedTypes(t *testing.T) {
+	page, sort := "1", "name"
+	reg := metadata.NewTypeRegistry()
+	reg.Types["Query"] = &metadata.Metadata{
+		Kind: metadata.KindObject,
+		Properties: []metadata.Property{
+			{Name: "page", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number", Optional: true}, Constraints: &metadata.Constraints{Default: &page}},
+			{Name: "sort", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string", Optional: true}, Constraints: &metadata.Constraints{Default: &sort}},
+			{Name: "q", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string", Optional: true}},
+			{Name: "next", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "Query", Optional: true}},
+		},
+	}
+
+	code := GenerateCompanionSelective("Query", reg.Types["Query"], reg, true, false)
+	// assert and the recursive validate path fill defaults before the checks.
+	assertContains(t, code, "export function assertQuery(input)")
+	if n := strings.Count(code, "input.page = 1;"); n < 2 {
+		t.Errorf("expected default assignment in validate and assert, found %d:\n%s", n, code)
+	}
+	assertContains(t, code, `input.sort = "name";`)
+	// is() stays a pure check.
+	isFn := code[strings.Index(code, "export function isQuery"):strings.Index(code, "function _validateQuery")]
+	assertNotContains(t, isFn, "input.page = 1;")
+
+	files := GenerateCompanionFiles("src/query.ts", map[string]*metadata.Metadata{"Query": reg.Types["Query"]}, reg, CompanionOptions{
+		Markers: map[string]map[string]bool{"Query": {"assertParse": true}},
+	})
+	var dts string
+	for _, f := range files {
+		if strings.HasSuffix(f.Path, ".d.ts") {
+			dts = f.Content
+		}
+	}
+	narrowed := `Query & Required<Pick<Query, "page" | "sort">>`
+	assertContains(t, dts, "export declare function isQuery(input: unknown): input is Query;")
+	assertContains(t, dts, "export declare function assertQuery(input: unknown): "+narrowed+";")
+	assertContains(t, dts, "{ success: true; data: "+narrowed+" }")
+	assertContains(t, dts, "export declare function assertParseQuery(input: string, options?: { coerce?: boolean }): "+narrowed+";")
+}
+
+func TestDefaultRuntime_UnionMembers(t *testing.T) {
+	page := "1"
+	withDefault := metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "x", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}, Required: true},
+		{Name: "page", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number", Optional: true}, Constraints: &metadata.Constraints{Default: &page}},
+	}}
+	other := metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "y", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+	}}
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "v", Type: metadata.Metadata{Kind: metadata.KindUnion, UnionMembers: []metadata.Metadata{withDefault, other}}, Required: true},
+	}}
+
+	code := GenerateCompanionSelective("Q", meta, metadata.NewTypeRegistry(), true, false)
+	// Defaults filled by a member trial are logged, and removed again when
+	// the member is rejected, before it is scored.
+	assertContains(t, code, "const _dl1 = [];")
+	assertContains(t, code, `_dl1.push([input.v, "page"]);`)
+	undo := strings.Index(code, "for (const [_o, _k] of _dl1) delete _o[_k];")
+	if score := strings.Index(code, `const _n = ["x", "page"]`); undo < 0 || score < undo {
+		t.Errorf("expected the defaults of a rejected member to be removed before scoring:\n%s", code)
+	}
+	// Members without defaults keep no log.
+	if n := strings.Count(code, "const _dl1 = [];"); n != 2 {
+		t.Errorf("expected a log for the defaulted member of validate and assert, found %d:\n%s", n, code)
+	}
+}
+
+func TestObjectRules_CrossFieldChecks(t *testing.T) {
+	str := metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string", Optional: true}
+	reg := metadata.NewTypeRegistry()
+	meta := &metadata.Metadata{
+		Kind: metadata.KindObject,
+		Properties: []metadata.Property{
+			{Name: "email", Type: str},
+			{Name: "phone", Type: str},
+			{Name: "startDate", Type: str},
+			{Name: "endDate", Type: str},
+		},
+		Rules: &metadata.ObjectRules{
+			ValidateFn:        "isValidPeriod",
+			ValidateModule:    "src/validators/period.ts",
+			ValidateError:     "endDate must be after startDate",
+			RequireOneOf:      []metadata.KeyGroup{{Keys: []string{"email", "phone"}}},
+			DependentRequired: map[string][]string{"endDate": {"startDate"}},
+		},
+	}
+
+	code := GenerateCompanionSelective("Contact", meta, reg, true, false)
+	assertContains(t, code, `import { isValidPeriod } from "./src/validators/period";`)
+
+	// validate: the predicate only runs when the object's own checks passed.
+	assertContains(t, code, "const _ne0 = errors.length;")
+	assertContains(t, code, "const _n0_0 = (input.email !== undefined) + (input.phone !== undefined);")
+	assertContains(t, code, `errors.push({ path: "input", expected: "exactly one of email, phone", received: _n0_0 + " present", code: "requireOneOf", params: { keys: "email, phone" } });`)
+	assertContains(t, code, `errors.push({ path: "input" + ".startDate", expected: "startDate (required with endDate)", received: "undefined", code: "dependentRequired", params: { property: "startDate", with: "endDate" } });`)
+	assertContains(t, code, "if (errors.length === _ne0 && !isValidPeriod(input))")
+	assertContains(t, code, `errors.push({ path: "input", expected: "endDate must be after startDate", received: "object", code: "validate", params: { fn: "isValidPeriod" }, message: "endDate must be after startDate" });`)
+
+	// assert: throws on the first failing rule.
+	assertContains(t, code, "if (input.endDate !== undefined && input.startDate === undefined)")
+	assertContains(t, code, `throw new __e([{path: "input", expected: "endDate must be after startDate", received: "object", code: "validate", params: { fn: "isValidPeriod" }, message: "endDate must be after startDate"}]);`)
+
+	// is: rules are appended after the property checks.
+	isFn := code[strings.Index(code, "export function isContact"):strings.Index(code, "export function validateContact")]
+	assertContains(t, isFn, "((input.email !== undefined) + (input.phone !== undefined)) === 1 && (input.endDate === undefined || input.startDate !== undefined) && isValidPeriod(input))")
+}
+
+func TestObjectRules_RecursiveValidatePath(t *testing.T) {
+	str := metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string", Optional: true}
+	reg := metadata.NewTypeRegistry()
+	reg.Types["Node"] = &metadata.Metadata{
+		Kind: metadata.KindObject,
+		Name: "Node",
+		Properties: []metadata.Property{
+			{Name: "a", Type: str},
+			{Name: "b", Type: str},
+			{Name: "next", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "Node", Optional: true}},
+		},
+		Rules: &metadata.ObjectRules{RequireOneOf: []metadata.KeyGroup{{Keys: []string{"a", "b"}, Error: "Provide a or b"}}},
+	}
+
+	code := GenerateCompanionSelective("Node", reg.Types["Node"], reg, true, false)
+	assertContains(t, code, "function _validateNode(input, _path, errors, _max)")
+	assertContains(t, code, `errors.push({ path: _path, expected: "Provide a or b", received: _n0_0 + " present", code: "requireOneOf", params: { keys: "a, b" }, message: "Provide a or b" });`)
+	assertNotContains(t, code, "_ne0")
+}
+
 func TestValidateTemplateLiteral(t *testing.T) {
 	reg := metadata.NewTypeRegistry()
 	meta := &metadata.Metadata{
@@ -1652,6 +1858,117 @@ func TestValidateNonDiscriminatedUnion_StillUseTryEach(t *testing.T) {
 	assertContains(t, code, "_uv")
 }
 
+func TestValidateUnionBestMatch(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	str := metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}
+	reg.Types["Card"] = &metadata.Metadata{Kind: metadata.KindObject, Name: "Card", Properties: []metadata.Property{
+		{Name: "number", Required: true, Type: str},
+		{Name: "cvc", Required: true, Type: str},
+	}}
+	reg.Types["Bank"] = &metadata.Metadata{Kind: metadata.KindObject, Name: "Bank", Properties: []metadata.Property{
+		{Name: "iban", Required: true, Type: str},
+	}}
+	meta := &metadata.Metadata{
+		Kind: metadata.KindObject,
+		Properties: []metadata.Property{
+			{
+				Name:     "payment",
+				Required: true,
+				Type: metadata.Metadata{
+					Kind: metadata.KindUnion,
+					UnionMembers: []metadata.Metadata{
+						{Kind: metadata.KindRef, Ref: "Card"},
+						{Kind: metadata.KindRef, Ref: "Bank"},
+					},
+				},
+			},
+		},
+	}
+
+	code := GenerateCompanionSelective("Dto", meta, reg, true, false)
+	// Members after the first valid one are skipped
+	assertContains(t, code, "if (!_uv1) {")
+	// Object members are scored by their present keys minus their errors
+	assertContains(t, code, `["number", "cvc"].filter((k) => input.payment[k] !== undefined).length`)
+	assertContains(t, code, `["iban"].filter((k) => input.payment[k] !== undefined).length`)
+	assertContains(t, code, "_ub1 = errors.slice(_ue1);")
+	// The closest member's errors replace the generic union error
+	assertContains(t, code, "errors.push(..._ub1);")
+	assertContains(t, code, `expected: "Card | Bank"`)
+}
+
+func TestAssertUnionBestMatch(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	str := metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}
+	reg.Types["Card"] = &metadata.Metadata{Kind: metadata.KindObject, Name: "Card", Properties: []metadata.Property{
+		{Name: "cardNumber", JsonName: "card_number", Required: true, Type: str},
+		{Name: "cvc", Required: true, Type: str},
+	}}
+	reg.Types["Bank"] = &metadata.Metadata{Kind: metadata.KindObject, Name: "Bank", Properties: []metadata.Property{
+		{Name: "iban", Required: true, Type: str},
+	}}
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "payment", Required: true, Type: metadata.Metadata{Kind: metadata.KindUnion, UnionMembers: []metadata.Metadata{
+			{Kind: metadata.KindRef, Ref: "Card"},
+			{Kind: metadata.KindRef, Ref: "Bank"},
+		}}},
+	}}
+
+	code := GenerateCompanionSelective("Dto", meta, reg, true, false, CompanionGenOptions{Markers: map[string]bool{"assertRequest": true}})
+	assert := code[strings.Index(code, "export function assertDto("):strings.Index(code, "export function assertRequestDto(")]
+	// assert runs the validate union checks and throws their errors under its path
+	assertContains(t, assert, "const _max = Infinity;")
+	assertContains(t, assert, "_ub1 = errors.slice(_ue1);")
+	assertContains(t, assert, `errors[_j].path = "input" + ".payment" + errors[_j].path.slice("input.payment".length);`)
+	assertContains(t, assert, "throw new __e(errors);")
+	assertNotContains(t, code, "complex union")
+	// assertRequest keeps the error of the closest object member, by JSON names
+	request := code[strings.Index(code, "export function assertRequestDto("):]
+	assertContains(t, request, `["card_number", "cvc"].filter((k) => input.payment[k] !== undefined).length`)
+	assertContains(t, request, "_ub1 = _err.errors;")
+	assertContains(t, request, "if (_ub1 !== null) {\n            throw new __e(_ub1);")
+}
+
+func TestValidateDiscriminatedUnion_TagsDiscriminant(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	meta := &metadata.Metadata{
+		Kind: metadata.KindObject,
+		Properties: []metadata.Property{
+			{
+				Name:     "shape",
+				Required: true,
+				Type: metadata.Metadata{
+					Kind: metadata.KindUnion,
+					Discriminant: &metadata.Discriminant{
+						Property: "kind",
+						Mapping:  map[string]int{"circle": 0, "square": 1},
+					},
+					UnionMembers: []metadata.Metadata{
+						{
+							Kind: metadata.KindObject,
+							Properties: []metadata.Property{
+								{Name: "kind", Required: true, Type: metadata.Metadata{Kind: metadata.KindLiteral, LiteralValue: "circle"}},
+								{Name: "radius", Required: true, Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}},
+							},
+						},
+						{
+							Kind: metadata.KindObject,
+							Properties: []metadata.Property{
+								{Name: "kind", Required: true, Type: metadata.Metadata{Kind: metadata.KindLiteral, LiteralValue: "square"}},
+								{Name: "side", Required: true, Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	code := GenerateCompanionSelective("Dto", meta, reg, true, false)
+	assertContains(t, code, `discriminant = { property: "kind", value: "circle" };`)
+	assertContains(t, code, `discriminant = { property: "kind", value: "square" };`)
+}
+
 func TestValidateDiscriminatedUnion_DefaultCaseError(t *testing.T) {
 	reg := metadata.NewTypeRegistry()
 	meta := &metadata.Metadata{
@@ -2176,6 +2493,54 @@ func TestSerializeAtomicUnion(t *testing.T) {
 	assertNotContains(t, code, "JSON.stringify(input.value)")
 }
 
+func TestSerializeShapedUnion(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	str := metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}
+	sensitive := true
+	reg.Types["Account"] = &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "id", Type: str, Required: true},
+		{Name: "password", Type: str, Required: true, WriteOnly: true},
+	}}
+	reg.Types["Card"] = &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "id", Type: str, Required: true},
+		{Name: "cardNumber", JsonName: "card_number", Type: str, Required: true},
+		{Name: "cvv", Type: str, Required: true, Constraints: &metadata.Constraints{Sensitive: &sensitive}},
+	}}
+	account := metadata.Metadata{Kind: metadata.KindRef, Ref: "Account"}
+	card := metadata.Metadata{Kind: metadata.KindRef, Ref: "Card"}
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "method", Type: metadata.Metadata{Kind: metadata.KindUnion, UnionMembers: []metadata.Metadata{account, card}}, Required: true},
+		{Name: "owned", Type: metadata.Metadata{Kind: metadata.KindIntersection, IntersectionMembers: []metadata.Metadata{
+			{Kind: metadata.KindObject, Properties: []metadata.Property{{Name: "owner", Type: str, Required: true}}},
+			{Kind: metadata.KindUnion, UnionMembers: []metadata.Metadata{account, card}},
+		}}, Required: true},
+		{Name: "byName", Type: metadata.Metadata{Kind: metadata.KindObject, IndexSignature: &metadata.IndexSignature{KeyType: str, ValueType: account}}, Required: true},
+	}}
+
+	code := GenerateCompanionSelective("Payment", meta, reg, false, true, CompanionGenOptions{Settings: Settings{SensitiveMask: "***"}})
+
+	// Never JSON.stringify: it would write password, cvv and cardNumber
+	assertNotContains(t, code, "JSON.stringify(")
+	// The member with more required properties is tried first
+	assertContains(t, code, `(typeof input.method === "object" && input.method !== null && !Array.isArray(input.method) && input.method.id !== undefined && input.method.cardNumber !== undefined && input.method.cvv !== undefined) ?`)
+	assertContains(t, code, `\"card_number\":`)
+	assertContains(t, code, `"\"***\""`)
+	assertNotContains(t, code, `\"password\"`)
+	// A & (B | C) is serialized as (A & B) | (A & C)
+	assertContains(t, code, "input.owned.owner !== undefined && input.owned.id !== undefined && input.owned.cardNumber !== undefined")
+	// Records of shaped values are serialized entry by entry
+	assertContains(t, code, "for (var _k1 in input.byName)")
+
+	// Without such properties, complex unions keep JSON.stringify
+	plain := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "v", Type: metadata.Metadata{Kind: metadata.KindUnion, UnionMembers: []metadata.Metadata{
+			{Kind: metadata.KindObject, Properties: []metadata.Property{{Name: "a", Type: str, Required: true}}},
+			{Kind: metadata.KindObject, Properties: []metadata.Property{{Name: "b", Type: str, Required: true}}},
+		}}, Required: true},
+	}}
+	assertContains(t, GenerateCompanionSelective("Plain", plain, reg, false, true), "JSON.stringify(input.v)")
+}
+
 // --- is() function codegen tests ---
 
 func TestGenerateIsFunction_Simple(t *testing.T) {
@@ -2368,8 +2733,8 @@ func TestGenerateValidate_RecursiveOptimized(t *testing.T) {
 	code := GenerateCompanionSelective("Dept", meta, reg, true, false)
 
 	// Should use inner function pattern (no regex path rewrite, no spread)
-	assertContains(t, code, "_validateDept(input, _path, errors)")
-	assertContains(t, code, `_validateDept(input, "input", errors)`)
+	assertContains(t, code, "_validateDept(input, _path, errors, _max)")
+	assertContains(t, code, `_validateDept(input, "input", errors, _max)`)
 	// Should NOT use the old expensive pattern
 	assertNotContains(t, code, ".replace(/^input/")
 	assertNotContains(t, code, "...r.errors")
@@ -2784,7 +3149,7 @@ func TestFormatConstName(t *testing.T) {
 }
 
 func TestGenerateHelpersFile(t *testing.T) {
-	files := GenerateHelpersFile("dist")
+	files := GenerateHelpersFile("dist", CompanionOptions{})
 
 	if len(files) != 2 {
 		t.Fatalf("expected 2 files, got %d", len(files))
@@ -2996,3 +3361,836 @@ func TestHelpersFilePath(t *testing.T) {
 		})
 	}
 }
+
+// --- random<T>() codegen tests ---
+
+func TestRandomFunction_OnlyWhenRequested(t *testing.T) {
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+	}}
+	reg := metadata.NewTypeRegistry()
+
+	without := GenerateCompanionSelective("User", meta, reg, true, true)
+	assertNotContains(t, without, "randomUser")
+	assertNotContains(t, without, "__rng")
+
+	with := GenerateCompanionSelective("User", meta, reg, true, true, CompanionGenOptions{Markers: map[string]bool{"random": true}})
+	assertContains(t, with, "export function randomUser(options)")
+	assertContains(t, with, "return _rnd_User(__rng(options), 0);")
+	assertContains(t, with, "__rng, __rint")
+
+	dts := GenerateMarkerTypes("User", map[string]bool{"random": true})
+	assertContains(t, dts, "export declare function randomUser(options?: { seed?: number; maxDepth?: number }): User;")
+}
+
+func TestRandomFunction_Constraints(t *testing.T) {
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "email", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true, Constraints: &metadata.Constraints{Format: ptrStr("email")}},
+		{Name: "code", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true, Constraints: &metadata.Constraints{Pattern: ptrStr(`^[A-Z]{3}-\d+$`)}},
+		{Name: "name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true, Constraints: &metadata.Constraints{MinLength: ptrInt(2), MaxLength: ptrInt(5), StartsWith: ptrStr("a")}},
+		{Name: "age", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number", Optional: true}, Constraints: &metadata.Constraints{ExclusiveMinimum: ptrFloat(0), Maximum: ptrFloat(120), NumericType: ptrStr("uint32")}},
+		{Name: "score", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}, Required: true, Constraints: &metadata.Constraints{Minimum: ptrFloat(1), Maximum: ptrFloat(10), MultipleOf: ptrFloat(2)}},
+		{Name: "tags", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}}, Required: true, Constraints: &metadata.Constraints{MinItems: ptrInt(1), MaxItems: ptrInt(4), UniqueItems: ptrBool(true)}},
+		{Name: "role", Type: metadata.Metadata{Kind: metadata.KindUnion, UnionMembers: []metadata.Metadata{{Kind: metadata.KindLiteral, LiteralValue: "admin"}, {Kind: metadata.KindLiteral, LiteralValue: "user"}}}, Required: true},
+	}}
+
+	code := GenerateCompanionSelective("Profile", meta, metadata.NewTypeRegistry(), false, false, CompanionGenOptions{Markers: map[string]bool{"random": true}})
+	assertContains(t, code, `email: __rfmt(r, "email")`)
+	assertContains(t, code, `code: (__rrep(r, 3, 3, function () { return __rch(r, [65, 90]); }) + "-" + __rrep(r, 1, 4, function () { return __rch(r, [48, 57]); }))`)
+	assertContains(t, code, `name: "a" + __rstr(r, 1, 4)`)
+	assertContains(t, code, `if (d < r.m && r.n() < 0.5) o.age = __rint(r, 1, 120);`)
+	assertContains(t, code, `score: __rint(r, 1, 5) * 2`)
+	assertContains(t, code, `tags: __rarr(r, d, 1, 4, true, function () { return __rstr(r, 5, 12); })`)
+	assertContains(t, code, `case 0: return "admin"; case 1: return "user";`)
+}
+
+func TestRandomFunction_RecursiveRef(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	node := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "value", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}, Required: true},
+		{Name: "children", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindRef, Ref: "TreeNode"}}, Required: true},
+	}}
+	reg.Register("TreeNode", node)
+
+	code := GenerateCompanionSelective("TreeNode", node, reg, false, false, CompanionGenOptions{Markers: map[string]bool{"random": true}})
+	if strings.Count(code, "function _rnd_TreeNode(r, d)") != 1 {
+		t.Errorf("expected exactly one TreeNode generator, got:\n%s", code)
+	}
+	// Recursion goes through the named generator with an increased depth,
+	// and __rarr falls back to minItems once the depth limit is reached.
+	assertContains(t, code, "children: __rarr(r, d, 0, 3, false, function () { return _rnd_TreeNode(r, d + 1); })")
+}
+
+func TestGenerateHelpers_RandomFormatsCoverValidatedFormats(t *testing.T) {
+	for name, pattern := range formatRegexes {
+		if pattern == "" {
+			continue
+		}
+		if _, ok := randomFormatCases[name]; !ok {
+			t.Errorf("format %q has no random generator", name)
+		}
+	}
+	helpers := GenerateHelpers()
+	assertContains(t, helpers, "export function __rng(o)")
+	assertContains(t, helpers, `case "uuid": return`)
+	assertContains(t, GenerateHelpersTypes(), "export declare function __rfmt(r: __Rng, format: string): string;")
+}
+
+func TestParseFunctions_OnlyWhenRequested(t *testing.T) {
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+	}}
+	reg := metadata.NewTypeRegistry()
+
+	without := GenerateCompanionSelective("Event", meta, reg, true, true)
+	assertNotContains(t, without, "parseEvent")
+
+	with := GenerateCompanionSelective("Event", meta, reg, true, true, CompanionGenOptions{Markers: map[string]bool{"parse": true}})
+	assertContains(t, with, "export function parseEvent(input, options)")
+	assertContains(t, with, "data = JSON.parse(input);")
+	assertContains(t, with, `expected: "valid JSON"`)
+	assertContains(t, with, "return validateEvent(data, options);")
+	assertNotContains(t, with, "assertParseEvent")
+	// No Date/bigint positions: nothing to revive.
+	assertNotContains(t, with, "_rev_")
+
+	// Parsing builds on the validators, so it is skipped without validation.
+	noValidation := GenerateCompanionSelective("Event", meta, reg, false, true, CompanionGenOptions{Markers: map[string]bool{"parse": true}})
+	assertNotContains(t, noValidation, "parseEvent")
+
+	dts := GenerateMarkerTypes("Event", map[string]bool{"parse": true, "assertParse": true})
+	assertContains(t, dts, "export declare function parseEvent(input: string, options?: { coerce?: boolean; maxErrors?: number; failFast?: boolean }): { success: true; data: Event }")
+	assertContains(t, dts, "export declare function assertParseEvent(input: string, options?: { coerce?: boolean }): Event;")
+}
+
+func TestParseFunctions_RevivesDateAndBigint(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	reg.Types["Node"] = &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "at", Type: metadata.Metadata{Kind: metadata.KindNative, NativeType: "Date"}, Required: true},
+		{Name: "children", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindRef, Ref: "Node"}}, Required: true},
+	}}
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "id", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "bigint"}, Required: true},
+		{Name: "label", Type: metadata.Metadata{Kind: metadata.KindUnion, UnionMembers: []metadata.Metadata{
+			{Kind: metadata.KindAtomic, Atomic: "string"},
+			{Kind: metadata.KindNative, NativeType: "Date"},
+		}}, Required: true},
+		{Name: "root", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "Node"}, Required: true},
+	}}
+
+	code := GenerateCompanionSelective("Event", meta, reg, true, false, CompanionGenOptions{Markers: map[string]bool{"assertParse": true}})
+	assertContains(t, code, "export function assertParseEvent(input, options)")
+	assertContains(t, code, "throw new __e(errors);")
+	assertContains(t, code, "if (!options || options.coerce !== false) {")
+	assertContains(t, code, "data = _rev_Event(data);")
+	assertContains(t, code, "return assertEvent(data);")
+	assertContains(t, code, `if (typeof v.id === "string" && /^-?\d+$/.test(v.id) || typeof v.id === "number" && Number.isInteger(v.id)) v.id = BigInt(v.id);`)
+	assertContains(t, code, "if (v.root !== undefined) v.root = _rev_Node(v.root);")
+	assertContains(t, code, "function _rev_Node(v)")
+	assertContains(t, code, "if (v.children[_i1] !== undefined) v.children[_i1] = _rev_Node(v.children[_i1]);")
+	// string | Date is ambiguous: the string stays a string.
+	assertNotContains(t, code, "new Date(v.label)")
+}
+
+func TestParseFunctions_RenamesJSONNames(t *testing.T) {
+	str := metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}
+	reg := metadata.NewTypeRegistry()
+	reg.Types["Address"] = &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "zipCode", JsonName: "zip_code", Type: str, Required: true},
+	}}
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "firstName", JsonName: "first_name", Type: str, Required: true},
+		{Name: "home", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "Address"}, Required: true},
+	}}
+
+	code := GenerateCompanionSelective("User", meta, reg, true, false, CompanionGenOptions{Markers: map[string]bool{"parse": true}})
+	assertContains(t, code, "  _names_User(data);\n  return validateUser(data, options);")
+	assertContains(t, code, "delete v.firstName;\n    if (\"first_name\" in v) {\n      v.firstName = v.first_name;\n      delete v.first_name;")
+	assertContains(t, code, "_names_Address(v.home);")
+	assertContains(t, code, "v.zipCode = v.zip_code;")
+
+	plain := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{{Name: "name", Type: str, Required: true}}}
+	assertNotContains(t, GenerateCompanionSelective("Plain", plain, reg, true, false, CompanionGenOptions{Markers: map[string]bool{"parse": true}}), "_names_")
+}
+
+func TestEqualsFunctions_RejectUnknownKeys(t *testing.T) {
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+		{Name: "address", Type: metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+			{Name: "city", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+		}}, Required: true},
+		{Name: "labels", Type: metadata.Metadata{Kind: metadata.KindObject, IndexSignature: &metadata.IndexSignature{
+			KeyType:   metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"},
+			ValueType: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"},
+		}}, Required: true},
+	}}
+	reg := metadata.NewTypeRegistry()
+
+	without := GenerateCompanionSelective("User", meta, reg, true, false)
+	assertNotContains(t, without, "equalsUser")
+	assertNotContains(t, without, "known property")
+
+	code := GenerateCompanionSelective("User", meta, reg, true, false, CompanionGenOptions{Markers: map[string]bool{"equals": true, "validateEquals": true}})
+	assertContains(t, code, "export function equalsUser(input)")
+	assertContains(t, code, `Object.keys(input).every(_k => _k === "name" || _k === "address" || _k === "labels")`)
+	assertContains(t, code, `Object.keys(input.address).every(_k => _k === "city")`)
+	assertContains(t, code, "export function validateEqualsUser(input, options)")
+	// Known-key sets are module constants, not allocated per key.
+	assertContains(t, code, `const _ek_User_0 = new Set(["name", "address", "labels"]);`)
+	assertContains(t, code, `const _ek_User_1 = new Set(["city"]);`)
+	assertContains(t, code, "if (!_ek_User_0.has(_k0))")
+	assertNotContains(t, code, "new Set([\"city\"]).has")
+	assertContains(t, code, `errors.push({ path: "input" + "." + _k0, expected: "known property", received: _k0, code: "unknownProperty" });`)
+	assertContains(t, code, `errors.push({ path: "input.address" + "." + _k1, expected: "known property", received: _k1, code: "unknownProperty" });`)
+	// Index signatures accept any key.
+	assertNotContains(t, code, "Object.keys(input.labels).every")
+	// The regular validators keep accepting extra keys.
+	assertContains(t, code, "export function isUser(input)")
+	if n := strings.Count(code, "known property"); n != 2 {
+		t.Errorf("expected 2 unknown-key checks, got %d", n)
+	}
+
+	dts := GenerateMarkerTypes("User", map[string]bool{"equals": true, "validateEquals": true})
+	assertContains(t, dts, "export declare function equalsUser(input: unknown): input is User;")
+	assertContains(t, dts, "export declare function validateEqualsUser(input: unknown, options?: { maxErrors?: number; failFast?: boolean }): { success: true; data: User }")
+}
+
+func TestEqualsFunctions_IntersectionAndRecursion(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	reg.Types["Base"] = &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "id", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+	}}
+	reg.Types["Tree"] = &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "node", Type: metadata.Metadata{Kind: metadata.KindIntersection, IntersectionMembers: []metadata.Metadata{
+			{Kind: metadata.KindRef, Ref: "Base"},
+			{Kind: metadata.KindObject, Properties: []metadata.Property{{Name: "label", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true}}},
+		}}, Required: true},
+		{Name: "children", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindRef, Ref: "Tree"}}, Required: true},
+	}}
+
+	code := GenerateCompanionSelective("Tree", reg.Types["Tree"], reg, true, false, CompanionGenOptions{Markers: map[string]bool{"equals": true, "validateEquals": true}})
+	// Intersection members are checked once against their combined keys.
+	assertContains(t, code, `_k === "id" || _k === "label"`)
+	assertNotContains(t, code, `Object.keys(input.node).every(_k => _k === "id")`)
+	assertContains(t, code, `new Set(["id", "label"])`)
+	// Recursion goes through the exact variants.
+	assertContains(t, code, "input.children.every(_v1 => equalsTree(_v1))")
+	assertContains(t, code, "function _validateEqualsTree(input, _path, errors, _max)")
+	assertContains(t, code, "_validateEqualsTree(input.children[i1], _path + \".children\" + \"[\" + i1 + \"]\", errors, _max);")
+	assertNotContains(t, code, "isTree(_v1) && equals")
+}
+
+func TestPruneAndCloneFunctions(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	reg.Types["Node"] = &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "at", Type: metadata.Metadata{Kind: metadata.KindNative, NativeType: "Date"}, Required: true},
+		{Name: "children", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindRef, Ref: "Node"}}, Required: true},
+	}}
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+		{Name: "labels", Type: metadata.Metadata{Kind: metadata.KindObject, IndexSignature: &metadata.IndexSignature{
+			KeyType:   metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"},
+			ValueType: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"},
+		}}, Required: true},
+		{Name: "root", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "Node"}, Required: true},
+	}}
+
+	without := GenerateCompanionSelective("Tree", meta, reg, true, true)
+	assertNotContains(t, without, "pruneTree")
+	assertNotContains(t, without, "cloneTree")
+
+	code := GenerateCompanionSelective("Tree", meta, reg, false, false, CompanionGenOptions{Markers: map[string]bool{"prune": true, "clone": true}})
+
+	assertContains(t, code, "export function pruneTree(input)")
+	assertContains(t, code, `const _pk_Tree_0 = new Set(["name", "labels", "root"]);`)
+	assertContains(t, code, `if (!_pk_Tree_0.has(_k0)) delete v[_k0];`)
+	assertContains(t, code, "_prune_Node(v.root);")
+	assertContains(t, code, "_prune_Node(v.children[_i1]);")
+	// Index signature objects keep their keys.
+	assertNotContains(t, code, "delete v.labels")
+
+	assertContains(t, code, "export function cloneTree(input)")
+	assertContains(t, code, "labels: { ...input.labels }")
+	assertContains(t, code, "(input.root.at instanceof Date ? new Date(input.root.at.getTime()) : input.root.at)")
+	assertContains(t, code, "children: input.root.children.map(_v2 => _clone_Node(_v2))")
+	assertContains(t, code, "function _clone_Node(input)")
+	assertContains(t, code, "children: input.children.map(_v1 => _clone_Node(_v1))")
+
+	dts := GenerateMarkerTypes("Tree", map[string]bool{"prune": true, "clone": true})
+	assertContains(t, dts, "export declare function pruneTree(input: Tree): void;")
+	assertContains(t, dts, "export declare function cloneTree(input: Tree): Tree;")
+}
+
+func TestPruneFunction_IntersectionAndDiscriminatedUnion(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	reg.Types["Base"] = &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "id", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+	}}
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "entity", Type: metadata.Metadata{Kind: metadata.KindIntersection, IntersectionMembers: []metadata.Metadata{
+			{Kind: metadata.KindRef, Ref: "Base"},
+			{Kind: metadata.KindObject, Properties: []metadata.Property{{Name: "label", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true}}},
+		}}, Required: true},
+		{Name: "shape", Type: metadata.Metadata{
+			Kind: metadata.KindUnion,
+			UnionMembers: []metadata.Metadata{
+				{Kind: metadata.KindObject, Properties: []metadata.Property{
+					{Name: "kind", Type: metadata.Metadata{Kind: metadata.KindLiteral, LiteralValue: "circle"}, Required: true},
+					{Name: "radius", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}, Required: true},
+				}},
+				{Kind: metadata.KindObject, Properties: []metadata.Property{
+					{Name: "kind", Type: metadata.Metadata{Kind: metadata.KindLiteral, LiteralValue: "square"}, Required: true},
+					{Name: "side", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}, Required: true},
+				}},
+			},
+			Discriminant: &metadata.Discriminant{Property: "kind", Mapping: map[string]int{"circle": 0, "square": 1}},
+		}, Required: true},
+	}}
+
+	code := GenerateCompanionSelective("Doc", meta, metadata.NewTypeRegistry(), false, false, CompanionGenOptions{Markers: map[string]bool{"prune": true}})
+	// Without the Base definition the intersection key set is unknown: keep everything.
+	assertNotContains(t, code, "delete v.entity")
+
+	code = GenerateCompanionSelective("Doc", meta, reg, false, false, CompanionGenOptions{Markers: map[string]bool{"prune": true}})
+	assertContains(t, code, `const _pk_Doc_1 = new Set(["id", "label"]);`)
+	assertContains(t, code, `if (!_pk_Doc_1.has(_k1)) delete v.entity[_k1];`)
+	assertContains(t, code, "switch (v.shape.kind) {")
+	assertContains(t, code, `case "circle":`)
+	assertContains(t, code, `const _pk_Doc_2 = new Set(["kind", "radius"]);`)
+	assertContains(t, code, `if (!_pk_Doc_2.has(_k2)) delete v.shape[_k2];`)
+	assertContains(t, code, `if (!_pk_Doc_3.has(_k2)) delete v.shape[_k2];`)
+}
+
+func TestHydrateFunction_ConstructsClassInstances(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	reg.Types["AddressDto"] = &metadata.Metadata{Kind: metadata.KindObject, ClassName: "AddressDto", ClassModule: "/src/address.dto.ts", Properties: []metadata.Property{
+		{Name: "city", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+	}}
+	meta := &metadata.Metadata{Kind: metadata.KindObject, ClassName: "CreateUserDto", ClassModule: "/src/user.dto.ts", Properties: []metadata.Property{
+		{Name: "name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+		{Name: "address", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "AddressDto"}, Required: true},
+		{Name: "previous", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindRef, Ref: "AddressDto"}}, Required: false},
+		{Name: "meta", Type: metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+			{Name: "source", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+		}}, Required: true},
+	}}
+
+	without := GenerateCompanionSelective("CreateUserDto", meta, reg, true, true)
+	assertNotContains(t, without, "hydrateCreateUserDto")
+	assertNotContains(t, without, "import { AddressDto }")
+
+	code := GenerateCompanionSelective("CreateUserDto", meta, reg, true, false, CompanionGenOptions{Markers: map[string]bool{"hydrate": true}})
+	assertContains(t, code, `import { AddressDto } from "/src/address.dto";`)
+	assertContains(t, code, `import { CreateUserDto } from "/src/user.dto";`)
+	assertContains(t, code, "export function hydrateCreateUserDto(input)")
+	assertContains(t, code, "return _hyd_CreateUserDto(assertCreateUserDto(input));")
+	assertContains(t, code, "if (v.address !== undefined) v.address = _hyd_AddressDto(v.address);")
+	assertContains(t, code, "if (v.previous[_i1] !== undefined) v.previous[_i1] = _hyd_AddressDto(v.previous[_i1]);")
+	assertContains(t, code, "v = Object.assign(Object.create(CreateUserDto.prototype), v);")
+	assertContains(t, code, "v = Object.assign(Object.create(AddressDto.prototype), v);")
+	// Plain nested objects stay plain.
+	assertNotContains(t, code, "v.meta =")
+
+	// Class modules resolve through ImportPath when set.
+	code = GenerateCompanionSelective("CreateUserDto", meta, reg, true, false, CompanionGenOptions{
+		Markers:    map[string]bool{"hydrate": true},
+		ImportPath: outputImportPath("/dist/user.dto.CreateUserDto.tsgonest.js", map[string]string{"/src/address.dto.ts": "/dist/shared/address.dto.ts"}),
+	})
+	assertContains(t, code, `import { AddressDto } from "./shared/address.dto.js";`)
+
+	dts := GenerateMarkerTypes("CreateUserDto", map[string]bool{"hydrate": true})
+	assertContains(t, dts, "export declare function hydrateCreateUserDto(input: unknown): CreateUserDto;")
+}
+
+func TestHydrateFunction_PlainTypesAndAmbiguousUnions(t *testing.T) {
+	classA := metadata.Metadata{Kind: metadata.KindObject, ClassName: "A", ClassModule: "/src/a.ts", Properties: []metadata.Property{
+		{Name: "a", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+	}}
+	plain := metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "b", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+	}}
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "either", Type: metadata.Metadata{Kind: metadata.KindUnion, UnionMembers: []metadata.Metadata{classA, plain}}, Required: true},
+		{Name: "maybe", Type: metadata.Metadata{Kind: metadata.KindUnion, UnionMembers: []metadata.Metadata{classA, {Kind: metadata.KindAtomic, Atomic: "string"}}}, Required: true},
+	}}
+
+	code := GenerateCompanionSelective("Holder", meta, metadata.NewTypeRegistry(), true, false, CompanionGenOptions{Markers: map[string]bool{"hydrate": true}})
+	assertContains(t, code, "v.maybe = Object.assign(Object.create(A.prototype), v.maybe);")
+	assertNotContains(t, code, "v.either =")
+
+	// Without class positions hydrate is assert.
+	code = GenerateCompanionSelective("Plain", &plain, metadata.NewTypeRegistry(), true, false, CompanionGenOptions{Markers: map[string]bool{"hydrate": true}})
+	assertContains(t, code, "return assertPlain(input);")
+	assertNotContains(t, code, "_hyd_")
+}
+
+func TestAsyncFunctions_RunAsyncValidators(t *testing.T) {
+	fn, module := "isUsernameFree", "/src/validators.ts"
+	reg := metadata.NewTypeRegistry()
+	reg.Types["Member"] = &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "email", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true,
+			Constraints: &metadata.Constraints{ValidateAsyncFn: &fn, ValidateAsyncModule: &module, Errors: map[string]string{"validateAsync": "email taken"}}},
+	}}
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "username", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true,
+			Constraints: &metadata.Constraints{ValidateAsyncFn: &fn, ValidateAsyncModule: &module}},
+		{Name: "members", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindRef, Ref: "Member"}}, Required: false},
+	}}
+
+	without := GenerateCompanionSelective("CreateTeamDto", meta, reg, true, false)
+	assertNotContains(t, without, "validateAsyncCreateTeamDto")
+	// Sync validation ignores async validators.
+	assertNotContains(t, without, "isUsernameFree(")
+
+	code := GenerateCompanionSelective("CreateTeamDto", meta, reg, true, false, CompanionGenOptions{Markers: map[string]bool{"assertAsync": true}})
+	assertContains(t, code, `import { isUsernameFree } from "/src/validators";`)
+	assertContains(t, code, "export async function validateAsyncCreateTeamDto(input, options)")
+	assertContains(t, code, "export async function assertAsyncCreateTeamDto(input)")
+	assertContains(t, code, "const data = assertCreateTeamDto(input);")
+	assertContains(t, code, "for (const _err of await Promise.all(_p))")
+	assertContains(t, code, `_p.push(Promise.resolve(isUsernameFree(_v)).then((_ok) => _ok ? null : { path: _path + ".username", expected: "validateAsync(isUsernameFree)", received: "" + _v, code: "validateAsync", params: { fn: "isUsernameFree" } }));`)
+	assertContains(t, code, `_va_Member(v.members[_i1], _path + ".members" + "[" + _i1 + "]", _p);`)
+	assertContains(t, code, `expected: "email taken"`)
+
+	if !HasAsyncValidators(meta, reg) {
+		t.Error("expected HasAsyncValidators to report the async validators")
+	}
+	if HasAsyncValidators(&reg.Types["Member"].Properties[0].Type, reg) {
+		t.Error("expected no async validators on an atomic type")
+	}
+
+	dts := GenerateMarkerTypes("CreateTeamDto", map[string]bool{"assertAsync": true})
+	assertContains(t, dts, "export declare function assertAsyncCreateTeamDto(input: unknown): Promise<CreateTeamDto>;")
+	assertContains(t, dts, "export declare function validateAsyncCreateTeamDto(input: unknown, options?: { maxErrors?: number; failFast?: boolean }): Promise<")
+}
+
+func TestCustomFormats(t *testing.T) {
+	settings := Settings{Formats: []CustomFormat{
+		{Name: "slug", Pattern: "^[a-z0-9]+(?:-[a-z0-9]+)*$"},
+		{Name: "e164", Pattern: `^\+[1-9][0-9]{1,14}$`, Flags: "u"},
+		{Name: "iban", Module: "/app/dist/validators/iban.js", Export: "isIban"},
+		{Name: "email", Pattern: "^ignored$"},
+		{Name: "date_time", Pattern: "^[0-9]+$"},
+	}}
+
+	slug, iban, email := "slug", "iban", "email"
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "handle", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true, Constraints: &metadata.Constraints{Format: &slug}},
+		{Name: "account", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true, Constraints: &metadata.Constraints{Format: &iban}},
+		{Name: "contact", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true, Constraints: &metadata.Constraints{Format: &email}},
+	}}
+
+	code := GenerateCompanionSelective("PayeeDto", meta, metadata.NewTypeRegistry(), true, false, CompanionGenOptions{Settings: settings})
+	assertContains(t, code, `import { __e, __cfmt_iban, __cfmt_slug } from "./_tsgonest_helpers.js";`)
+	assertContains(t, code, "if (!__cfmt_slug.test(input.handle))")
+	assertContains(t, code, "__cfmt_iban.test(input.account)")
+	// Built-in formats are not overridden.
+	assertNotContains(t, code, "ignored")
+	assertNotContains(t, code, "__cfmt_e164")
+
+	helpers := GenerateHelpersFile("/app/dist", CompanionOptions{Settings: settings})[0].Content
+	assertContains(t, helpers, `import { isIban } from "./validators/iban.js";`)
+	assertContains(t, helpers, "export const __cfmt_iban = { test: isIban };")
+	assertContains(t, helpers, "export const __cfmt_slug = /^[a-z0-9]+(?:-[a-z0-9]+)*$/;")
+	assertContains(t, helpers, `export const __cfmt_e164 = /^\+[1-9][0-9]{1,14}$/u;`)
+	// A custom name matching a built-in constant name keeps its own constant.
+	assertContains(t, helpers, "export const __cfmt_date_time = /^[0-9]+$/;")
+	if n := strings.Count(helpers, "export const __fmt_date_time ="); n != 1 {
+		t.Errorf("expected the built-in date-time constant once, found %d:\n%s", n, helpers)
+	}
+
+	dts := generateHelpersTypes(&settings)
+	assertContains(t, dts, "export declare const __cfmt_iban: { test(value: string): boolean };")
+	assertContains(t, dts, "export declare const __cfmt_slug: RegExp;")
+	assertNotContains(t, GenerateHelpersTypes(), "__cfmt_slug")
+
+	cjs := GenerateHelpersFile("/app/dist", CompanionOptions{ModuleFormat: "cjs", Settings: settings})[0].Content
+	assertContains(t, cjs, `const { isIban } = require("./validators/iban.js");`)
+}
+
+func TestErrorCodes(t *testing.T) {
+	minLen, maxItems := 3, 5
+	msg := "Too short"
+	format := "email"
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true,
+			Constraints: &metadata.Constraints{MinLength: &minLen, Format: &format}},
+		{Name: "nick", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string", Optional: true},
+			Constraints: &metadata.Constraints{MinLength: &minLen, Errors: map[string]string{"minLength": msg}}},
+		{Name: "tags", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}}, Required: true,
+			Constraints: &metadata.Constraints{MaxItems: &maxItems}},
+	}}
+
+	code := GenerateCompanionSelective("UserDto", meta, metadata.NewTypeRegistry(), true, false)
+	assertContains(t, code, `errors.push({ path: "input.name", expected: "string", received: "undefined", code: "required" });`)
+	assertContains(t, code, `errors.push({ path: "input.name", expected: "minLength 3", received: "length " + input.name.length, code: "minLength", params: { min: 3 } });`)
+	assertContains(t, code, `code: "format", params: { format: "email" } });`)
+	assertContains(t, code, `errors.push({ path: "input.nick", expected: "Too short", received: "length " + input.nick.length, code: "minLength", params: { min: 3 }, message: "Too short" });`)
+	assertContains(t, code, `code: "maxItems", params: { max: 5 } });`)
+	// Type mismatches carry the "type" code.
+	assertContains(t, code, `errors.push({ path: "input.name", expected: "string", received: typeof input.name, code: "type" });`)
+	assertContains(t, code, `throw new __e([{path: "input" + ".tags", expected: "array", received: typeof input.tags, code: "type"}]);`)
+	// assert carries the same fields.
+	assertContains(t, code, `throw new __e([{path: "input" + ".name", expected: "minLength 3", received: "length " + input.name.length, code: "minLength", params: { min: 3 }}]);`)
+}
+
+func TestLocaleHelpers(t *testing.T) {
+	settings := Settings{Locales: map[string]map[string]string{
+		"de":    {"minLength": "mindestens {min} Zeichen"},
+		"pt-BR": {"required": "obrigatório"},
+	}, DefaultLocale: "de"}
+
+	helpers := generateHelpers("", &settings)
+	assertContains(t, helpers, `export const __msgs = {"de":{"minLength":"mindestens {min} Zeichen"},"pt-br":{"required":"obrigatório"}};`)
+	assertContains(t, helpers, `const __dl = "de";`)
+	assertContains(t, helpers, "export function __lz(errors, locale)")
+	assertContains(t, helpers, "localize(locale) {\n    return __lz(this.errors, locale);")
+
+	dts := GenerateHelpersTypes()
+	assertContains(t, dts, "export declare const __msgs: Record<string, Record<string, string>>;")
+	assertContains(t, dts, "localize(locale?: string | null)")
+
+	helpers = GenerateHelpers()
+	assertContains(t, helpers, "export const __msgs = {};")
+	assertContains(t, helpers, "const __dl = null;")
+}
+
+func TestValidateMaxErrors(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "tags", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}}, Required: true},
+		{Name: "children", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindRef, Ref: "Node"}}, Required: true},
+	}}
+	reg.Types["Node"] = meta
+
+	code := GenerateCompanionSelective("Node", meta, reg, true, false)
+	assertContains(t, code, "const _max = options === undefined || options === null ? Infinity : options.failFast ? 1 : options.maxErrors > 0 ? options.maxErrors : Infinity;")
+	// Recursive calls and error-producing loops exit once the limit is reached.
+	assertContains(t, code, "function _validateNode(input, _path, errors, _max) {\n  if (errors.length >= _max) {\n    return;\n  }")
+	assertContains(t, code, "for (let i1 = 0; i1 < input.tags.length; i1++) {\n            if (errors.length >= _max) break;")
+	// Property checks stop at the first failing property once the limit is reached.
+	assertContains(t, code, "    _o0: {\n      if (input.tags === undefined) {")
+	assertContains(t, code, "      }\n      if (errors.length >= _max) break _o0;\n      if (input.children === undefined) {")
+	assertContains(t, code, "if (errors.length > _max) {\n      errors.length = _max;\n    }")
+
+	code = GenerateCompanionSelective("Node", meta, reg, true, false, CompanionGenOptions{Settings: Settings{MaxErrors: 1}})
+	assertContains(t, code, "const _max = options === undefined || options === null ? 1 : options.failFast ? 1 : options.maxErrors > 0 ? options.maxErrors : 1;")
+}
+
+func TestValidateStructuralLimits(t *testing.T) {
+	maxItems := 1000
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+		{Name: "ids", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}}, Required: true,
+			Constraints: &metadata.Constraints{MaxItems: &maxItems}},
+		{Name: "labels", Type: metadata.Metadata{Kind: metadata.KindObject, IndexSignature: &metadata.IndexSignature{
+			KeyType: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, ValueType: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}}}, Required: true},
+		{Name: "children", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindRef, Ref: "Node"}}, Required: true},
+	}}
+	reg := metadata.NewTypeRegistry()
+	reg.Types["Node"] = meta
+	opts := CompanionGenOptions{Settings: Settings{Limits: Limits{MaxDepth: 3, MaxArrayLength: 100, MaxStringLength: 50, MaxProperties: 20}}}
+
+	code := GenerateCompanionSelective("Node", meta, reg, true, false, opts)
+	// is: depth-limited inner function, implicit limits, explicit MaxItems instead of the default
+	assertContains(t, code, "function _isNode(input, _d) {\n  return _d <= 3 && ")
+	assertContains(t, code, "typeof input.name === \"string\" && input.name.length <= 50")
+	assertContains(t, code, "Object.keys(input.labels).length <= 20")
+	assertContains(t, code, "(Array.isArray(input.children) && input.children.length <= 100 && input.children.every(_v1 => _isNode(_v1, _d + 1)))")
+	assertContains(t, code, "(!Array.isArray(input.ids) || input.ids.length <= 1000)")
+	assertNotContains(t, code, "input.ids.length <= 100 ")
+	assertContains(t, code, "return _isNode(input, 1);")
+	// validate: depth check on entry, oversized arrays are not iterated
+	assertContains(t, code, "function _validateNode(input, _path, errors, _max, _d) {")
+	assertContains(t, code, `errors.push({ path: _path, expected: "maxDepth 3", received: "depth " + _d, code: "maxDepth", params: { max: 3 } });`)
+	assertContains(t, code, "_validateNode(input.children[i1], _path + \".children\" + \"[\" + i1 + \"]\", errors, _max, _d + 1);")
+	assertContains(t, code, "if (input.children.length > 100) {")
+	assertContains(t, code, `expected: "maxProperties 20", received: "properties " + Object.keys(input.labels).length, code: "maxProperties", params: { max: 20 } });`)
+	// assert: depth parameter and throws
+	assertContains(t, code, "function _assertNode(input, _path, _d) {\n  if (_d > 3) {")
+	assertContains(t, code, "_assertNode(input.children[i1], _path + \".children\" + \"[\" + i1 + \"]\", _d + 1);")
+	assertContains(t, code, `throw new __e([{path: _path + ".name", expected: "maxLength 50", received: "length " + input.name.length, code: "maxLength", params: { max: 50 }}]);`)
+
+	code = GenerateCompanionSelective("Node", meta, reg, true, false)
+	assertNotContains(t, code, "_d")
+	assertNotContains(t, code, "maxProperties")
+}
+
+func TestValidateStructuralLimits_DeclaredBounds(t *testing.T) {
+	maxItems, maxLength := 1000, 5
+	str := metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		// The declared MaxItems bounds the array member only: the string member keeps its limit
+		{Name: "value", Type: metadata.Metadata{Kind: metadata.KindUnion, UnionMembers: []metadata.Metadata{
+			str, {Kind: metadata.KindArray, ElementType: &str},
+		}}, Required: true, Constraints: &metadata.Constraints{MaxItems: &maxItems}},
+		// A declared element MaxLength replaces the limit of the elements
+		{Name: "codes", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{
+			Kind: metadata.KindAtomic, Atomic: "string", Constraints: &metadata.Constraints{MaxLength: &maxLength},
+		}}, Required: true},
+	}}
+	opts := CompanionGenOptions{Settings: Settings{Limits: Limits{MaxArrayLength: 100, MaxStringLength: 50}}}
+
+	code := GenerateCompanionSelective("Form", meta, metadata.NewTypeRegistry(), true, false, opts)
+	assertNotContains(t, code, "input.value.length > 100)")
+	assertNotContains(t, code, "input.value.length <= 100 ")
+	assertContains(t, code, "input.value.length > 50")
+	assertContains(t, code, "input.codes.length > 100")
+	assertNotContains(t, code, "input.codes[i1].length > 50")
+}
+
+func TestSensitiveRedactionAndMask(t *testing.T) {
+	sensitive := true
+	minLen := 8
+	pattern := "^[0-9]{16}$"
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "username", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true,
+			Constraints: &metadata.Constraints{Pattern: &pattern}},
+		{Name: "password", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true,
+			Constraints: &metadata.Constraints{MinLength: &minLen, Pattern: &pattern, Sensitive: &sensitive}},
+		{Name: "pin", Type: metadata.Metadata{Kind: metadata.KindLiteral, LiteralValue: "1234"}, Required: false,
+			Constraints: &metadata.Constraints{Sensitive: &sensitive}},
+	}}
+	reg := metadata.NewTypeRegistry()
+
+	code := GenerateCompanionSelective("Login", meta, reg, true, true)
+	// Non-sensitive values are echoed, sensitive ones report their type
+	assertContains(t, code, `errors.push({ path: "input.username", expected: "pattern ^[0-9]{16}$", received: input.username, code: "pattern"`)
+	assertContains(t, code, `errors.push({ path: "input.password", expected: "pattern ^[0-9]{16}$", received: typeof input.password, code: "pattern"`)
+	assertContains(t, code, `received: "length " + input.password.length, code: "minLength"`)
+	assertContains(t, code, `errors.push({ path: "input.pin", expected: "1234", received: typeof input.pin, code: "type" });`)
+	assertContains(t, code, `throw new __e([{path: "input" + ".password", expected: "pattern ^[0-9]{16}$", received: typeof input.password, code: "pattern"`)
+	assertNotContains(t, code, `"\"" + input.password + "\""`)
+	// Serializers write the value without a mask
+	assertNotContains(t, code, `"\"***\""`)
+
+	code = GenerateCompanionSelective("Login", meta, reg, true, true, CompanionGenOptions{Settings: Settings{SensitiveMask: "***"}})
+	assertContains(t, code, `\"password\":${"\"***\""}`)
+	assertContains(t, code, `(input.pin !== undefined ? ",\"pin\":" + "\"***\"" : "")`)
+	assertNotContains(t, code, `__s(input.password)`)
+}
+
+func TestSerializeOmitsWriteOnlyProperties(t *testing.T) {
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "email", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+		{Name: "password", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true, WriteOnly: true},
+		{Name: "nickname", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: false},
+	}}
+	code := GenerateCompanionSelective("SignUp", meta, metadata.NewTypeRegistry(), true, true)
+	assertContains(t, code, `\"email\":`)
+	assertContains(t, code, `",\"nickname\":"`)
+	assertNotContains(t, code, `\"password\"`)
+	// Validation still checks write-only properties
+	assertContains(t, code, `input.password === undefined`)
+
+	secret := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "token", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true, WriteOnly: true},
+	}}
+	code = GenerateCompanionSelective("Secret", secret, metadata.NewTypeRegistry(), false, true)
+	assertContains(t, code, `return "{}";`)
+}
+
+func TestAssertRequestReadOnlyProperties(t *testing.T) {
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "id", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true, Readonly: true},
+		{Name: "name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+	}}
+	reg := metadata.NewTypeRegistry()
+	opts := CompanionGenOptions{Markers: map[string]bool{"assertRequest": true}}
+
+	// Without a read-only mode, assertRequest is assert
+	code := GenerateCompanionSelective("Item", meta, reg, true, false, opts)
+	assertContains(t, code, "export function assertRequestItem(input) {\n  return assertItem(input);\n}")
+
+	opts.Settings.ReadOnlyMode = ReadOnlyStrip
+	code = GenerateCompanionSelective("Item", meta, reg, true, false, opts)
+	assertContains(t, code, "export function assertRequestItem(input)")
+	assertContains(t, code, "delete input.id;")
+	assertContains(t, code, `throw new __e([{path: "input" + ".name"`)
+
+	opts.Settings.ReadOnlyMode = ReadOnlyReject
+	code = GenerateCompanionSelective("Item", meta, reg, true, false, opts)
+	assertContains(t, code, `if (input.id !== undefined) {`)
+	assertContains(t, code, `code: "readOnly"`)
+	assertNotContains(t, code, "delete input.id;")
+
+	// Types without read-only properties keep assert
+	plain := &metadata.Metadata{Kind: metadata.KindObject, Properties: meta.Properties[1:]}
+	code = GenerateCompanionSelective("Plain", plain, reg, true, false, opts)
+	assertContains(t, code, "return assertPlain(input);")
+
+	dts := GenerateMarkerTypes("Item", map[string]bool{"assertRequest": true})
+	assertContains(t, dts, "export declare function assertRequestItem(input: unknown): Item;")
+}
+
+func TestAssertRequestDeprecationHook(t *testing.T) {
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+		{Name: "nickname", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: false, Deprecated: true},
+	}}
+	reg := metadata.NewTypeRegistry()
+	opts := CompanionGenOptions{Markers: map[string]bool{"assertRequest": true}}
+
+	if NeedsAssertRequest(meta, reg, &opts.Settings) {
+		t.Error("expected no assertRequest without a deprecation hook")
+	}
+	code := GenerateCompanionSelective("Profile", meta, reg, true, false, opts)
+	assertNotContains(t, code, "__dep")
+
+	opts.Settings.DeprecationHook = DeprecationHook{Module: "/app/dist/deprecation.js", Export: "onDeprecated"}
+	if !NeedsAssertRequest(meta, reg, &opts.Settings) {
+		t.Error("expected assertRequest with a deprecation hook")
+	}
+	code = GenerateCompanionSelective("Profile", meta, reg, true, false, opts)
+	assertContains(t, code, `import { __e, __dep } from "./_tsgonest_helpers.js";`)
+	assertContains(t, code, `if (input.nickname !== undefined) __dep("input" + ".nickname");`)
+	assertContains(t, code, "export function assertRequestProfile(input)")
+	// assertProfile is unchanged: only request bodies report deprecated properties
+	if n := strings.Count(code, "__dep("); n != 1 {
+		t.Errorf("expected 1 __dep call, got %d", n)
+	}
+
+	helpers := GenerateHelpersFile("/app/dist", CompanionOptions{Settings: opts.Settings})[0].Content
+	assertContains(t, helpers, `import { onDeprecated } from "./deprecation.js";`)
+	assertContains(t, helpers, "export const __dep = onDeprecated;")
+	assertContains(t, generateHelpersTypes(&opts.Settings), "export declare const __dep: (path: string) => void;")
+	cjs := GenerateHelpersFile("/app/dist", CompanionOptions{ModuleFormat: "cjs", Settings: opts.Settings})[0].Content
+	assertContains(t, cjs, `const { onDeprecated } = require("./deprecation.js");`)
+	assertContains(t, cjs, "__dep")
+}
+
+func TestRequestBodyFunctions(t *testing.T) {
+	fn, module := "isUsernameFree", "/src/validators.ts"
+	meta := &metadata.Metadata{Kind: metadata.KindObject, ClassName: "SignupDto", ClassModule: "/src/signup.dto.ts", Properties: []metadata.Property{
+		{Name: "id", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true, Readonly: true},
+		{Name: "userName", JsonName: "user_name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true,
+			Constraints: &metadata.Constraints{ValidateAsyncFn: &fn, ValidateAsyncModule: &module}},
+	}}
+	reg := metadata.NewTypeRegistry()
+	settings := Settings{ReadOnlyMode: ReadOnlyStrip}
+
+	// hydrateRequest: assertRequest (read-only, JSON names) then instances, asserted once
+	code := GenerateCompanionSelective("SignupDto", meta, reg, true, false, CompanionGenOptions{
+		Markers:  map[string]bool{"hydrate": true, "hydrateRequest": true},
+		Settings: settings,
+	})
+	assertContains(t, code, "export function assertRequestSignupDto(input)")
+	assertContains(t, code, "delete input.id;")
+	assertContains(t, code, "export function hydrateRequestSignupDto(input) {\n  return _hyd_SignupDto(assertRequestSignupDto(input));\n}")
+	assertContains(t, code, "return _hyd_SignupDto(assertSignupDto(input));")
+	if n := strings.Count(code, "function _hyd_SignupDto(v)"); n != 1 {
+		t.Errorf("expected one _hyd_SignupDto function, got %d", n)
+	}
+
+	// assertAsyncRequest: assertRequest then async validators; hydrateRequest awaits it
+	code = GenerateCompanionSelective("SignupDto", meta, reg, true, false, CompanionGenOptions{
+		Markers:  map[string]bool{"hydrateRequest": true, "assertAsyncRequest": true},
+		Settings: settings,
+	})
+	assertContains(t, code, "export async function assertAsyncRequestSignupDto(input)")
+	assertContains(t, code, "const data = assertRequestSignupDto(input);")
+	assertContains(t, code, "export async function hydrateRequestSignupDto(input) {\n  return _hyd_SignupDto(await assertAsyncRequestSignupDto(input));\n}")
+	assertNotContains(t, code, "assertAsyncSignupDto")
+	assertNotContains(t, code, "export function hydrateSignupDto")
+	// Async errors of request bodies are reported under JSON names
+	assertContains(t, code, "_var_SignupDto(data, \"input\", _p);")
+	assertContains(t, code, `path: _path + ".user_name"`)
+	assertNotContains(t, code, "function _va_SignupDto")
+
+	dts := GenerateMarkerTypes("SignupDto", map[string]bool{"hydrateRequest": true, "assertAsyncRequest": true})
+	assertContains(t, dts, "export declare function assertRequestSignupDto(input: unknown): SignupDto;")
+	assertContains(t, dts, "export declare function hydrateRequestSignupDto(input: unknown): Promise<SignupDto>;")
+	assertContains(t, dts, "export declare function assertAsyncRequestSignupDto(input: unknown): Promise<SignupDto>;")
+}
+
+func TestJSONNames(t *testing.T) {
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "firstName", JsonName: "first_name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+		{Name: "nickName", JsonName: "nick_name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string", Optional: true}},
+		{Name: "age", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}, Required: true},
+	}}
+	reg := metadata.NewTypeRegistry()
+	if !NeedsAssertRequest(meta, reg, &Settings{}) {
+		t.Fatal("expected assertRequest for JSON names")
+	}
+	code := GenerateCompanionSelective("Person", meta, reg, true, true, CompanionGenOptions{Markers: map[string]bool{"assertRequest": true}})
+
+	// Serializers write JSON names
+	assertContains(t, code, `\"first_name\":${__s(input.firstName)}`)
+	assertContains(t, code, `",\"nick_name\":"`)
+	assertContains(t, code, `\"age\":`)
+
+	// Request bodies are read under JSON names and renamed once validated
+	assertContains(t, code, "export function assertRequestPerson(input)")
+	assertContains(t, code, "delete input.firstName;")
+	assertContains(t, code, `throw new __e([{path: "input" + ".first_name", expected: "string", received: "undefined", code: "required"}]);`)
+	assertContains(t, code, "if (\"first_name\" in input) {\n      input.firstName = input.first_name;\n      delete input.first_name;")
+	// assert checks the TypeScript-named object
+	assertContains(t, code, `path: "input" + ".firstName"`)
+}
+
+func TestAssertRequestUnion(t *testing.T) {
+	str := metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "payment", Type: metadata.Metadata{Kind: metadata.KindUnion, UnionMembers: []metadata.Metadata{
+			{Kind: metadata.KindObject, Properties: []metadata.Property{{Name: "iban", Type: str, Required: true}}},
+			{Kind: metadata.KindObject, Properties: []metadata.Property{
+				{Name: "id", Type: str, Required: true, Readonly: true},
+				{Name: "cardNumber", JsonName: "card_number", Type: str, Required: true},
+				{Name: "holder", Type: str, Required: true},
+			}},
+		}}, Required: true},
+	}}
+	code := GenerateCompanionSelective("Order", meta, metadata.NewTypeRegistry(), true, false, CompanionGenOptions{
+		Markers:  map[string]bool{"assertRequest": true},
+		Settings: Settings{ReadOnlyMode: ReadOnlyStrip},
+	})
+
+	// Each member is tried on a copy, the one with more required properties first
+	code = code[strings.Index(code, "export function assertRequestOrder"):]
+	assertNotContains(t, code, "complex union")
+	assertContains(t, code, "let _u1 = structuredClone(input.payment);")
+	assertContains(t, code, `if ("card_number" in _u1) {`)
+	assertContains(t, code, "delete _u1.id;")
+	assertContains(t, code, "if (!(_err instanceof __e)) throw _err;")
+	if strings.Index(code, "delete _u1.id;") > strings.Index(code, "_u1.iban") {
+		t.Error("expected the card member to be tried before the iban member")
+	}
+	assertContains(t, code, "input.payment = _u1;")
+	assertContains(t, code, `throw new __e([{path: "input" + ".payment"`)
+}
+
+func TestSerializationViews(t *testing.T) {
+	admin := &metadata.Constraints{Groups: []string{"admin"}}
+	owner := &metadata.Constraints{Groups: []string{"owner", "admin"}}
+	meta := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "id", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+		{Name: "email", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true, Constraints: owner},
+		{Name: "notes", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true, Constraints: admin},
+	}}
+	reg := metadata.NewTypeRegistry()
+	if !HasGroups(meta, reg) {
+		t.Fatal("expected groups")
+	}
+	code := GenerateCompanionSelective("User", meta, reg, true, true, CompanionGenOptions{Views: []string{"admin", "owner"}})
+
+	// The default view only writes ungrouped properties
+	assertContains(t, code, "export function serializeUser(input) {\n  return `{\\\"id\\\":${__s(input.id)}}`;")
+	// Each view adds the properties of its groups
+	assertContains(t, code, "export function serializeUser_admin(input) {\n  return `{\\\"id\\\":${__s(input.id)},\\\"email\\\":${__s(input.email)},\\\"notes\\\":${__s(input.notes)}}`;")
+	assertContains(t, code, "export function serializeUser_owner(input) {\n  return `{\\\"id\\\":${__s(input.id)},\\\"email\\\":${__s(input.email)}}`;")
+	assertContains(t, code, "export function stringifyUser_admin(input)")
+	assertContains(t, code, "return serializeUser_admin(input);")
+
+	// Views of types without groups are the default serializers
+	plain := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "id", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+	}}
+	code = GenerateCompanionSelective("Plain", plain, reg, true, true, CompanionGenOptions{Views: []string{"admin"}})
+	assertContains(t, code, "export function serializePlain_admin(input) {\n  return serializePlain(input);")
+	assertContains(t, code, "export function stringifyPlain_admin(input) {\n  return stringifyPlain(input);")
+
+	dts := generateViewTypes("User", []string{"admin"}, true)
+	assertContains(t, dts, "export declare function serializeUser_admin(input: User): string;")
+	assertContains(t, dts, "export declare function stringifyUser_admin(input: User): string;")
+}
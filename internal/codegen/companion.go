@@ -1,6 +1,7 @@
 package codegen
 
 import (
+	"path/filepath"
 	"strings"
 
 	"github.com/tsgonest/tsgonest/internal/metadata"
@@ -19,6 +20,19 @@ type CompanionOptions struct {
 	ModuleFormat      string // "cjs" or "esm" (default: "esm")
 	StandardSchema    bool   // Generate Standard Schema v1 wrappers (default: false)
 	ResponseTypeCheck string // "safe" (default), "guard", or "none" — controls type checking in stringify
+	// Markers maps type names to the on-demand marker functions used on them
+	// (e.g. {"UserDto": {"random": true}}), collected from marker calls.
+	Markers map[string]map[string]bool
+	// Views maps type names to the serialization views of the routes returning
+	// them (e.g. {"UserDto": {"admin"}}), see CompanionGenOptions.Views.
+	Views map[string][]string
+	// SourceToOutput maps source file paths to their output paths (with a .ts
+	// extension, see companionPath). When set, source modules imported by
+	// companions are referenced by their emitted .js file relative to the companion.
+	SourceToOutput map[string]string
+	// Settings are the project-wide options of generated code, also used by
+	// the helpers file (see GenerateHelpersFile).
+	Settings Settings
 }
 
 // GenerateCompanionFiles generates consolidated companion files (.tsgonest.js)
@@ -59,6 +73,10 @@ func GenerateCompanionFiles(sourceFileName string, types map[string]*metadata.Me
 		jsContent := GenerateCompanionSelective(typeName, resolved, registry, includeValidation, includeSerialization, CompanionGenOptions{
 			StandardSchema:    opts.StandardSchema,
 			ResponseTypeCheck: opts.ResponseTypeCheck,
+			Markers:           opts.Markers[typeName],
+			ImportPath:        outputImportPath(jsPath, opts.SourceToOutput),
+			Views:             opts.Views[typeName],
+			Settings:          opts.Settings,
 		})
 		if isCJS {
 			jsContent = ConvertToCommonJS(jsContent)
@@ -70,7 +88,12 @@ func GenerateCompanionFiles(sourceFileName string, types map[string]*metadata.Me
 
 		// Generate type declarations (.tsgonest.d.ts)
 		dtsPath := strings.TrimSuffix(jsPath, ".js") + ".d.ts"
-		dtsContent := GenerateCompanionTypesSelective(typeName, includeValidation, includeSerialization, opts.StandardSchema)
+		outputType := companionOutputType(typeName, resolved)
+		dtsContent := generateCompanionTypes(typeName, outputType, transformChains(resolved, registry), includeValidation, includeSerialization, opts.StandardSchema)
+		dtsContent += generateMarkerTypes(typeName, outputType, opts.Markers[typeName])
+		if includeSerialization {
+			dtsContent += generateViewTypes(typeName, opts.Views[typeName], includeValidation)
+		}
 		if isCJS {
 			dtsContent = ConvertDtsToCommonJS(dtsContent)
 		}
@@ -83,6 +106,30 @@ func GenerateCompanionFiles(sourceFileName string, types map[string]*metadata.Me
 	return files
 }
 
+// outputImportPath returns a CompanionGenOptions.ImportPath resolving source files
+// to the relative path of their emitted .js file from the companion at jsPath,
+// or nil (toRelativeImportPath) when sourceToOutput is not set.
+func outputImportPath(jsPath string, sourceToOutput map[string]string) func(string) string {
+	if sourceToOutput == nil {
+		return nil
+	}
+	return func(sourceFile string) string {
+		out, ok := sourceToOutput[sourceFile]
+		if !ok {
+			return toRelativeImportPath(sourceFile)
+		}
+		rel, err := filepath.Rel(filepath.Dir(jsPath), strings.TrimSuffix(out, ".ts")+".js")
+		if err != nil {
+			return toRelativeImportPath(sourceFile)
+		}
+		rel = filepath.ToSlash(rel)
+		if !strings.HasPrefix(rel, ".") {
+			rel = "./" + rel
+		}
+		return rel
+	}
+}
+
 // companionPath generates the companion file path from the source file path.
 // e.g., "src/user.dto.ts" + "CreateUserDto" → "src/user.dto.CreateUserDto.tsgonest.js"
 func companionPath(sourceFileName string, typeName string) string {
@@ -111,14 +158,14 @@ func HelpersFilePath(outDir string) string {
 
 // GenerateHelpersFile returns the shared helpers file (.js and .d.ts) as CompanionFile entries.
 // The outDir parameter is the output directory where companion files are written.
-// This should be called once per build, not per source file.
-// moduleFormat should be "cjs" or "esm".
-func GenerateHelpersFile(outDir string, moduleFormat ...string) []CompanionFile {
+// This should be called once per build, not per source file, with the
+// ModuleFormat and Settings of the companions.
+func GenerateHelpersFile(outDir string, opts CompanionOptions) []CompanionFile {
 	jsPath := HelpersFilePath(outDir)
 	dtsPath := strings.TrimSuffix(jsPath, ".js") + ".d.ts"
-	jsContent := GenerateHelpers()
-	dtsContent := GenerateHelpersTypes()
-	if len(moduleFormat) > 0 && moduleFormat[0] == "cjs" {
+	jsContent := generateHelpers(jsPath, &opts.Settings)
+	dtsContent := generateHelpersTypes(&opts.Settings)
+	if opts.ModuleFormat == "cjs" {
 		jsContent = ConvertToCommonJS(jsContent)
 		dtsContent = ConvertDtsToCommonJS(dtsContent)
 	}
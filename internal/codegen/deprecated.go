@@ -0,0 +1,34 @@
+package codegen
+
+import (
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// DeprecationHook is the function reporting deprecated properties sent in
+// request bodies: (path: string) => void, exported as Export by Module.
+type DeprecationHook struct {
+	Module string // path of the JS module exporting the hook
+	Export string // exported function name
+}
+
+// HasDeprecatedProperties reports whether meta contains deprecated properties
+// (@deprecated), reported by request bodies when a hook is registered.
+func HasDeprecatedProperties(meta *metadata.Metadata, registry *metadata.TypeRegistry) bool {
+	return hasProperty(meta, registry, func(p *metadata.Property) bool { return p.Deprecated }, make(map[string]bool))
+}
+
+// emitDeprecationReport emits the hook call reporting the deprecated property
+// at propAccessor when it is present. Within a union member trial (see
+// generateAssertRequestUnion), the path is queued until the member is selected.
+func emitDeprecationReport(e *Emitter, propAccessor string, propPathExpr string, ctx *validateCtx) {
+	e.Line("if (%s !== undefined) %s;", propAccessor, ctx.deprecationCall(propPathExpr))
+}
+
+// deprecationCall returns the JS call reporting the deprecated property at
+// pathExpr: the hook, or a push onto the queue of the enclosing union trial.
+func (ctx *validateCtx) deprecationCall(pathExpr string) string {
+	if ctx.depQueue != "" {
+		return ctx.depQueue + ".push(" + pathExpr + ")"
+	}
+	return "__dep(" + pathExpr + ")"
+}
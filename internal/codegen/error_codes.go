@@ -0,0 +1,54 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validation error objects carry a stable code next to path/expected/received,
+// identifying the failed check, with the check's parameters:
+//
+//	{ path: "input.name", expected: "minLength 3", received: "length 1", code: "minLength", params: { min: 3 } }
+//
+// Locale catalogs (see RegisterLocales) map codes to message templates using
+// the params as placeholders. Type mismatches carry the code "type".
+
+// customMessage returns the escaped custom message of the check identified by
+// key: the per-constraint message, then the property-wide one, or "".
+func customMessage(errs map[string]string, errorMessage *string, key string) string {
+	if msg, ok := errs[key]; ok {
+		return jsStringEscape(msg)
+	}
+	if errorMessage != nil {
+		return jsStringEscape(*errorMessage)
+	}
+	return ""
+}
+
+// errorFields returns the fields appended to the error object of a failed check:
+// `, code: "minLength", params: { min: 3 }`. custom is the escaped custom
+// message of the check, added as message (catalogs never replace it). params
+// alternates parameter names and JS expressions.
+func errorFields(code string, custom string, params ...string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, ", code: %q", code)
+	if len(params) > 0 {
+		b.WriteString(", params: { ")
+		for i := 0; i+1 < len(params); i += 2 {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%s: %s", params[i], params[i+1])
+		}
+		b.WriteString(" }")
+	}
+	if custom != "" {
+		fmt.Fprintf(&b, ", message: \"%s\"", custom)
+	}
+	return b.String()
+}
+
+// jsParam returns a JS string literal for a string error parameter.
+func jsParam(s string) string {
+	return "\"" + jsStringEscape(s) + "\""
+}
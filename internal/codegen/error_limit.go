@@ -0,0 +1,85 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// validateOptionsType is the declared options type of validate functions.
+const validateOptionsType = "{ maxErrors?: number; failFast?: boolean }"
+
+// emitErrorLimit declares _max, the error limit of a validate function: 1 with
+// { failFast: true }, options.maxErrors when positive, else the default of
+// s (Settings.MaxErrors).
+func emitErrorLimit(e *Emitter, s *Settings) {
+	def := "Infinity"
+	if s.MaxErrors > 0 {
+		def = fmt.Sprint(s.MaxErrors)
+	}
+	e.Line("const _max = options === undefined || options === null ? %s : options.failFast ? 1 : options.maxErrors > 0 ? options.maxErrors : %s;", def, def)
+}
+
+// emitErrorLimitGuard exits the enclosing loop once _max errors are collected.
+// Checks skipped this way can only hide errors of an already failed validation.
+func emitErrorLimitGuard(e *Emitter) {
+	e.Line("if (errors.length >= _max) break;")
+}
+
+// emitErrorLimitResult drops the errors pushed past _max by the checks of the
+// element that reached it.
+func emitErrorLimitResult(e *Emitter) {
+	e.Block("if (errors.length > _max)")
+	e.Line("errors.length = _max;")
+	e.EndBlock()
+}
+
+// checkSeq separates the checks of an object with error limit guards, so a
+// validation stops between two property checks once _max errors are
+// collected (a failFast validation reports its first error only).
+type checkSeq struct {
+	label   string // label of the block the guards exit, "" without guards
+	started bool
+}
+
+// openCheckSeq opens the labeled block of the n checks of the object at depth.
+// A single check needs no guard.
+func openCheckSeq(e *Emitter, depth int, n int) *checkSeq {
+	if n < 2 {
+		return &checkSeq{}
+	}
+	s := &checkSeq{label: fmt.Sprintf("_o%d", depth)}
+	e.Block("%s:", s.label)
+	return s
+}
+
+// next emits the guard before a check other than the first.
+func (s *checkSeq) next(e *Emitter) {
+	if s.label != "" && s.started {
+		e.Line("if (errors.length >= _max) break %s;", s.label)
+	}
+	s.started = true
+}
+
+// close closes the labeled block.
+func (s *checkSeq) close(e *Emitter) {
+	if s.label != "" {
+		e.EndBlock()
+	}
+}
+
+// objectCheckCount returns the number of checks of an object: its unknown
+// keys when checked, its properties, its index signature and its rules.
+func objectCheckCount(meta *metadata.Metadata, checksKeys bool) int {
+	n := len(meta.Properties)
+	if checksKeys {
+		n++
+	}
+	if meta.IndexSignature != nil {
+		n++
+	}
+	if meta.Rules != nil {
+		n++
+	}
+	return n
+}
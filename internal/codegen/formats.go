@@ -1,5 +1,12 @@
 package codegen
 
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
 // formatRegexes maps format names to their JavaScript regex patterns for validation.
 // Based on typia's FormatCheatSheet.ts which itself references ajv-formats.
 // Special entries:
@@ -75,3 +82,95 @@ func FormatNames() []string {
 	}
 	return names
 }
+
+// CustomFormat is a user-defined string format (transforms.formats in config),
+// checked by Format<"name"> like the built-in formats. Its check is exported by
+// the helpers file as customFormatConstName(Name), an object with a test method: the
+// compiled Pattern, or the exported predicate Export of Module.
+type CustomFormat struct {
+	Name    string
+	Pattern string // JS regular expression source
+	Flags   string // JS regular expression flags
+	Module  string // path of the JS module exporting the predicate
+	Export  string // exported predicate name: (value: string) => boolean
+}
+
+// formatTestExpr returns the JS expression of an object with a test method
+// checking format, and whether the format is checked at all: a regex literal
+// for built-in formats and the helpers constant for the custom formats of s.
+func formatTestExpr(format string, s *Settings) (string, bool) {
+	if pattern, ok := formatRegexes[format]; ok {
+		if pattern == "" {
+			return "", false
+		}
+		return fmt.Sprintf("/%s/%s", pattern, formatFlags[format]), true
+	}
+	if _, ok := s.customFormat(format); ok {
+		return customFormatConstName(format), true
+	}
+	return "", false
+}
+
+// collectCustomFormats returns the custom formats of s checked in the metadata
+// tree, sorted by name. Their constants are imported from the helpers file.
+func collectCustomFormats(meta *metadata.Metadata, registry *metadata.TypeRegistry, s *Settings) []string {
+	if len(s.Formats) == 0 {
+		return nil
+	}
+	used := make(map[string]bool)
+	visitedRefs := make(map[string]bool)
+	add := func(c *metadata.Constraints) {
+		if c != nil && c.Format != nil {
+			if _, ok := s.customFormat(*c.Format); ok {
+				used[*c.Format] = true
+			}
+		}
+	}
+
+	var scan func(m *metadata.Metadata)
+	scan = func(m *metadata.Metadata) {
+		if m == nil {
+			return
+		}
+		add(m.Constraints)
+		switch m.Kind {
+		case metadata.KindObject:
+			for i := range m.Properties {
+				add(m.Properties[i].Constraints)
+				scan(&m.Properties[i].Type)
+			}
+			if m.IndexSignature != nil {
+				scan(&m.IndexSignature.ValueType)
+			}
+		case metadata.KindArray:
+			scan(m.ElementType)
+		case metadata.KindTuple:
+			for i := range m.Elements {
+				scan(&m.Elements[i].Type)
+			}
+		case metadata.KindUnion:
+			for i := range m.UnionMembers {
+				scan(&m.UnionMembers[i])
+			}
+		case metadata.KindIntersection:
+			for i := range m.IntersectionMembers {
+				scan(&m.IntersectionMembers[i])
+			}
+		case metadata.KindRef:
+			if m.Ref != "" && registry != nil && !visitedRefs[m.Ref] {
+				visitedRefs[m.Ref] = true
+				if resolved, ok := registry.Types[m.Ref]; ok {
+					scan(resolved)
+				}
+			}
+		}
+	}
+	scan(meta)
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,64 @@
+package codegen
+
+import (
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// ViewFuncName returns the name of the companion function fn (serialize or
+// stringify) of typeName in a serialization view: stringifyUserDto_admin.
+// The default view ("") keeps the plain name.
+func ViewFuncName(fn string, typeName string, view string) string {
+	if view == "" {
+		return fn + typeName
+	}
+	return fn + typeName + "_" + view
+}
+
+// HasGroups reports whether meta contains properties with serialization groups
+// (Groups<G> / @groups), whose serialization depends on the view.
+func HasGroups(meta *metadata.Metadata, registry *metadata.TypeRegistry) bool {
+	return hasProperty(meta, registry, func(p *metadata.Property) bool {
+		return p.Constraints != nil && len(p.Constraints.Groups) > 0
+	}, make(map[string]bool))
+}
+
+// generateViewFunctions generates serialize<Name>_<view> and, with validation,
+// stringify<Name>_<view>: the serializers of a route view, writing the grouped
+// properties of view besides the ungrouped ones. Without groups, they call the
+// default functions.
+func generateViewFunctions(e *Emitter, typeName string, view string, meta *metadata.Metadata, registry *metadata.TypeRegistry, includeValidation bool, mode string, s *Settings) {
+	if !HasGroups(meta, registry) {
+		e.Block("export function %s(input)", ViewFuncName("serialize", typeName, view))
+		e.Line("return %s(input);", ViewFuncName("serialize", typeName, ""))
+		e.EndBlock()
+		if includeValidation {
+			e.Blank()
+			e.Block("export function %s(input)", ViewFuncName("stringify", typeName, view))
+			e.Line("return %s(input);", ViewFuncName("stringify", typeName, ""))
+			e.EndBlock()
+		}
+		return
+	}
+	ctx := &serializeCtx{generating: map[string]bool{typeName: true}, view: view, cfg: s}
+	generateSerializeFunction(e, typeName, meta, registry, ctx)
+	if includeValidation {
+		e.Blank()
+		generateStringifyFunction(e, typeName, view, mode)
+	}
+}
+
+// generateViewTypes generates the declarations of the view functions (see
+// generateViewFunctions), appended to the companion .d.ts content.
+func generateViewTypes(typeName string, views []string, includeValidation bool) string {
+	if len(views) == 0 {
+		return ""
+	}
+	e := NewEmitter()
+	for _, view := range views {
+		e.Line("export declare function %s(input: %s): string;", ViewFuncName("serialize", typeName, view), typeName)
+		if includeValidation {
+			e.Line("export declare function %s(input: %s): string;", ViewFuncName("stringify", typeName, view), typeName)
+		}
+	}
+	return e.String()
+}
@@ -1,28 +1,67 @@
 package codegen
 
 import (
+	"path/filepath"
 	"sort"
+	"strings"
 )
 
 // GenerateHelpers generates the shared _tsgonest_helpers.js file content.
 // This file contains serialization helpers and format regex constants
 // that are shared across all companion files, avoiding code duplication.
 func GenerateHelpers() string {
+	return generateHelpers("", &Settings{})
+}
+
+// generateHelpers generates the helpers file content for the settings s, with
+// the modules of custom format predicates imported relative to jsPath (as
+// configured when empty).
+func generateHelpers(jsPath string, s *Settings) string {
 	e := NewEmitter()
 	e.Line("// Auto-generated by tsgonest — do not edit")
 	e.Blank()
 
+	// Predicates of custom formats (Settings.Formats)
+	formats := s.customFormats()
+	imported := make(map[string]bool)
+	for _, f := range formats {
+		if f.Module == "" || imported[f.Export] {
+			continue
+		}
+		imported[f.Export] = true
+		e.Line("import { %s } from %q;", f.Export, helpersImportPath(jsPath, f.Module))
+	}
+	// Hook reporting deprecated request properties (Settings.DeprecationHook)
+	if h := s.DeprecationHook; h.Module != "" && !imported[h.Export] {
+		imported[h.Export] = true
+		e.Line("import { %s } from %q;", h.Export, helpersImportPath(jsPath, h.Module))
+	}
+	if len(imported) > 0 {
+		e.Blank()
+	}
+	if s.DeprecationHook.Module != "" {
+		e.Line("export const __dep = %s;", s.DeprecationHook.Export)
+		e.Blank()
+	}
+
 	// __e: TsgonestValidationError — thrown by assert functions
 	e.Block("export class __e extends Error")
 	e.Block("constructor(errors)")
 	e.Line("super(\"Validation failed: \" + errors.length + \" error(s)\");")
 	e.Line("this.name = \"TsgonestValidationError\";")
 	e.Line("this.errors = errors;")
-	e.Line("this.status = 400;")
+	e.Line("this.status = %d;", s.validationErrorStatus())
+	e.EndBlock()
+	e.Block("localize(locale)")
+	e.Line("return __lz(this.errors, locale);")
 	e.EndBlock()
 	e.EndBlock()
 	e.Blank()
 
+	// __msgs/__lz: locale catalogs and the error localizer (Settings.Locales)
+	generateLocaleHelpers(e, s)
+	e.Blank()
+
 	// __s: fast string serializer
 	// Fast path: if no control chars (<0x20), double-quotes (34), backslashes (92),
 	// or LINE/PARAGRAPH SEPARATOR (0x2028/0x2029) are found, wrap in quotes directly.
@@ -38,6 +77,10 @@ func GenerateHelpers() string {
 	e.EndBlock()
 	e.Blank()
 
+	// __rng and friends: seedable random generators used by random<T>() companions
+	generateRandomHelpers(e)
+	e.Blank()
+
 	// Format regex constants
 	names := make([]string, 0, len(formatRegexes))
 	for name := range formatRegexes {
@@ -59,17 +102,56 @@ func GenerateHelpers() string {
 		}
 	}
 
+	// Custom format constants: a regex, or an object testing with the predicate
+	for _, f := range formats {
+		if f.Module != "" {
+			e.Line("export const %s = { test: %s };", customFormatConstName(f.Name), f.Export)
+		} else {
+			e.Line("export const %s = /%s/%s;", customFormatConstName(f.Name), escapeForRegexLiteral(f.Pattern), f.Flags)
+		}
+	}
+
 	return e.String()
 }
 
+// helpersImportPath returns the specifier importing module from the helpers
+// file at jsPath: a relative path, or module itself when jsPath is empty.
+func helpersImportPath(jsPath string, module string) string {
+	if jsPath == "" || !filepath.IsAbs(module) {
+		return module
+	}
+	rel, err := filepath.Rel(filepath.Dir(jsPath), module)
+	if err != nil {
+		return module
+	}
+	rel = filepath.ToSlash(rel)
+	if !strings.HasPrefix(rel, ".") {
+		rel = "./" + rel
+	}
+	return rel
+}
+
 // GenerateHelpersTypes generates the _tsgonest_helpers.d.ts type declarations.
 func GenerateHelpersTypes() string {
+	return generateHelpersTypes(&Settings{})
+}
+
+// generateHelpersTypes generates the helpers type declarations for the settings s.
+func generateHelpersTypes(s *Settings) string {
 	e := NewEmitter()
 	e.Line("// Auto-generated by tsgonest — do not edit")
 	e.Blank()
-	e.Line("export declare class __e extends Error { errors: { path: string; expected: string; received: string }[]; status: number; }")
+	validationError := "{ path: string; expected: string; received: string; code?: string; params?: Record<string, string | number>; message?: string; discriminant?: { property: string; value: string | number | boolean } }"
+	localizedError := "{ path: string; expected: string; received: string; code: string; params?: Record<string, string | number>; message: string; discriminant?: { property: string; value: string | number | boolean } }"
+	e.Line("export declare class __e extends Error { errors: %s[]; status: number; localize(locale?: string | null): %s[]; }", validationError, localizedError)
+	e.Line("export declare const __msgs: Record<string, Record<string, string>>;")
+	e.Line("export declare function __lz(errors: readonly %s[], locale?: string | null): %s[];", validationError, localizedError)
 	e.Line("export declare function __s(s: string): string;")
 	e.Line("export declare function __sa(a: readonly unknown[], f: (v: unknown) => string): string;")
+	generateRandomHelpersTypes(e)
+	if s.DeprecationHook.Module != "" {
+		e.Line("export declare const __dep: (path: string) => void;")
+	}
 
 	names := make([]string, 0, len(formatRegexes))
 	for name := range formatRegexes {
@@ -83,6 +165,13 @@ func GenerateHelpersTypes() string {
 	for _, name := range names {
 		e.Line("export declare const %s: RegExp;", FormatConstName(name))
 	}
+	for _, f := range s.customFormats() {
+		if f.Module != "" {
+			e.Line("export declare const %s: { test(value: string): boolean };", customFormatConstName(f.Name))
+		} else {
+			e.Line("export declare const %s: RegExp;", customFormatConstName(f.Name))
+		}
+	}
 
 	return e.String()
 }
@@ -99,3 +188,10 @@ func FormatConstName(name string) string {
 	}
 	return result
 }
+
+// customFormatConstName returns the JS constant name of a custom format, like
+// "__cfmt_iso_week" for "iso-week". Custom formats have their own prefix, so
+// that a name such as "date_time" does not redeclare a built-in constant.
+func customFormatConstName(name string) string {
+	return "__c" + strings.TrimPrefix(FormatConstName(name), "__")
+}
@@ -0,0 +1,151 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// hydrateClass is a class imported by a companion to construct instances.
+type hydrateClass struct {
+	Name   string
+	Module string // source file path (see metadata.Metadata.ClassModule)
+}
+
+// collectHydrateClasses scans the metadata tree for objects declared by exported
+// classes and returns one entry per class name, sorted by name. When two classes
+// share a name, the first one found wins and the other stays a plain object.
+func collectHydrateClasses(meta *metadata.Metadata, registry *metadata.TypeRegistry) []hydrateClass {
+	byName := make(map[string]string)
+	visitedRefs := make(map[string]bool)
+
+	var scan func(m *metadata.Metadata)
+	scan = func(m *metadata.Metadata) {
+		if m == nil {
+			return
+		}
+		switch m.Kind {
+		case metadata.KindObject:
+			if m.ClassName != "" && m.ClassModule != "" {
+				if _, ok := byName[m.ClassName]; !ok {
+					byName[m.ClassName] = m.ClassModule
+				}
+			}
+			for i := range m.Properties {
+				scan(&m.Properties[i].Type)
+			}
+			if m.IndexSignature != nil {
+				scan(&m.IndexSignature.ValueType)
+			}
+		case metadata.KindArray:
+			scan(m.ElementType)
+		case metadata.KindTuple:
+			for i := range m.Elements {
+				scan(&m.Elements[i].Type)
+			}
+		case metadata.KindUnion:
+			for i := range m.UnionMembers {
+				scan(&m.UnionMembers[i])
+			}
+		case metadata.KindIntersection:
+			for i := range m.IntersectionMembers {
+				scan(&m.IntersectionMembers[i])
+			}
+		case metadata.KindRef:
+			if m.Ref != "" && registry != nil && !visitedRefs[m.Ref] {
+				visitedRefs[m.Ref] = true
+				if resolved, ok := registry.Types[m.Ref]; ok {
+					scan(resolved)
+				}
+			}
+		}
+	}
+	scan(meta)
+
+	classes := make([]hydrateClass, 0, len(byName))
+	for name, module := range byName {
+		classes = append(classes, hydrateClass{Name: name, Module: module})
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].Name < classes[j].Name
+	})
+	return classes
+}
+
+// generateHydrateFunction generates: export function hydrate<Name>(input) { ... }
+// It asserts the input like assert<Name>, then replaces every object declared by
+// an imported class (see collectHydrateClasses) with an instance of that class,
+// nested objects first. Instances are created with Object.create(Cls.prototype)
+// and the validated properties are assigned, so constructors (and their field
+// initializers) do not run. Object unions are only descended into when they are
+// discriminated or when their other members are primitives. The input is
+// hydrated in place: nested objects of the caller's value are replaced by the
+// instances, and absent optional properties stay absent.
+// With the hydrateRequest marker, hydrateRequest<Name> does the same for
+// request bodies on top of assertRequest<Name>, awaiting assertAsyncRequest<Name>
+// instead with the assertAsyncRequest marker, so the input is asserted once.
+// Requires the assert (assertRequest, assertAsyncRequest) functions of the same companion.
+func generateHydrateFunction(e *Emitter, typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry, classes []hydrateClass, markers map[string]bool) {
+	op := hydrateOp{classes: make(map[string]string, len(classes))}
+	for _, cls := range classes {
+		op.classes[cls.Name] = cls.Module
+	}
+	w := newWalker(registry, op, newKeySets("_hk_"+typeName+"_"))
+	hydrates := w.needs(meta)
+	if hydrates {
+		w.define(typeName, meta)
+	}
+	emit := func(async string, name string, assert string) {
+		e.Block("export %sfunction %s%s(input)", async, name, typeName)
+		if hydrates {
+			e.Line("return %s(%s);", hydrateFuncName(typeName), assert)
+		} else {
+			e.Line("return %s;", assert)
+		}
+		e.EndBlock()
+	}
+
+	if markers["hydrate"] {
+		emit("", "hydrate", fmt.Sprintf("assert%s(input)", typeName))
+	}
+	if markers["hydrateRequest"] {
+		if markers["assertAsyncRequest"] {
+			emit("async ", "hydrateRequest", fmt.Sprintf("await assertAsyncRequest%s(input)", typeName))
+		} else {
+			emit("", "hydrateRequest", fmt.Sprintf("assertRequest%s(input)", typeName))
+		}
+	}
+	w.emit(e)
+}
+
+// hydrateFuncName returns the local hydrate function name for a named type.
+func hydrateFuncName(typeName string) string {
+	return "_hyd_" + typeName
+}
+
+// hydrateOp replaces objects of imported classes with instances.
+type hydrateOp struct {
+	walkDefaults
+	classes map[string]string // imported class name → module
+}
+
+func (hydrateOp) funcName(typeName string) string { return hydrateFuncName(typeName) }
+
+// applies reports whether meta is an object of an imported class.
+func (op hydrateOp) applies(meta *metadata.Metadata) bool {
+	return meta.Kind == metadata.KindObject && meta.ClassName != "" && op.classes[meta.ClassName] == meta.ClassModule
+}
+
+func (hydrateOp) call(e *Emitter, fn string, accessor string, pathExpr string) {
+	e.Line("if (%s !== undefined) %s = %s(%s);", accessor, accessor, fn, accessor)
+}
+
+func (hydrateOp) returns() bool { return true }
+
+// exit replaces a class object, whose values are hydrated, with an instance.
+func (op hydrateOp) exit(e *Emitter, accessor string, meta *metadata.Metadata) {
+	if op.applies(meta) {
+		e.Line("%s = Object.assign(Object.create(%s.prototype), %s);", accessor, meta.ClassName, accessor)
+	}
+}
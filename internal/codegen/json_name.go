@@ -0,0 +1,82 @@
+package codegen
+
+import (
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// HasJSONNames reports whether meta contains properties whose JSON name
+// differs from their TypeScript name (JsonName<N> / transforms.jsonNaming).
+func HasJSONNames(meta *metadata.Metadata, registry *metadata.TypeRegistry) bool {
+	return hasProperty(meta, registry, func(p *metadata.Property) bool { return p.WireName() != p.Name }, make(map[string]bool))
+}
+
+// requestKey returns the key of prop in the object checked by ctx: its JSON
+// name in request bodies, its TypeScript name otherwise.
+func (ctx *validateCtx) requestKey(prop *metadata.Property) string {
+	if ctx != nil && ctx.request {
+		return prop.WireName()
+	}
+	return prop.Name
+}
+
+// emitDropTSName emits the deletion of the TypeScript-named key of prop from
+// the request body at accessor, so only the JSON-named value (validated, then
+// renamed) reaches the handler. Keys that are the JSON name of another
+// property of props are kept.
+func emitDropTSName(e *Emitter, accessor string, prop *metadata.Property, props []metadata.Property) {
+	for i := range props {
+		if props[i].WireName() == prop.Name {
+			return
+		}
+	}
+	e.Line("delete %s;", jsPropAccess(accessor, prop.Name))
+}
+
+// emitJSONNameRename emits the renaming of the validated JSON-named value of
+// prop in the request body at accessor to its TypeScript name.
+func emitJSONNameRename(e *Emitter, accessor string, prop *metadata.Property) {
+	wire := jsPropAccess(accessor, prop.WireName())
+	e.Block("if (%q in %s)", prop.WireName(), accessor)
+	e.Line("%s = %s;", jsPropAccess(accessor, prop.Name), wire)
+	e.Line("delete %s;", wire)
+	e.EndBlock()
+}
+
+// jsonNameOp renames the JSON-named properties of parsed JSON values to their
+// TypeScript name, like assertRequest does: keys sent under the TypeScript
+// name are dropped (see emitDropTSName) and JSON-named values are renamed.
+type jsonNameOp struct {
+	walkDefaults
+}
+
+func (jsonNameOp) funcName(typeName string) string { return "_names_" + typeName }
+
+// applies reports whether meta is an object with JSON-named properties.
+func (jsonNameOp) applies(meta *metadata.Metadata) bool {
+	if meta.Kind != metadata.KindObject {
+		return false
+	}
+	for i := range meta.Properties {
+		if prop := &meta.Properties[i]; prop.WireName() != prop.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (jsonNameOp) call(e *Emitter, fn string, accessor string, pathExpr string) {
+	e.Line("%s(%s);", fn, accessor)
+}
+
+func (jsonNameOp) enter(e *Emitter, accessor string, meta *metadata.Metadata, props []metadata.Property, depth int) {
+	var renamed []*metadata.Property
+	for i := range props {
+		if prop := &props[i]; prop.WireName() != prop.Name {
+			emitDropTSName(e, accessor, prop, props)
+			renamed = append(renamed, prop)
+		}
+	}
+	for _, prop := range renamed {
+		emitJSONNameRename(e, accessor, prop)
+	}
+}
@@ -0,0 +1,119 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// Limits are structural limits enforced by generated is/validate/assert
+// functions on top of the declared constraints, to bound the work done on
+// hostile payloads (transforms.limits). Zero fields are unlimited.
+type Limits struct {
+	// MaxDepth bounds the nesting depth of recursive types (the root is depth 1).
+	MaxDepth int
+	// MaxArrayLength bounds arrays, unless they declare MaxItems.
+	MaxArrayLength int
+	// MaxStringLength bounds strings, unless they declare MaxLength.
+	MaxStringLength int
+	// MaxProperties bounds the key count of objects with an index signature.
+	MaxProperties int
+}
+
+// maxStringLength returns the implicit length limit of the string check of
+// meta (0: none). A declared MaxLength replaces it.
+func (ctx *validateCtx) maxStringLength(meta *metadata.Metadata) int {
+	if meta.Constraints != nil && meta.Constraints.MaxLength != nil {
+		return 0
+	}
+	return ctx.settings().Limits.MaxStringLength
+}
+
+// maxArrayLength returns the implicit length limit of the array check of meta
+// (0: none). A declared MaxItems replaces it.
+func (ctx *validateCtx) maxArrayLength(meta *metadata.Metadata) int {
+	if meta.Constraints != nil && meta.Constraints.MaxItems != nil {
+		return 0
+	}
+	return ctx.settings().Limits.MaxArrayLength
+}
+
+// boundedType returns the type of a property declaring the constraints c,
+// with its declared MaxLength and MaxItems also set on the type and on its
+// union members, the values they bound (see maxStringLength). t is returned as
+// is when c declares neither.
+func boundedType(t *metadata.Metadata, c *metadata.Constraints) *metadata.Metadata {
+	if c == nil || (c.MaxLength == nil && c.MaxItems == nil) {
+		return t
+	}
+	bounded := *t
+	declared := metadata.Constraints{}
+	if t.Constraints != nil {
+		declared = *t.Constraints
+	}
+	if declared.MaxLength == nil {
+		declared.MaxLength = c.MaxLength
+	}
+	if declared.MaxItems == nil {
+		declared.MaxItems = c.MaxItems
+	}
+	bounded.Constraints = &declared
+	if t.Kind == metadata.KindUnion {
+		bounded.UnionMembers = make([]metadata.Metadata, len(t.UnionMembers))
+		for i := range t.UnionMembers {
+			bounded.UnionMembers[i] = *boundedType(&t.UnionMembers[i], c)
+		}
+	}
+	return &bounded
+}
+
+// depthLimited reports whether the recursive functions of typeName take a
+// depth parameter (_d), checked against Limits.MaxDepth of s on entry.
+func depthLimited(typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry, s *Settings) bool {
+	return s.Limits.MaxDepth > 0 && isRecursiveType(typeName, meta, registry)
+}
+
+// lengthErrorFields returns the expected description and error fields of an
+// implicit length limit, matching the explicit maxLength/maxItems errors.
+func lengthErrorFields(code string, max int) (string, string) {
+	return fmt.Sprintf("%s %d", code, max), errorFields(code, "", "max", fmt.Sprint(max))
+}
+
+// emitStringLengthCheck pushes an error when the string at accessor exceeds
+// max. pathExpr is a JS string expression.
+func emitStringLengthCheck(e *Emitter, accessor string, pathExpr string, max int) {
+	expected, fields := lengthErrorFields("maxLength", max)
+	e.Block("if (typeof %s === \"string\" && %s.length > %d)", accessor, accessor, max)
+	e.Line("errors.push({ path: %s, expected: \"%s\", received: \"length \" + %s.length%s });", pathExpr, expected, accessor, fields)
+	e.EndBlock()
+}
+
+// emitArrayLengthCheck pushes an error when the array at accessor exceeds max,
+// and opens an else block for the element checks, so oversized arrays are not
+// iterated. The caller closes it.
+func emitArrayLengthCheck(e *Emitter, accessor string, pathExpr string, max int) {
+	expected, fields := lengthErrorFields("maxItems", max)
+	e.Block("if (%s.length > %d)", accessor, max)
+	e.Line("errors.push({ path: %s, expected: \"%s\", received: \"length \" + %s.length%s });", pathExpr, expected, accessor, fields)
+	e.EndBlockSuffix(" else {")
+	e.indent++
+}
+
+// emitPropertyCountCheck pushes an error when the object at accessor has more
+// than max keys (Limits.MaxProperties), and opens an else block for the index
+// signature checks. The caller closes it.
+func emitPropertyCountCheck(e *Emitter, accessor string, pathExpr string, max int) {
+	e.Block("if (Object.keys(%s).length > %d)", accessor, max)
+	e.Line("errors.push({ path: %s, expected: \"maxProperties %d\", received: \"properties \" + Object.keys(%s).length%s });", pathExpr, max, accessor, errorFields("maxProperties", "", "max", fmt.Sprint(max)))
+	e.EndBlockSuffix(" else {")
+	e.indent++
+}
+
+// emitDepthCheck pushes an error and returns from a recursive validate
+// function entered deeper than max (Limits.MaxDepth).
+func emitDepthCheck(e *Emitter, max int) {
+	e.Block("if (_d > %d)", max)
+	e.Line("errors.push({ path: _path, expected: \"maxDepth %d\", received: \"depth \" + _d%s });", max, errorFields("maxDepth", "", "max", fmt.Sprint(max)))
+	e.Line("return;")
+	e.EndBlock()
+}
@@ -0,0 +1,56 @@
+package codegen
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// generateLocaleHelpers emits the catalogs of s (__msgs) and __lz, which
+// returns validation errors with their code and a message in the requested
+// locale. locale is a tag or an Accept-Language header value; its tags are
+// tried in order, each then without its region. Codes missing from the
+// matched catalog fall back to the default one, then to the built-in English
+// message. A template replaces {name} placeholders with the error params, then
+// with path/expected/received. Errors with a custom message keep it.
+func generateLocaleHelpers(e *Emitter, s *Settings) {
+	catalogs, _ := json.Marshal(s.localeCatalogs())
+	e.Line("export const __msgs = %s;", catalogs)
+	if s.DefaultLocale != "" {
+		e.Line("const __dl = %q;", strings.ToLower(s.DefaultLocale))
+	} else {
+		e.Line("const __dl = null;")
+	}
+	e.Line("const __h = Object.prototype.hasOwnProperty;")
+	e.Block("function __lc(locale)")
+	e.Block("if (typeof locale === \"string\")")
+	e.Line("const tags = locale.toLowerCase().split(\",\");")
+	e.Block("for (let i = 0; i < tags.length; i++)")
+	e.Line("const tag = tags[i].split(\";\")[0].trim();")
+	e.Block("if (tag !== \"\" && __h.call(__msgs, tag))")
+	e.Line("return __msgs[tag];")
+	e.EndBlock()
+	e.Line("const base = tag.split(\"-\")[0];")
+	e.Block("if (base !== \"\" && __h.call(__msgs, base))")
+	e.Line("return __msgs[base];")
+	e.EndBlock()
+	e.EndBlock()
+	e.EndBlock()
+	e.Line("return undefined;")
+	e.EndBlock()
+	e.Block("export function __lz(errors, locale)")
+	e.Line("const c = __lc(locale);")
+	e.Line("const d = __dl !== null && __h.call(__msgs, __dl) ? __msgs[__dl] : undefined;")
+	e.Block("return errors.map((err) =>")
+	e.Line("const code = err.code || \"type\";")
+	e.Line("let message = err.message;")
+	e.Line("const t = c !== undefined && __h.call(c, code) ? c[code] : d !== undefined && __h.call(d, code) ? d[code] : undefined;")
+	e.Block("if (message === undefined && t !== undefined)")
+	e.Line("message = t.replace(/\\{(\\w+)\\}/g, (m, k) => err.params && __h.call(err.params, k) ? String(err.params[k]) : k === \"path\" || k === \"expected\" || k === \"received\" ? String(err[k]) : m);")
+	e.EndBlock()
+	e.Block("if (message === undefined)")
+	e.Line("message = \"expected \" + err.expected + \", received \" + err.received;")
+	e.EndBlock()
+	e.Line("return Object.assign({}, err, { code, message });")
+	e.EndBlockSuffix(");")
+	e.EndBlock()
+}
@@ -0,0 +1,128 @@
+package codegen
+
+import (
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// generateParseFunctions generates the JSON.parse + validation companions:
+//
+//	export function parse<Name>(input, options)       → { success, data, errors } like validate<Name>
+//	export function assertParse<Name>(input, options) → data, throws like assert<Name>
+//
+// Malformed JSON is reported as a validation error at path "input". JSON has no
+// Date or bigint values, so ISO strings/timestamps at Date positions and integer
+// strings/numbers at bigint positions are revived before validation; pass
+// { coerce: false } to validate the raw parsed value instead. Properties are
+// read under their JSON name and renamed first (see jsonNameOp), so
+// parse<Name>(stringify<Name>(value)) returns the value.
+// Requires the validate/assert functions of the same companion.
+func generateParseFunctions(e *Emitter, typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry, markers map[string]bool) {
+	w := newWalker(registry, reviveOp{}, newKeySets("_rk_"+typeName+"_"))
+	revive := w.needs(meta)
+	if revive {
+		w.define(typeName, meta)
+	}
+	names := newWalker(registry, jsonNameOp{}, newKeySets("_nk_"+typeName+"_"))
+	rename := names.needs(meta)
+	if rename {
+		names.define(typeName, meta)
+	}
+
+	emitParsed := func(onError string) {
+		e.Line("var data;")
+		e.Block("try")
+		e.Line("data = JSON.parse(input);")
+		e.EndBlockSuffix(" catch (err) {")
+		e.Indent()
+		e.Line("var errors = [{ path: \"input\", expected: \"valid JSON\", received: String(err && err.message || err) }];")
+		e.Line("%s", onError)
+		e.EndBlock()
+		if rename {
+			e.Line("%s(data);", names.op.funcName(typeName))
+		}
+		if revive {
+			e.Block("if (!options || options.coerce !== false)")
+			e.Line("data = %s(data);", reviveFuncName(typeName))
+			e.EndBlock()
+		}
+	}
+
+	if markers["parse"] {
+		e.Block("export function parse%s(input, options)", typeName)
+		emitParsed("return { success: false, errors };")
+		e.Line("return validate%s(data, options);", typeName)
+		e.EndBlock()
+	}
+	if markers["assertParse"] {
+		e.Block("export function assertParse%s(input, options)", typeName)
+		emitParsed("throw new __e(errors);")
+		e.Line("return assert%s(data);", typeName)
+		e.EndBlock()
+	}
+
+	names.emit(e)
+	w.emit(e)
+}
+
+// reviveFuncName returns the local reviver function name for a named type.
+func reviveFuncName(typeName string) string {
+	return "_rev_" + typeName
+}
+
+// reviveOp converts JSON values at Date and bigint positions. Every
+// conversion is guarded by a typeof check, so values of the wrong shape are
+// left for the validator to report.
+type reviveOp struct {
+	walkDefaults
+}
+
+func (reviveOp) funcName(typeName string) string { return reviveFuncName(typeName) }
+
+// applies reports whether meta is a Date or bigint position.
+func (reviveOp) applies(meta *metadata.Metadata) bool {
+	return meta.Kind == metadata.KindNative && meta.NativeType == "Date" ||
+		meta.Kind == metadata.KindAtomic && meta.Atomic == "bigint"
+}
+
+func (reviveOp) call(e *Emitter, fn string, accessor string, pathExpr string) {
+	// Absent optional properties stay absent instead of becoming own undefined keys
+	e.Line("if (%s !== undefined) %s = %s(%s);", accessor, accessor, fn, accessor)
+}
+
+func (reviveOp) returns() bool { return true }
+
+func (reviveOp) leaf(e *Emitter, accessor string, meta *metadata.Metadata, depth int) {
+	switch meta.Kind {
+	case metadata.KindNative:
+		e.Block("if (typeof %s === \"string\" || typeof %s === \"number\")", accessor, accessor)
+		e.Line("var _d%d = new Date(%s);", depth, accessor)
+		e.Line("if (!isNaN(_d%d.getTime())) %s = _d%d;", depth, accessor, depth)
+		e.EndBlock()
+	case metadata.KindAtomic:
+		e.Line("if (typeof %s === \"string\" && /^-?\\d+$/.test(%s) || typeof %s === \"number\" && Number.isInteger(%s)) %s = BigInt(%s);", accessor, accessor, accessor, accessor, accessor, accessor)
+	}
+}
+
+// unionMembers returns every member but the scalar ones when the union has a
+// string member: it makes Date/bigint revival ambiguous (e.g. string | Date).
+func (reviveOp) unionMembers(meta *metadata.Metadata) []*metadata.Metadata {
+	hasString := false
+	for i := range meta.UnionMembers {
+		m := &meta.UnionMembers[i]
+		if m.Kind == metadata.KindAtomic && m.Atomic == "string" {
+			hasString = true
+		}
+		if _, ok := m.LiteralValue.(string); ok && m.Kind == metadata.KindLiteral {
+			hasString = true
+		}
+	}
+	var members []*metadata.Metadata
+	for i := range meta.UnionMembers {
+		m := &meta.UnionMembers[i]
+		if hasString && (m.Kind == metadata.KindNative || m.Kind == metadata.KindAtomic) {
+			continue
+		}
+		members = append(members, m)
+	}
+	return members
+}
@@ -0,0 +1,61 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// generatePruneFunction generates: export function prune<Name>(input) { ... }
+// It deletes, in place, every own key that is not a declared property, on the
+// value and on all nested objects. Objects with an index signature keep their
+// keys. Unions are only descended into when they are discriminated or have a
+// single object-like member, since the shape of other object unions cannot be
+// told apart without validation.
+func generatePruneFunction(e *Emitter, typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry) {
+	keys := newKeySets("_pk_" + typeName + "_")
+	w := newWalker(registry, pruneOp{keys: keys}, keys)
+	w.define(typeName, meta)
+
+	e.Block("export function prune%s(input)", typeName)
+	e.Line("%s(input);", pruneFuncName(typeName))
+	e.EndBlock()
+	w.emit(e)
+}
+
+// pruneFuncName returns the local prune function name for a named type.
+func pruneFuncName(typeName string) string {
+	return "_prune_" + typeName
+}
+
+// pruneOp removes undeclared keys from objects.
+type pruneOp struct {
+	walkDefaults
+	keys *keySets // declared keys of closed objects
+}
+
+func (pruneOp) funcName(typeName string) string { return pruneFuncName(typeName) }
+
+func (pruneOp) applies(meta *metadata.Metadata) bool { return meta.Kind == metadata.KindObject }
+
+func (pruneOp) call(e *Emitter, fn string, accessor string, pathExpr string) {
+	e.Line("%s(%s);", fn, accessor)
+}
+
+// enter deletes the undeclared keys of closed objects (and of intersections,
+// which keep the keys of all their members).
+func (op pruneOp) enter(e *Emitter, accessor string, meta *metadata.Metadata, props []metadata.Property, depth int) {
+	if meta.IndexSignature != nil {
+		return
+	}
+	kVar := fmt.Sprintf("_k%d", depth)
+	e.Block("for (const %s of Object.keys(%s))", kVar, accessor)
+	e.Line("if (!%s.has(%s)) delete %s[%s];", op.keys.ref(propNames(props)), kVar, accessor, kVar)
+	e.EndBlock()
+}
+
+// intersectionMembers returns no member: pruning one member on its own would
+// delete the keys of the others.
+func (pruneOp) intersectionMembers(meta *metadata.Metadata) []*metadata.Metadata {
+	return nil
+}
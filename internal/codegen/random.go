@@ -0,0 +1,607 @@
+package codegen
+
+import (
+	"fmt"
+	"math"
+	"regexp/syntax"
+	"sort"
+	"strings"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// randomHelpers lists the _tsgonest_helpers.js exports used by random generators.
+var randomHelpers = []string{"__rng", "__rint", "__rnum", "__rstr", "__rch", "__rrep", "__rarr", "__rpick", "__rfmt"}
+
+// randomCtx tracks the local generator functions emitted for named types.
+// Every named type reachable from the root gets one `_rnd_<Name>(r, d)` function,
+// so recursive types call back into their own generator with an increased depth.
+type randomCtx struct {
+	registry *metadata.TypeRegistry
+	settings *Settings
+	order    []string
+	bodies   map[string]string
+}
+
+// generateRandomFunction generates: export function random<Name>(options) { ... }
+// The generator produces values satisfying the type's constraints (formats,
+// bounds, lengths, patterns, literals, unions). options.seed makes the output
+// deterministic and options.maxDepth (default 3) bounds optional properties,
+// nullable branches, array lengths and recursion.
+func generateRandomFunction(e *Emitter, typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry, s *Settings) {
+	ctx := &randomCtx{registry: registry, settings: s, bodies: make(map[string]string)}
+	ctx.define(typeName, meta)
+
+	e.Block("export function random%s(options)", typeName)
+	e.Line("return %s(__rng(options), 0);", randomFuncName(typeName))
+	e.EndBlock()
+	for _, name := range ctx.order {
+		e.Block("function %s(r, d)", randomFuncName(name))
+		e.Line("return %s;", ctx.bodies[name])
+		e.EndBlock()
+	}
+}
+
+// randomFuncName returns the local generator function name for a named type.
+func randomFuncName(typeName string) string {
+	return "_rnd_" + typeName
+}
+
+// define emits the generator for a named type once.
+func (ctx *randomCtx) define(name string, meta *metadata.Metadata) {
+	if _, ok := ctx.bodies[name]; ok {
+		return
+	}
+	ctx.bodies[name] = "" // reserve before generating so recursive refs terminate
+	ctx.order = append(ctx.order, name)
+	ctx.bodies[name] = ctx.expr(meta, nil)
+}
+
+// expr returns a JS expression producing a random value for meta.
+// Property-level constraints take precedence over constraints on the type itself.
+func (ctx *randomCtx) expr(meta *metadata.Metadata, c *metadata.Constraints) string {
+	if c == nil {
+		c = meta.Constraints
+	}
+	if meta.Nullable {
+		inner := *meta
+		inner.Nullable = false
+		return fmt.Sprintf("(d < r.m && r.n() < 0.8 ? %s : null)", ctx.expr(&inner, c))
+	}
+
+	switch meta.Kind {
+	case metadata.KindAtomic:
+		return randomAtomicExpr(meta, c, ctx.settings)
+	case metadata.KindLiteral:
+		if meta.LiteralValue == nil {
+			return "null"
+		}
+		return jsLiteral(meta.LiteralValue)
+	case metadata.KindEnum:
+		values := make([]string, 0, len(meta.EnumValues))
+		for _, ev := range meta.EnumValues {
+			values = append(values, jsLiteral(ev.Value))
+		}
+		if len(values) == 0 {
+			return "undefined"
+		}
+		return fmt.Sprintf("__rpick(r, [%s])", strings.Join(values, ", "))
+	case metadata.KindObject:
+		return ctx.objectExpr(meta)
+	case metadata.KindArray:
+		if meta.ElementType == nil {
+			return "[]"
+		}
+		return ctx.arrayExpr(ctx.expr(meta.ElementType, nil), c)
+	case metadata.KindTuple:
+		var elems []string
+		for i := range meta.Elements {
+			if meta.Elements[i].Rest {
+				continue
+			}
+			elems = append(elems, ctx.expr(&meta.Elements[i].Type, nil))
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case metadata.KindUnion:
+		return ctx.unionExpr(meta)
+	case metadata.KindIntersection:
+		return ctx.intersectionExpr(meta)
+	case metadata.KindNative:
+		return ctx.nativeExpr(meta)
+	case metadata.KindRef:
+		resolved, ok := ctx.registry.Types[meta.Ref]
+		if !ok {
+			return "null"
+		}
+		ctx.define(meta.Ref, resolved)
+		return fmt.Sprintf("%s(r, d + 1)", randomFuncName(meta.Ref))
+	case metadata.KindVoid, metadata.KindNever:
+		return "undefined"
+	default:
+		return "null"
+	}
+}
+
+// objectExpr generates an object literal. Optional properties are included
+// at random while below the depth limit.
+func (ctx *randomCtx) objectExpr(meta *metadata.Metadata) string {
+	var required []string
+	var optional []string
+	for i := range meta.Properties {
+		prop := &meta.Properties[i]
+		value := ctx.expr(&prop.Type, prop.Constraints)
+		if prop.Required && !prop.Type.Optional {
+			required = append(required, fmt.Sprintf("%s: %s", jsObjectKey(prop.Name), value))
+			continue
+		}
+		optional = append(optional, fmt.Sprintf("if (d < r.m && r.n() < 0.5) %s = %s;", jsPropAccess("o", prop.Name), value))
+	}
+	if meta.IndexSignature != nil {
+		key := randomIndexKeyExpr(&meta.IndexSignature.KeyType)
+		value := ctx.expr(&meta.IndexSignature.ValueType, nil)
+		entries := 2
+		if limit := ctx.settings.Limits.MaxProperties; limit > 0 {
+			entries = min(entries, max(0, limit-len(meta.Properties)))
+		}
+		optional = append(optional, fmt.Sprintf("for (var i = d < r.m ? __rint(r, 0, %d) : 0; i > 0; i--) o[%s] = %s;", entries, key, value))
+	}
+
+	literal := "{}"
+	if len(required) > 0 {
+		literal = "{ " + strings.Join(required, ", ") + " }"
+	}
+	if len(optional) == 0 {
+		return literal
+	}
+	return fmt.Sprintf("(function () { var o = %s; %s return o; }())", literal, strings.Join(optional, " "))
+}
+
+// randomIndexKeyExpr generates a key for an index signature entry.
+func randomIndexKeyExpr(key *metadata.Metadata) string {
+	if key.Kind == metadata.KindAtomic && key.Atomic == "number" {
+		return "__rint(r, 0, 1000)"
+	}
+	if key.TemplatePattern != "" {
+		if gen, ok := randomPatternExpr(key.TemplatePattern); ok {
+			return gen
+		}
+	}
+	return "__rstr(r, 3, 8)"
+}
+
+// arrayExpr generates an array honoring minItems/maxItems/uniqueItems.
+func (ctx *randomCtx) arrayExpr(elem string, c *metadata.Constraints) string {
+	lo, hi := 0, 3
+	unique := false
+	if c != nil {
+		if c.MinItems != nil {
+			lo = *c.MinItems
+			if hi < lo {
+				hi = lo + 3
+			}
+		}
+		if c.MaxItems != nil {
+			hi = *c.MaxItems
+			if lo > hi {
+				lo = hi
+			}
+		}
+		unique = c.UniqueItems != nil && *c.UniqueItems
+	}
+	// transforms.limits bounds arrays without a declared maxItems
+	if limit := ctx.settings.Limits.MaxArrayLength; limit > 0 && (c == nil || c.MaxItems == nil) {
+		hi = min(hi, limit)
+		lo = min(lo, hi)
+	}
+	return fmt.Sprintf("__rarr(r, d, %d, %d, %t, function () { return %s; })", lo, hi, unique, elem)
+}
+
+// unionExpr picks a random member. At the depth limit, a terminal member
+// (literal, atomic, enum, null) is preferred so recursive unions terminate.
+func (ctx *randomCtx) unionExpr(meta *metadata.Metadata) string {
+	members := meta.UnionMembers
+	if len(members) == 0 {
+		return "undefined"
+	}
+	if len(members) == 1 {
+		return ctx.expr(&members[0], nil)
+	}
+	terminal := 0
+	for i := range members {
+		if k := members[i].Kind; k == metadata.KindLiteral || k == metadata.KindAtomic || k == metadata.KindEnum {
+			terminal = i
+			break
+		}
+	}
+
+	var cases []string
+	for i := range members {
+		cases = append(cases, fmt.Sprintf("case %d: return %s;", i, ctx.expr(&members[i], nil)))
+	}
+	return fmt.Sprintf("(function (i) { switch (i) { %s } }(d < r.m ? __rint(r, 0, %d) : %d))", strings.Join(cases, " "), len(members)-1, terminal)
+}
+
+// intersectionExpr merges object members; non-object intersections fall back
+// to the first member (branded primitives are already folded into atomics).
+func (ctx *randomCtx) intersectionExpr(meta *metadata.Metadata) string {
+	if len(meta.IntersectionMembers) == 0 {
+		return "{}"
+	}
+	var parts []string
+	for i := range meta.IntersectionMembers {
+		member := &meta.IntersectionMembers[i]
+		resolved := member
+		if member.Kind == metadata.KindRef {
+			if r, ok := ctx.registry.Types[member.Ref]; ok {
+				resolved = r
+			}
+		}
+		if resolved.Kind != metadata.KindObject {
+			return ctx.expr(&meta.IntersectionMembers[0], nil)
+		}
+		parts = append(parts, ctx.expr(member, nil))
+	}
+	return fmt.Sprintf("Object.assign({}, %s)", strings.Join(parts, ", "))
+}
+
+// nativeExpr generates values for built-in classes.
+func (ctx *randomCtx) nativeExpr(meta *metadata.Metadata) string {
+	switch meta.NativeType {
+	case "Date":
+		return "new Date(__rint(r, 946684800000, 1893456000000))"
+	case "RegExp":
+		return "/^[a-z]+$/"
+	case "Map":
+		if len(meta.TypeArguments) == 2 {
+			entry := fmt.Sprintf("[%s, %s]", ctx.expr(&meta.TypeArguments[0], nil), ctx.expr(&meta.TypeArguments[1], nil))
+			return fmt.Sprintf("new Map(%s)", ctx.arrayExpr(entry, nil))
+		}
+		return "new Map()"
+	case "Set":
+		if len(meta.TypeArguments) == 1 {
+			return fmt.Sprintf("new Set(%s)", ctx.arrayExpr(ctx.expr(&meta.TypeArguments[0], nil), nil))
+		}
+		return "new Set()"
+	case "Uint8Array", "Buffer":
+		return "new Uint8Array(__rarr(r, d, 1, 16, false, function () { return __rint(r, 0, 255); }))"
+	default:
+		return "null"
+	}
+}
+
+// randomAtomicExpr generates primitives that satisfy the given constraints.
+func randomAtomicExpr(meta *metadata.Metadata, c *metadata.Constraints, s *Settings) string {
+	switch meta.Atomic {
+	case "string":
+		return randomStringExpr(meta, c, s)
+	case "number":
+		return randomNumberExpr(c, false)
+	case "bigint":
+		return fmt.Sprintf("BigInt(%s)", randomNumberExpr(c, true))
+	case "boolean":
+		return "r.n() < 0.5"
+	case "null":
+		return "null"
+	case "undefined":
+		return "undefined"
+	default:
+		return "null"
+	}
+}
+
+// randomStringExpr handles pattern, format, length and content constraints,
+// in that order of precedence.
+func randomStringExpr(meta *metadata.Metadata, c *metadata.Constraints, s *Settings) string {
+	if c != nil && c.Pattern != nil {
+		if gen, ok := randomPatternExpr(*c.Pattern); ok {
+			return gen
+		}
+	}
+	if meta.TemplatePattern != "" {
+		if gen, ok := randomPatternExpr(meta.TemplatePattern); ok {
+			return gen
+		}
+	}
+	if c != nil && c.Format != nil {
+		// Regex custom formats generate from their pattern; predicate formats
+		// fall back to the default string of __rfmt.
+		if f, ok := s.customFormat(*c.Format); ok && f.Pattern != "" {
+			if gen, ok := randomPatternExpr(f.Pattern); ok {
+				return gen
+			}
+		}
+		return fmt.Sprintf("__rfmt(r, %q)", *c.Format)
+	}
+
+	var prefix, suffix, includes string
+	lo, hi := -1, -1
+	if c != nil {
+		if c.StartsWith != nil {
+			prefix = *c.StartsWith
+		}
+		if c.EndsWith != nil {
+			suffix = *c.EndsWith
+		}
+		if c.Includes != nil {
+			includes = *c.Includes
+		}
+		if c.MinLength != nil {
+			lo = *c.MinLength
+		}
+		if c.MaxLength != nil {
+			hi = *c.MaxLength
+		}
+	}
+	switch {
+	case lo < 0 && hi < 0:
+		lo, hi = 5, 12
+	case hi < 0:
+		hi = lo + 10
+	case lo < 0:
+		lo = min(1, hi)
+	}
+	// transforms.limits bounds strings without a declared maxLength
+	if limit := s.Limits.MaxStringLength; limit > 0 && (c == nil || c.MaxLength == nil) {
+		hi = min(hi, limit)
+		lo = min(lo, hi)
+	}
+	fixed := len(prefix) + len(suffix) + len(includes)
+	lo, hi = max(0, lo-fixed), max(0, hi-fixed)
+
+	parts := []string{fmt.Sprintf("__rstr(r, %d, %d)", lo, hi)}
+	if includes != "" {
+		parts = append(parts, jsLiteral(includes))
+	}
+	if prefix != "" {
+		parts = append([]string{jsLiteral(prefix)}, parts...)
+	}
+	if suffix != "" {
+		parts = append(parts, jsLiteral(suffix))
+	}
+	expr := strings.Join(parts, " + ")
+	if c != nil && c.Uppercase != nil && *c.Uppercase {
+		return "(" + expr + ").toUpperCase()"
+	}
+	if c != nil && c.Lowercase != nil && *c.Lowercase {
+		return "(" + expr + ").toLowerCase()"
+	}
+	return expr
+}
+
+// randomNumberExpr generates a number (or integer when integral is true or the
+// numeric type requires it) within the min/max bounds and multipleOf step.
+func randomNumberExpr(c *metadata.Constraints, integral bool) string {
+	var lo, hi *float64
+	exclusiveLo, exclusiveHi := false, false
+	var step float64
+	if c != nil {
+		lo, hi = c.Minimum, c.Maximum
+		if c.ExclusiveMinimum != nil {
+			lo, exclusiveLo = c.ExclusiveMinimum, true
+		}
+		if c.ExclusiveMaximum != nil {
+			hi, exclusiveHi = c.ExclusiveMaximum, true
+		}
+		if c.MultipleOf != nil && *c.MultipleOf > 0 {
+			step = *c.MultipleOf
+		}
+		if c.NumericType != nil {
+			switch *c.NumericType {
+			case "int32", "uint32", "int64", "uint64":
+				integral = true
+			}
+		}
+	}
+
+	low, high := 0.0, 1000.0
+	switch {
+	case lo != nil && hi != nil:
+		low, high = *lo, *hi
+	case lo != nil:
+		low, high = *lo, *lo+1000
+	case hi != nil:
+		low, high = *hi-1000, *hi
+	}
+	if c != nil && c.NumericType != nil {
+		switch *c.NumericType {
+		case "int32":
+			low, high = math.Max(low, math.MinInt32), math.Min(high, math.MaxInt32)
+		case "uint32":
+			low, high = math.Max(low, 0), math.Min(high, math.MaxUint32)
+		case "int64":
+			low, high = math.Max(low, -9007199254740991), math.Min(high, 9007199254740991)
+		case "uint64":
+			low, high = math.Max(low, 0), math.Min(high, 9007199254740991)
+		}
+	}
+
+	if step == 0 && integral {
+		step = 1
+	}
+	if step > 0 {
+		kLo, kHi := math.Ceil(low/step), math.Floor(high/step)
+		if exclusiveLo && kLo*step <= low {
+			kLo++
+		}
+		if exclusiveHi && kHi*step >= high {
+			kHi--
+		}
+		if kHi < kLo {
+			kHi = kLo
+		}
+		if step == 1 {
+			return fmt.Sprintf("__rint(r, %v, %v)", kLo, kHi)
+		}
+		return fmt.Sprintf("__rint(r, %v, %v) * %v", kLo, kHi, step)
+	}
+	if exclusiveLo {
+		low += (high - low) * 1e-6
+	}
+	return fmt.Sprintf("__rnum(r, %v, %v)", low, high)
+}
+
+// randomPatternExpr compiles a regular expression into a JS expression that
+// produces a matching string. Returns false for syntax RE2 cannot parse
+// (lookarounds, backreferences), in which case the caller falls back.
+func randomPatternExpr(pattern string) (string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	return randomRegexpExpr(re), true
+}
+
+func randomRegexpExpr(re *syntax.Regexp) string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return jsLiteral(string(re.Rune))
+	case syntax.OpCharClass:
+		return fmt.Sprintf("__rch(r, [%s])", joinRuneRanges(printableRanges(re.Rune)))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return "__rch(r, [97, 122, 48, 57])"
+	case syntax.OpCapture:
+		return randomRegexpExpr(re.Sub[0])
+	case syntax.OpConcat:
+		parts := make([]string, 0, len(re.Sub))
+		for _, sub := range re.Sub {
+			if part := randomRegexpExpr(sub); part != `""` {
+				parts = append(parts, part)
+			}
+		}
+		if len(parts) == 0 {
+			return `""`
+		}
+		return "(" + strings.Join(parts, " + ") + ")"
+	case syntax.OpAlternate:
+		cases := make([]string, len(re.Sub))
+		for i, sub := range re.Sub {
+			cases[i] = fmt.Sprintf("function () { return %s; }", randomRegexpExpr(sub))
+		}
+		return fmt.Sprintf("__rpick(r, [%s])()", strings.Join(cases, ", "))
+	case syntax.OpStar:
+		return randomRepeatExpr(re.Sub[0], 0, 3)
+	case syntax.OpPlus:
+		return randomRepeatExpr(re.Sub[0], 1, 4)
+	case syntax.OpQuest:
+		return randomRepeatExpr(re.Sub[0], 0, 1)
+	case syntax.OpRepeat:
+		hi := re.Max
+		if hi < 0 {
+			hi = re.Min + 3
+		}
+		return randomRepeatExpr(re.Sub[0], re.Min, hi)
+	default:
+		// Anchors, word boundaries and empty matches produce no characters.
+		return `""`
+	}
+}
+
+func randomRepeatExpr(sub *syntax.Regexp, lo, hi int) string {
+	return fmt.Sprintf("__rrep(r, %d, %d, function () { return %s; })", lo, hi, randomRegexpExpr(sub))
+}
+
+// printableRanges narrows a character class to printable ASCII when possible,
+// so negated classes like [^,] don't produce control or astral characters.
+func printableRanges(ranges []rune) []rune {
+	var out []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := max(ranges[i], 0x20), min(ranges[i+1], 0x7e)
+		if lo <= hi {
+			out = append(out, lo, hi)
+		}
+	}
+	if len(out) == 0 {
+		return ranges
+	}
+	return out
+}
+
+func joinRuneRanges(ranges []rune) string {
+	parts := make([]string, len(ranges))
+	for i, r := range ranges {
+		parts[i] = fmt.Sprintf("%d", r)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// randomFormatCases maps format names to JS generator expressions used by the
+// __rfmt helper. Each output matches the corresponding formatRegexes entry.
+var randomFormatCases = map[string]string{
+	"email":                 `__rstr(r, 3, 10) + "@" + __rstr(r, 3, 8) + ".com"`,
+	"idn-email":             `__rstr(r, 3, 10) + "@" + __rstr(r, 3, 8) + ".com"`,
+	"uuid":                  `"xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx".replace(/[xy]/g, function (c) { var v = __rint(r, 0, 15); return (c === "x" ? v : (v & 3) | 8).toString(16); })`,
+	"hostname":              `__rstr(r, 3, 10) + ".com"`,
+	"idn-hostname":          `__rstr(r, 3, 10) + ".com"`,
+	"url":                   `"https://" + __rstr(r, 3, 10) + ".com/" + __rstr(r, 1, 8)`,
+	"uri":                   `"https://" + __rstr(r, 3, 10) + ".com/" + __rstr(r, 1, 8)`,
+	"iri":                   `"https://" + __rstr(r, 3, 10) + ".com/" + __rstr(r, 1, 8)`,
+	"uri-reference":         `"/" + __rstr(r, 1, 8)`,
+	"iri-reference":         `"https://" + __rstr(r, 3, 10) + ".com/" + __rstr(r, 1, 8)`,
+	"uri-template":          `"https://" + __rstr(r, 3, 10) + ".com/{id}"`,
+	"ipv4":                  `[0, 0, 0, 0].map(function () { return __rint(r, 0, 255); }).join(".")`,
+	"ipv6":                  `[0, 0, 0, 0, 0, 0, 0, 0].map(function () { return __rint(r, 0, 65535).toString(16); }).join(":")`,
+	"cidrv4":                `[0, 0, 0, 0].map(function () { return __rint(r, 0, 255); }).join(".") + "/" + __rint(r, 0, 32)`,
+	"cidrv6":                `[0, 0, 0, 0, 0, 0, 0, 0].map(function () { return __rint(r, 0, 65535).toString(16); }).join(":") + "/" + __rint(r, 0, 128)`,
+	"mac":                   `[0, 0, 0, 0, 0, 0].map(function () { return (256 + __rint(r, 0, 255)).toString(16).slice(1); }).join(":")`,
+	"date-time":             `new Date(__rint(r, 946684800000, 1893456000000)).toISOString()`,
+	"date":                  `new Date(__rint(r, 946684800000, 1893456000000)).toISOString().slice(0, 10)`,
+	"time":                  `new Date(__rint(r, 946684800000, 1893456000000)).toISOString().slice(11)`,
+	"duration":              `"P" + __rint(r, 1, 30) + "D"`,
+	"byte":                  `btoa(__rstr(r, 3, 12))`,
+	"base64url":             `btoa(__rstr(r, 3, 12)).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "")`,
+	"hex":                   `__rstr(r, 8, 16, "0123456789abcdef")`,
+	"json-pointer":          `"/" + __rstr(r, 1, 8)`,
+	"relative-json-pointer": `"0/" + __rstr(r, 1, 8)`,
+	"nanoid":                `__rstr(r, 21, 21, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_-")`,
+	"cuid":                  `"c" + __rstr(r, 24, 24)`,
+	"cuid2":                 `__rstr(r, 1, 1, "abcdefghijklmnopqrstuvwxyz") + __rstr(r, 23, 23)`,
+	"ulid":                  `__rstr(r, 26, 26, "0123456789ABCDEFGHJKMNPQRSTVWXYZ")`,
+	"jwt":                   `[0, 0, 0].map(function () { return __rstr(r, 8, 16, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_-"); }).join(".")`,
+	"emoji":                 `__rpick(r, ["\u{1F600}", "\u{1F680}", "\u{1F389}", "\u{1F44D}"])`,
+	"password":              `__rstr(r, 8, 16)`,
+	"regex":                 `"^[a-z]+$"`,
+}
+
+// generateRandomHelpers emits the shared random generation helpers into the
+// helpers file: a seedable PRNG (mulberry32) and primitive generators.
+func generateRandomHelpers(e *Emitter) {
+	e.Block("export function __rng(o)")
+	e.Line("var s = (o && o.seed != null ? o.seed : Math.random() * 4294967296) >>> 0;")
+	e.Line("return { m: o && o.maxDepth != null ? o.maxDepth : 3, n: function () { s = (s + 0x6D2B79F5) | 0; var t = Math.imul(s ^ (s >>> 15), 1 | s); t = (t + Math.imul(t ^ (t >>> 7), 61 | t)) ^ t; return ((t ^ (t >>> 14)) >>> 0) / 4294967296; } };")
+	e.EndBlock()
+	e.Line("export function __rint(r, lo, hi) { return lo + Math.floor(r.n() * (hi - lo + 1)); }")
+	e.Line("export function __rnum(r, lo, hi) { return lo + r.n() * (hi - lo); }")
+	e.Line("export function __rstr(r, lo, hi, a) { a = a || \"abcdefghijklmnopqrstuvwxyz0123456789\"; var n = __rint(r, lo, hi), s = \"\"; while (s.length < n) s += a[__rint(r, 0, a.length - 1)]; return s; }")
+	e.Line("export function __rch(r, c) { var i = __rint(r, 0, c.length / 2 - 1) * 2; return String.fromCodePoint(__rint(r, c[i], c[i + 1])); }")
+	e.Line("export function __rrep(r, lo, hi, f) { var n = __rint(r, lo, hi), s = \"\"; for (var i = 0; i < n; i++) s += f(); return s; }")
+	e.Line("export function __rarr(r, d, lo, hi, u, f) { var n = d < r.m ? __rint(r, lo, hi) : lo, a = [], k = u ? new Set() : null; for (var t = 0; a.length < n && t < n * 10; t++) { var v = f(); if (k) { var j = typeof v === \"object\" && v !== null ? JSON.stringify(v) : v; if (k.has(j)) continue; k.add(j); } a.push(v); } return a; }")
+	e.Line("export function __rpick(r, a) { return a[__rint(r, 0, a.length - 1)]; }")
+
+	names := make([]string, 0, len(randomFormatCases))
+	for name := range randomFormatCases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	e.Block("export function __rfmt(r, f)")
+	e.Block("switch (f)")
+	for _, name := range names {
+		e.Line("case %q: return %s;", name, randomFormatCases[name])
+	}
+	e.Line("default: return __rstr(r, 5, 12);")
+	e.EndBlock()
+	e.EndBlock()
+}
+
+// generateRandomHelpersTypes emits declarations for generateRandomHelpers.
+func generateRandomHelpersTypes(e *Emitter) {
+	e.Line("export interface __Rng { m: number; n(): number; }")
+	e.Line("export declare function __rng(options?: { seed?: number; maxDepth?: number }): __Rng;")
+	e.Line("export declare function __rint(r: __Rng, lo: number, hi: number): number;")
+	e.Line("export declare function __rnum(r: __Rng, lo: number, hi: number): number;")
+	e.Line("export declare function __rstr(r: __Rng, lo: number, hi: number, alphabet?: string): string;")
+	e.Line("export declare function __rch(r: __Rng, ranges: number[]): string;")
+	e.Line("export declare function __rrep(r: __Rng, lo: number, hi: number, f: () => string): string;")
+	e.Line("export declare function __rarr<T>(r: __Rng, depth: number, lo: number, hi: number, unique: boolean, f: () => T): T[];")
+	e.Line("export declare function __rpick<T>(r: __Rng, values: readonly T[]): T;")
+	e.Line("export declare function __rfmt(r: __Rng, format: string): string;")
+}
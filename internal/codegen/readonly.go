@@ -0,0 +1,60 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// Read-only property handling of request bodies (transforms.readOnly).
+const (
+	// ReadOnlyStrip deletes read-only properties from request bodies.
+	ReadOnlyStrip = "strip"
+	// ReadOnlyReject fails request bodies containing read-only properties.
+	ReadOnlyReject = "reject"
+)
+
+// HasReadOnlyProperties reports whether meta contains read-only properties
+// (readonly or @readOnly), which request bodies strip or reject.
+func HasReadOnlyProperties(meta *metadata.Metadata, registry *metadata.TypeRegistry) bool {
+	return hasProperty(meta, registry, func(p *metadata.Property) bool { return p.Readonly }, make(map[string]bool))
+}
+
+// generateAssertRequestFunction generates assertRequest<Name>, the assert
+// function of request bodies: read-only properties are deleted or rejected
+// (Settings.ReadOnlyMode) instead of checked, so a DTO shared by requests and
+// responses does not require its server-set properties from clients, sent
+// deprecated properties are reported (Settings.DeprecationHook), and
+// properties are read under their JSON name (see emitJSONNameRename).
+func generateAssertRequestFunction(e *Emitter, typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry, s *Settings) {
+	if !NeedsAssertRequest(meta, registry, s) {
+		e.Block("export function assertRequest%s(input)", typeName)
+		e.Line("return assert%s(input);", typeName)
+		e.EndBlock()
+		return
+	}
+	ctx := &validateCtx{generating: map[string]bool{typeName: true}, request: true, cfg: s}
+	generateAssertFunction(e, typeName, meta, registry, ctx)
+}
+
+// NeedsAssertRequest reports whether request bodies of meta are checked
+// differently from assert<Name> with the settings s: it has read-only
+// properties and a read-only mode is set, deprecated properties and a
+// deprecation hook, or properties with a JSON name.
+func NeedsAssertRequest(meta *metadata.Metadata, registry *metadata.TypeRegistry, s *Settings) bool {
+	return (s.ReadOnlyMode != "" && HasReadOnlyProperties(meta, registry)) ||
+		(s.DeprecationHook.Module != "" && HasDeprecatedProperties(meta, registry)) ||
+		HasJSONNames(meta, registry)
+}
+
+// emitReadOnlyRequestCheck emits the request handling of the read-only
+// property at propAccessor: its deletion, or a readOnly error when present.
+func emitReadOnlyRequestCheck(e *Emitter, propAccessor string, propPathExpr string, mode string) {
+	if mode == ReadOnlyReject {
+		e.Block("if (%s !== undefined)", propAccessor)
+		emitAssertThrowFields(e, propPathExpr, "undefined (readOnly)", fmt.Sprintf("typeof %s", propAccessor), errorFields("readOnly", ""))
+		e.EndBlock()
+		return
+	}
+	e.Line("delete %s;", propAccessor)
+}
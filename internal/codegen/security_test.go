@@ -861,6 +861,13 @@ func TestHelpers_ErrorClassHasStatus400(t *testing.T) {
 	assertContains(t, code, "this.status = 400")
 }
 
+func TestHelpers_ErrorClassHasConfiguredStatus(t *testing.T) {
+	code := GenerateHelpersFile("dist", CompanionOptions{Settings: Settings{ValidationErrorStatus: 422}})[0].Content
+	assertContains(t, code, "this.status = 422")
+	// Settings do not leak into later builds
+	assertContains(t, GenerateHelpersFile("dist", CompanionOptions{})[0].Content, "this.status = 400")
+}
+
 func TestHelpers_ErrorClassExtendsError(t *testing.T) {
 	code := GenerateHelpers()
 	assertContains(t, code, "extends Error")
@@ -0,0 +1,52 @@
+package codegen
+
+import (
+	"encoding/json"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// isSensitive reports whether the constraints mark the value as sensitive
+// (Sensitive / @sensitive).
+func isSensitive(c *metadata.Constraints) bool {
+	return c != nil && c.Sensitive != nil && *c.Sensitive
+}
+
+// redactBy redacts the received values of the checks generated next when c
+// is sensitive, including the checks of nested values, and returns the
+// previous state. Pass it to restoreRedact once the property is generated.
+func (ctx *validateCtx) redactBy(c *metadata.Constraints) bool {
+	if ctx == nil {
+		return false
+	}
+	prev := ctx.redact
+	if isSensitive(c) {
+		ctx.redact = true
+	}
+	return prev
+}
+
+func (ctx *validateCtx) restoreRedact(prev bool) {
+	if ctx != nil {
+		ctx.redact = prev
+	}
+}
+
+// received returns the received field of an error on the value at accessor:
+// expr, or the type of the value when redacted.
+func (ctx *validateCtx) received(accessor string, expr string) string {
+	if ctx != nil && ctx.redact {
+		return "typeof " + accessor
+	}
+	return expr
+}
+
+// sensitiveMaskExpr returns a JS string expression of the JSON serialized in
+// place of prop (Settings.SensitiveMask), and whether prop is masked.
+func sensitiveMaskExpr(prop *metadata.Property, s *Settings) (string, bool) {
+	if s.SensitiveMask == "" || !isSensitive(prop.Constraints) {
+		return "", false
+	}
+	b, _ := json.Marshal(s.SensitiveMask)
+	return "\"" + jsStringEscape(string(b)) + "\"", true
+}
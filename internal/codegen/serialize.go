@@ -12,11 +12,25 @@ import (
 type serializeCtx struct {
 	// generating tracks type names currently being generated to detect recursion.
 	generating map[string]bool
+	// view is the serialization view ("" by default): properties with groups
+	// are only written in the views named by one of them.
+	view string
+	// cfg holds the settings of the generated code (nil: the defaults).
+	cfg *Settings
+}
+
+// settings returns the settings of the generated code.
+func (ctx *serializeCtx) settings() *Settings {
+	if ctx == nil || ctx.cfg == nil {
+		return &Settings{}
+	}
+	return ctx.cfg
 }
 
 // generateSerializeFunction generates: export function serialize<Name>(input) { ... }
+// (serialize<Name>_<view> in a view, see ViewFuncName).
 func generateSerializeFunction(e *Emitter, typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry, ctx *serializeCtx) {
-	fnName := "serialize" + typeName
+	fnName := ViewFuncName("serialize", typeName, ctx.view)
 	e.Block("export function %s(input)", fnName)
 	e.Line("return %s;", generateSerializeExpr("input", meta, registry, 0, ctx))
 	e.EndBlock()
@@ -55,7 +69,7 @@ func generateSerializeExpr(accessor string, meta *metadata.Metadata, registry *m
 	case metadata.KindRef:
 		// For recursive references, emit a function call to prevent infinite codegen recursion
 		if ctx != nil && ctx.generating[meta.Ref] {
-			fnName := "serialize" + meta.Ref
+			fnName := ViewFuncName("serialize", meta.Ref, ctx.view)
 			return fmt.Sprintf("%s(%s)", fnName, accessor)
 		}
 		if resolved, ok := registry.Types[meta.Ref]; ok {
@@ -103,6 +117,12 @@ func generateSerializeExpr(accessor string, meta *metadata.Metadata, registry *m
 			}
 			return generateSerializeExpr(accessor, merged, registry, depth, ctx)
 		}
+		// A & (B | C): serialized as (A & B) | (A & C)
+		if needsShapedSerialize(meta, registry, ctx.settings()) {
+			if distributed, ok := distributeIntersection(meta, registry); ok {
+				return generateSerializeExpr(accessor, distributed, registry, depth, ctx)
+			}
+		}
 		return fmt.Sprintf("JSON.stringify(%s)", accessor)
 
 	case metadata.KindEnum:
@@ -182,9 +202,27 @@ func generateSerializeLiteral(value any) string {
 
 func generateSerializeObject(accessor string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *serializeCtx) string {
 	if len(meta.Properties) == 0 {
+		if meta.IndexSignature != nil && needsShapedSerialize(&meta.IndexSignature.ValueType, registry, ctx.settings()) {
+			return generateSerializeRecord(accessor, meta, registry, depth, ctx)
+		}
 		return fmt.Sprintf("JSON.stringify(%s)", accessor)
 	}
 
+	// Write-only properties (@writeOnly) are accepted in requests but never
+	// serialized into responses, nor are grouped properties outside their views.
+	view := ""
+	if ctx != nil {
+		view = ctx.view
+	}
+	if props := readableProperties(meta.Properties, view); len(props) < len(meta.Properties) {
+		if len(props) == 0 {
+			return "\"{}\""
+		}
+		readable := *meta
+		readable.Properties = props
+		meta = &readable
+	}
+
 	// Check if any property is optional
 	hasOptional := false
 	for _, prop := range meta.Properties {
@@ -204,6 +242,18 @@ func generateSerializeObject(accessor string, meta *metadata.Metadata, registry
 	return generateSerializeObjectWithOptional(accessor, meta, registry, depth, ctx)
 }
 
+// readableProperties returns the props serialized in view: without the
+// write-only ones and the grouped ones outside view (see Property.InView).
+func readableProperties(props []metadata.Property, view string) []metadata.Property {
+	var readable []metadata.Property
+	for _, prop := range props {
+		if !prop.WriteOnly && prop.InView(view) {
+			readable = append(readable, prop)
+		}
+	}
+	return readable
+}
+
 // generateSerializeObjectAllRequired generates a template literal for objects
 // where all properties are required (no conditional key inclusion needed).
 // V8 pre-computes static segment sizes and fills dynamic parts in one pass.
@@ -218,9 +268,12 @@ func generateSerializeObjectAllRequired(accessor string, meta *metadata.Metadata
 			buf.WriteByte(',')
 		}
 		// Static key portion — use jsonKeyInTemplate for correct double-escaping
-		buf.WriteString(fmt.Sprintf(`\"%s\":`, jsonKeyInTemplate(prop.Name)))
+		buf.WriteString(fmt.Sprintf(`\"%s\":`, jsonKeyInTemplate(prop.WireName())))
 		// Dynamic value as ${...} interpolation
 		valExpr := generateSerializeExprTemplate(propAccessor, &prop.Type, registry, depth+1, ctx)
+		if mask, ok := sensitiveMaskExpr(&prop, ctx.settings()); ok {
+			valExpr = mask
+		}
 		buf.WriteString("${")
 		buf.WriteString(valExpr)
 		buf.WriteString("}")
@@ -275,8 +328,11 @@ func generateSerializeObjectWithOptional(accessor string, meta *metadata.Metadat
 		if i > 0 {
 			buf.WriteByte(',')
 		}
-		buf.WriteString(fmt.Sprintf(`\"%s\":`, jsonKeyInTemplate(prop.Name)))
+		buf.WriteString(fmt.Sprintf(`\"%s\":`, jsonKeyInTemplate(prop.WireName())))
 		valExpr := generateSerializeExprTemplate(propAccessor, &prop.Type, registry, depth+1, ctx)
+		if mask, ok := sensitiveMaskExpr(&prop, ctx.settings()); ok {
+			valExpr = mask
+		}
 		buf.WriteString("${")
 		buf.WriteString(valExpr)
 		buf.WriteString("}")
@@ -287,10 +343,13 @@ func generateSerializeObjectWithOptional(accessor string, meta *metadata.Metadat
 	for _, prop := range optional {
 		propAccessor := jsPropAccess(accessor, prop.Name)
 		valExpr := generateSerializeExpr(propAccessor, &prop.Type, registry, depth+1, ctx)
+		if mask, ok := sensitiveMaskExpr(&prop, ctx.settings()); ok {
+			valExpr = mask
+		}
 
 		// Build the key string literal as a JS string: ",\"name\":"
 		// Use jsonKeyInString for correct double-escaping (JSON layer + JS string layer)
-		keyLiteral := fmt.Sprintf(`",\"%s\":"`, jsonKeyInString(prop.Name))
+		keyLiteral := fmt.Sprintf(`",\"%s\":"`, jsonKeyInString(prop.WireName()))
 		buf.WriteString(fmt.Sprintf(` + (%s !== undefined ? %s + %s : "")`, propAccessor, keyLiteral, valExpr))
 	}
 
@@ -358,7 +417,9 @@ func generateSerializeNative(accessor string, meta *metadata.Metadata) string {
 //  3. Nullable unions (T | null → null check + serialize T)
 //  4. Atomic unions (string | number → typeof switch)
 //
-// Falls back to JSON.stringify for complex unions that don't match any pattern.
+// Falls back to JSON.stringify for complex unions that don't match any pattern,
+// unless their members have properties JSON.stringify would write wrongly (see
+// generateSerializeShapedUnion).
 func generateSerializeUnion(accessor string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *serializeCtx) string {
 	members := meta.UnionMembers
 	if len(members) == 0 {
@@ -386,7 +447,13 @@ func generateSerializeUnion(accessor string, meta *metadata.Metadata, registry *
 		return generateSerializeAtomicUnion(accessor, members)
 	}
 
-	// 5. Fallback: JSON.stringify
+	// 5. Members with write-only, sensitive, JSON-named or grouped properties:
+	// pick the member at runtime
+	if needsShapedSerialize(meta, registry, ctx.settings()) {
+		return generateSerializeShapedUnion(accessor, members, registry, depth, ctx)
+	}
+
+	// 6. Fallback: JSON.stringify
 	return fmt.Sprintf("JSON.stringify(%s)", accessor)
 }
 
@@ -417,7 +484,11 @@ func generateSerializeDiscriminatedUnion(accessor string, meta *metadata.Metadat
 		parts = append(parts, fmt.Sprintf("case %s: return %s;", jsLiteral(val), memberExpr))
 	}
 
-	parts = append(parts, fmt.Sprintf("default: return JSON.stringify(%s); } }())", accessor))
+	fallback := fmt.Sprintf("JSON.stringify(%s)", accessor)
+	if needsShapedSerialize(meta, registry, ctx.settings()) {
+		fallback = generateSerializeShapedUnion(accessor, meta.UnionMembers, registry, depth, ctx)
+	}
+	parts = append(parts, fmt.Sprintf("default: return %s; } }())", fallback))
 	return strings.Join(parts, " ")
 }
 
@@ -0,0 +1,197 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// needsShapedSerialize reports whether meta contains properties that
+// JSON.stringify would write wrongly: write-only, masked sensitive, JSON-named
+// or grouped ones. Such values are always serialized through their declared
+// shape, never with the JSON.stringify fallback.
+func needsShapedSerialize(meta *metadata.Metadata, registry *metadata.TypeRegistry, s *Settings) bool {
+	return hasProperty(meta, registry, func(p *metadata.Property) bool {
+		_, masked := sensitiveMaskExpr(p, s)
+		return p.WriteOnly || masked || p.WireName() != p.Name || (p.Constraints != nil && len(p.Constraints.Groups) > 0)
+	}, make(map[string]bool))
+}
+
+// generateSerializeShapedUnion serializes a union that cannot be told apart
+// by a discriminant or typeof alone by picking the member at runtime: each
+// member but the last is tried with a shallow check (see memberGuardExpr),
+// objects with more required properties first, and the last one is used
+// when none matches. Values are thus never written by JSON.stringify.
+func generateSerializeShapedUnion(accessor string, members []metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *serializeCtx) string {
+	ordered := make([]*metadata.Metadata, len(members))
+	for i := range members {
+		ordered[i] = &members[i]
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return memberSpecificity(ordered[i], registry) > memberSpecificity(ordered[j], registry)
+	})
+
+	expr := generateSerializeExpr(accessor, ordered[len(ordered)-1], registry, depth+1, ctx)
+	for i := len(ordered) - 2; i >= 0; i-- {
+		guard := memberGuardExpr(accessor, ordered[i], registry, make(map[string]bool))
+		expr = fmt.Sprintf("(%s ? %s : %s)", guard, generateSerializeExpr(accessor, ordered[i], registry, depth+1, ctx), expr)
+	}
+	return expr
+}
+
+// memberSpecificity orders union members for generateSerializeShapedUnion:
+// the number of required properties of objects, -1 for members matching
+// any value.
+func memberSpecificity(meta *metadata.Metadata, registry *metadata.TypeRegistry) int {
+	resolved := resolveSerializeRef(meta, registry)
+	switch resolved.Kind {
+	case metadata.KindObject:
+		n := 0
+		for _, prop := range resolved.Properties {
+			if prop.Required && !prop.Type.Optional {
+				n++
+			}
+		}
+		return n
+	case metadata.KindAny, metadata.KindUnknown:
+		return -1
+	}
+	return 0
+}
+
+// memberGuardExpr returns a shallow JS check of the value at accessor being
+// the union member meta: typeof and Array.isArray checks, and for objects the
+// presence of their required properties and the value of their literal ones.
+func memberGuardExpr(accessor string, meta *metadata.Metadata, registry *metadata.TypeRegistry, visited map[string]bool) string {
+	if meta.Nullable || meta.Optional {
+		inner := *meta
+		inner.Nullable = false
+		inner.Optional = false
+		return fmt.Sprintf("(%s == null || %s)", accessor, memberGuardExpr(accessor, &inner, registry, visited))
+	}
+	switch meta.Kind {
+	case metadata.KindAtomic:
+		if meta.Atomic == "null" {
+			return fmt.Sprintf("%s === null", accessor)
+		}
+		return generateIsAtomicExpr(accessor, meta)
+	case metadata.KindLiteral:
+		return fmt.Sprintf("%s === %s", accessor, jsLiteral(meta.LiteralValue))
+	case metadata.KindEnum:
+		vals := make([]string, len(meta.EnumValues))
+		for i, ev := range meta.EnumValues {
+			vals[i] = fmt.Sprintf("%s === %s", accessor, jsLiteral(ev.Value))
+		}
+		if len(vals) == 0 {
+			return "true"
+		}
+		return "(" + strings.Join(vals, " || ") + ")"
+	case metadata.KindNative:
+		return nativeInstanceCheck(accessor, meta.NativeType)
+	case metadata.KindArray, metadata.KindTuple:
+		return fmt.Sprintf("Array.isArray(%s)", accessor)
+	case metadata.KindObject:
+		parts := []string{fmt.Sprintf("typeof %s === \"object\" && %s !== null && !Array.isArray(%s)", accessor, accessor, accessor)}
+		for i := range meta.Properties {
+			prop := &meta.Properties[i]
+			propAccessor := jsPropAccess(accessor, prop.Name)
+			if prop.Type.Kind == metadata.KindLiteral && !prop.Type.Nullable && !prop.Type.Optional {
+				parts = append(parts, fmt.Sprintf("%s === %s", propAccessor, jsLiteral(prop.Type.LiteralValue)))
+			} else if prop.Required && !prop.Type.Optional {
+				parts = append(parts, fmt.Sprintf("%s !== undefined", propAccessor))
+			}
+		}
+		return "(" + strings.Join(parts, " && ") + ")"
+	case metadata.KindUnion:
+		parts := make([]string, len(meta.UnionMembers))
+		for i := range meta.UnionMembers {
+			parts[i] = memberGuardExpr(accessor, &meta.UnionMembers[i], registry, visited)
+		}
+		return "(" + strings.Join(parts, " || ") + ")"
+	case metadata.KindIntersection:
+		parts := make([]string, len(meta.IntersectionMembers))
+		for i := range meta.IntersectionMembers {
+			parts[i] = memberGuardExpr(accessor, &meta.IntersectionMembers[i], registry, visited)
+		}
+		return "(" + strings.Join(parts, " && ") + ")"
+	case metadata.KindRef:
+		resolved, ok := registry.Types[meta.Ref]
+		if !ok || visited[meta.Ref] {
+			return "true"
+		}
+		visited[meta.Ref] = true
+		guard := memberGuardExpr(accessor, resolved, registry, visited)
+		delete(visited, meta.Ref)
+		return guard
+	}
+	return "true"
+}
+
+// distributeIntersection returns the union of objects equivalent to an
+// intersection of objects and unions of objects, A & (B | C) being
+// (A & B) | (A & C), with the properties of each combination merged.
+// Members that are neither (branded primitives) carry no properties and are
+// left out. Reports false when there is no object member.
+func distributeIntersection(meta *metadata.Metadata, registry *metadata.TypeRegistry) (*metadata.Metadata, bool) {
+	combos := [][]metadata.Property{nil}
+	found := false
+	for i := range meta.IntersectionMembers {
+		member := resolveSerializeRef(&meta.IntersectionMembers[i], registry)
+		switch member.Kind {
+		case metadata.KindObject:
+			found = true
+			for j := range combos {
+				combos[j] = append(combos[j][:len(combos[j]):len(combos[j])], member.Properties...)
+			}
+		case metadata.KindUnion:
+			var alternatives [][]metadata.Property
+			for k := range member.UnionMembers {
+				alt := resolveSerializeRef(&member.UnionMembers[k], registry)
+				if alt.Kind != metadata.KindObject {
+					return nil, false
+				}
+				alternatives = append(alternatives, alt.Properties)
+			}
+			found = true
+			var next [][]metadata.Property
+			for _, combo := range combos {
+				for _, alt := range alternatives {
+					next = append(next, append(combo[:len(combo):len(combo)], alt...))
+				}
+			}
+			combos = next
+		}
+	}
+	if !found {
+		return nil, false
+	}
+	if len(combos) == 1 {
+		return &metadata.Metadata{Kind: metadata.KindObject, Properties: combos[0]}, true
+	}
+	union := &metadata.Metadata{Kind: metadata.KindUnion}
+	for _, props := range combos {
+		union.UnionMembers = append(union.UnionMembers, metadata.Metadata{Kind: metadata.KindObject, Properties: props})
+	}
+	return union, true
+}
+
+// generateSerializeRecord serializes an object with only an index signature
+// through its value type, writing own enumerable keys with a defined value.
+func generateSerializeRecord(accessor string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *serializeCtx) string {
+	kVar := fmt.Sprintf("_k%d", depth)
+	valExpr := generateSerializeExpr(fmt.Sprintf("%s[%s]", accessor, kVar), &meta.IndexSignature.ValueType, registry, depth+1, ctx)
+	return fmt.Sprintf("(function() { var _r = []; for (var %s in %s) { if (Object.prototype.hasOwnProperty.call(%s, %s) && %s[%s] !== undefined) _r.push(__s(%s) + \":\" + %s); } return \"{\" + _r.join(\",\") + \"}\"; }())",
+		kVar, accessor, accessor, kVar, accessor, kVar, kVar, valExpr)
+}
+
+// resolveSerializeRef resolves a named type reference through the registry.
+func resolveSerializeRef(meta *metadata.Metadata, registry *metadata.TypeRegistry) *metadata.Metadata {
+	if meta.Kind == metadata.KindRef {
+		if resolved, ok := registry.Types[meta.Ref]; ok {
+			return resolved
+		}
+	}
+	return meta
+}
@@ -0,0 +1,86 @@
+package codegen
+
+import (
+	"sort"
+	"strings"
+)
+
+// Settings holds the project-wide options of generated code, set from
+// transforms.* in the config. They are passed with CompanionOptions (and
+// CompanionGenOptions) so that each build generates from its own settings.
+// The zero value generates the default code.
+type Settings struct {
+	// Formats are the custom string formats (transforms.formats). Built-in
+	// formats take precedence over custom formats of the same name.
+	Formats []CustomFormat
+	// Locales maps locale tags ("de", "pt-BR") to message templates by error
+	// code (transforms.locales), emitted in the helpers file.
+	Locales map[string]map[string]string
+	// DefaultLocale names the catalog used when none of the requested locales
+	// has one ("": the built-in English messages).
+	DefaultLocale string
+	// ValidationErrorStatus is the HTTP status of the validation errors thrown
+	// by generated functions (transforms.validationError.status; 0: 400).
+	ValidationErrorStatus int
+	// MaxErrors is the default error limit of validate functions
+	// (transforms.maxErrors, 1 for transforms.failFast; 0: all errors).
+	MaxErrors int
+	// Limits are the structural limits of validators (transforms.limits).
+	Limits Limits
+	// SensitiveMask is serialized in place of sensitive properties
+	// (transforms.sensitiveMask; "": their value is serialized).
+	SensitiveMask string
+	// ReadOnlyMode is the handling of read-only properties by assertRequest
+	// functions: ReadOnlyStrip or ReadOnlyReject ("": they are checked like
+	// the other properties).
+	ReadOnlyMode string
+	// DeprecationHook is called by assertRequest functions with the path of
+	// each deprecated property present in a request body (transforms.onDeprecated).
+	DeprecationHook DeprecationHook
+}
+
+// customFormat returns the custom format named name. Names of built-in
+// formats never resolve to a custom format.
+func (s *Settings) customFormat(name string) (CustomFormat, bool) {
+	if _, builtin := formatRegexes[name]; builtin {
+		return CustomFormat{}, false
+	}
+	for _, f := range s.Formats {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return CustomFormat{}, false
+}
+
+// customFormats returns the custom formats sorted by name, without the ones
+// shadowed by built-in formats.
+func (s *Settings) customFormats() []CustomFormat {
+	formats := make([]CustomFormat, 0, len(s.Formats))
+	for _, f := range s.Formats {
+		if _, builtin := formatRegexes[f.Name]; !builtin {
+			formats = append(formats, f)
+		}
+	}
+	sort.Slice(formats, func(i, j int) bool {
+		return formats[i].Name < formats[j].Name
+	})
+	return formats
+}
+
+// localeCatalogs returns Locales keyed by lowercased locale tag.
+func (s *Settings) localeCatalogs() map[string]map[string]string {
+	catalogs := make(map[string]map[string]string, len(s.Locales))
+	for tag, messages := range s.Locales {
+		catalogs[strings.ToLower(tag)] = messages
+	}
+	return catalogs
+}
+
+// validationErrorStatus returns the HTTP status of validation errors.
+func (s *Settings) validationErrorStatus() int {
+	if s.ValidationErrorStatus == 0 {
+		return 400
+	}
+	return s.ValidationErrorStatus
+}
@@ -12,14 +12,47 @@ import (
 type transformCtx struct {
 	// generating tracks type names currently being generated to detect recursion.
 	generating map[string]bool
+	// root is the type of the exported clone function. Recursive references to
+	// other types go through local _clone_<Name> helpers, collected in locals.
+	root   string
+	locals []string
 }
 
-// generateTransformFunction generates: export function transform<Name>(input) { return ...; }
-func generateTransformFunction(e *Emitter, typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry, ctx *transformCtx) {
-	fnName := "transform" + typeName
-	e.Block("export function %s(input)", fnName)
+// generateCloneFunction generates: export function clone<Name>(input) { return ...; }
+// The result is a deep copy limited to the declared shape: undeclared properties
+// are dropped, arrays and Dates are copied. Values whose shape cannot be resolved
+// statically (non-discriminated unions of objects, any) are kept by reference.
+func generateCloneFunction(e *Emitter, typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry) {
+	ctx := &transformCtx{generating: map[string]bool{typeName: true}, root: typeName}
+	e.Block("export function clone%s(input)", typeName)
 	e.Line("return %s;", generateTransformExpr("input", meta, registry, 0, ctx))
 	e.EndBlock()
+	for i := 0; i < len(ctx.locals); i++ {
+		name := ctx.locals[i]
+		ctx.generating = map[string]bool{name: true}
+		e.Block("function _clone_%s(input)", name)
+		e.Line("return %s;", generateTransformExpr("input", registry.Types[name], registry, 0, ctx))
+		e.EndBlock()
+	}
+}
+
+// recursiveCall returns the call cloning a recursive reference: the exported
+// function for the root type, a local helper for any other type.
+func (ctx *transformCtx) recursiveCall(ref, accessor string) string {
+	if ref == ctx.root {
+		return fmt.Sprintf("clone%s(%s)", ref, accessor)
+	}
+	found := false
+	for _, name := range ctx.locals {
+		if name == ref {
+			found = true
+			break
+		}
+	}
+	if !found {
+		ctx.locals = append(ctx.locals, ref)
+	}
+	return fmt.Sprintf("_clone_%s(%s)", ref, accessor)
 }
 
 // generateTransformExpr returns a JS expression that transforms the value at `accessor`,
@@ -48,8 +81,7 @@ func generateTransformExpr(accessor string, meta *metadata.Metadata, registry *m
 	case metadata.KindRef:
 		// For recursive references, emit a function call to prevent infinite codegen recursion
 		if ctx != nil && ctx.generating[meta.Ref] {
-			fnName := "transform" + meta.Ref
-			return fmt.Sprintf("%s(%s)", fnName, accessor)
+			return ctx.recursiveCall(meta.Ref, accessor)
 		}
 		if resolved, ok := registry.Types[meta.Ref]; ok {
 			if ctx != nil {
@@ -98,7 +130,10 @@ func generateTransformExpr(accessor string, meta *metadata.Metadata, registry *m
 		return generateTransformUnion(accessor, meta, registry, depth, ctx)
 
 	case metadata.KindNative:
-		// Date and other natives: pass through
+		// Dates are copied; other natives pass through
+		if meta.NativeType == "Date" {
+			return fmt.Sprintf("(%s instanceof Date ? new Date(%s.getTime()) : %s)", accessor, accessor, accessor)
+		}
 		return accessor
 
 	case metadata.KindAny, metadata.KindUnknown:
@@ -112,17 +147,19 @@ func generateTransformExpr(accessor string, meta *metadata.Metadata, registry *m
 
 // generateTransformObject generates a plain object literal with only declared properties.
 func generateTransformObject(accessor string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *transformCtx) string {
-	if len(meta.Properties) == 0 {
-		// Objects with no properties but possibly an index signature: pass through
-		if meta.IndexSignature != nil {
-			return accessor
+	// If the object has an index signature, we can't strip unknown keys:
+	// copy every entry instead (the declared properties conform to the index type)
+	if meta.IndexSignature != nil {
+		entryVar := fmt.Sprintf("_e%d", depth)
+		valExpr := generateTransformExpr(entryVar, &meta.IndexSignature.ValueType, registry, depth+1, ctx)
+		if valExpr == entryVar {
+			return fmt.Sprintf("{ ...%s }", accessor)
 		}
-		return "{}"
+		return fmt.Sprintf("Object.fromEntries(Object.entries(%s).map(([_k, %s]) => [_k, %s]))", accessor, entryVar, valExpr)
 	}
 
-	// If the object has an index signature, we can't strip unknown keys
-	if meta.IndexSignature != nil {
-		return accessor
+	if len(meta.Properties) == 0 {
+		return "{}"
 	}
 
 	// Check if any property is optional
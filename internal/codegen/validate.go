@@ -3,6 +3,7 @@ package codegen
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/tsgonest/tsgonest/internal/metadata"
@@ -19,6 +20,17 @@ func GenerateCompanion(typeName string, meta *metadata.Metadata, registry *metad
 type CompanionGenOptions struct {
 	StandardSchema    bool   // Generate Standard Schema v1 wrappers
 	ResponseTypeCheck string // "safe" (default), "guard", or "none"
+	// Markers lists the on-demand marker functions used for this type (e.g. "random").
+	// Functions for these markers are only generated when a marker call needs them.
+	Markers map[string]bool
+	// ImportPath converts a source file path to the module specifier used to
+	// import from it (e.g. classes constructed by hydrate). Defaults to toRelativeImportPath.
+	ImportPath func(sourceFile string) string
+	// Views lists the serialization views of routes returning this type
+	// (e.g. "admin"), each getting serialize<Name>_<view> and stringify<Name>_<view>.
+	Views []string
+	// Settings are the project-wide options of generated code.
+	Settings Settings
 }
 
 // GenerateCompanionSelective generates a companion file with optional sections.
@@ -27,24 +39,42 @@ type CompanionGenOptions struct {
 // An optional CompanionGenOptions can be passed to control Standard Schema and response type check.
 func GenerateCompanionSelective(typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry, includeValidation bool, includeSerialization bool, opts ...CompanionGenOptions) string {
 	var standardSchema bool
+	var markers map[string]bool
+	var views []string
+	settings := &Settings{}
 	rtc := "safe"
+	importPath := toRelativeImportPath
 	if len(opts) > 0 {
 		standardSchema = opts[0].StandardSchema
+		markers = opts[0].Markers
+		views = opts[0].Views
+		settings = &opts[0].Settings
 		if opts[0].ResponseTypeCheck != "" {
 			rtc = opts[0].ResponseTypeCheck
 		}
+		if opts[0].ImportPath != nil {
+			importPath = opts[0].ImportPath
+		}
 	}
 	e := NewEmitter()
 	e.Line("// Auto-generated by tsgonest — do not edit")
 	e.Blank()
 
+	var hydrateClasses []hydrateClass
 	if includeValidation {
 		// Collect and emit imports for custom validator functions (Validate<typeof fn>)
 		imports := collectValidateImports(meta, registry)
 		for _, imp := range imports {
 			e.Line("import { %s } from %q;", imp.FnName, imp.Module)
 		}
-		if len(imports) > 0 {
+		// Classes constructed by hydrate are imported under their own name
+		if markers["hydrate"] || markers["hydrateRequest"] {
+			hydrateClasses = collectHydrateClasses(meta, registry)
+			for _, cls := range hydrateClasses {
+				e.Line("import { %s } from %q;", cls.Name, importPath(cls.Module))
+			}
+		}
+		if len(imports) > 0 || len(hydrateClasses) > 0 {
 			e.Blank()
 		}
 	}
@@ -54,10 +84,20 @@ func GenerateCompanionSelective(typeName string, meta *metadata.Metadata, regist
 		var helperImports []string
 		if includeValidation {
 			helperImports = append(helperImports, "__e")
+			// Custom formats are checked with the constants of the helpers file
+			for _, name := range collectCustomFormats(meta, registry, settings) {
+				helperImports = append(helperImports, customFormatConstName(name))
+			}
 		}
 		if includeSerialization {
 			helperImports = append(helperImports, "__s", "__sa")
 		}
+		if markers["random"] {
+			helperImports = append(helperImports, randomHelpers...)
+		}
+		if includeValidation && requestMarkers(markers) && settings.DeprecationHook.Module != "" && HasDeprecatedProperties(meta, registry) {
+			helperImports = append(helperImports, "__dep")
+		}
 		if len(helperImports) > 0 {
 			e.Line("import { %s } from \"./_tsgonest_helpers.js\";", strings.Join(helperImports, ", "))
 			e.Blank()
@@ -68,6 +108,7 @@ func GenerateCompanionSelective(typeName string, meta *metadata.Metadata, regist
 		// Generate is function (pure boolean, zero allocations)
 		isCtx := &validateCtx{
 			generating: map[string]bool{typeName: true},
+			cfg:        settings,
 		}
 		generateIsFunction(e, typeName, meta, registry, isCtx)
 		e.Blank()
@@ -75,6 +116,7 @@ func GenerateCompanionSelective(typeName string, meta *metadata.Metadata, regist
 		// Generate validate function
 		ctx := &validateCtx{
 			generating: map[string]bool{typeName: true},
+			cfg:        settings,
 		}
 		generateValidateFunction(e, typeName, meta, registry, ctx)
 		e.Blank()
@@ -82,6 +124,7 @@ func GenerateCompanionSelective(typeName string, meta *metadata.Metadata, regist
 		// Generate assert function (standalone, throws on first error)
 		assertCtx := &validateCtx{
 			generating: map[string]bool{typeName: true},
+			cfg:        settings,
 		}
 		generateAssertFunction(e, typeName, meta, registry, assertCtx)
 		e.Blank()
@@ -91,6 +134,7 @@ func GenerateCompanionSelective(typeName string, meta *metadata.Metadata, regist
 		// Generate serialize function
 		sCtx := &serializeCtx{
 			generating: map[string]bool{typeName: true},
+			cfg:        settings,
 		}
 		generateSerializeFunction(e, typeName, meta, registry, sCtx)
 		e.Blank()
@@ -98,18 +142,80 @@ func GenerateCompanionSelective(typeName string, meta *metadata.Metadata, regist
 
 	if includeValidation && includeSerialization {
 		// Generate stringify function (validate + serialize combined)
-		generateStringifyFunction(e, typeName, rtc)
+		generateStringifyFunction(e, typeName, "", rtc)
 		e.Blank()
 	}
 
+	if includeSerialization {
+		// Generate the serializers of the route views (Groups<G>)
+		for _, view := range views {
+			generateViewFunctions(e, typeName, view, meta, registry, includeValidation, rtc, settings)
+			e.Blank()
+		}
+	}
+
 	if includeValidation && standardSchema {
 		// Generate Standard Schema v1 wrapper
 		generateStandardSchemaWrapper(e, typeName)
 	}
 
+	if includeValidation && (markers["equals"] || markers["validateEquals"]) {
+		// Generate equals/validateEquals functions (reject undeclared properties)
+		generateEqualsFunctions(e, typeName, meta, registry, markers, settings)
+		e.Blank()
+	}
+
+	if includeValidation && (markers["parse"] || markers["assertParse"]) {
+		// Generate parse/assertParse functions (JSON.parse + validate combined)
+		generateParseFunctions(e, typeName, meta, registry, markers)
+		e.Blank()
+	}
+
+	if includeValidation && requestMarkers(markers) {
+		// Generate assertRequest function (assert for request bodies, read-only properties handled)
+		generateAssertRequestFunction(e, typeName, meta, registry, settings)
+		e.Blank()
+	}
+
+	if includeValidation && (markers["hydrate"] || markers["hydrateRequest"]) {
+		// Generate hydrate functions (assert + class instance construction)
+		generateHydrateFunction(e, typeName, meta, registry, hydrateClasses, markers)
+		e.Blank()
+	}
+
+	if includeValidation && (markers["validateAsync"] || markers["assertAsync"] || markers["assertAsyncRequest"]) {
+		// Generate validateAsync/assertAsync functions (sync checks + async validators)
+		generateAsyncFunctions(e, typeName, meta, registry, markers, settings)
+		e.Blank()
+	}
+
+	if markers["prune"] {
+		// Generate prune function (in-place removal of undeclared properties)
+		generatePruneFunction(e, typeName, meta, registry)
+		e.Blank()
+	}
+
+	if markers["clone"] {
+		// Generate clone function (deep copy limited to the declared shape)
+		generateCloneFunction(e, typeName, meta, registry)
+		e.Blank()
+	}
+
+	if markers["random"] {
+		// Generate random function (constraint-respecting mock data)
+		generateRandomFunction(e, typeName, meta, registry, settings)
+		e.Blank()
+	}
+
 	return e.String()
 }
 
+// requestMarkers reports whether markers include functions for request bodies,
+// all built on assertRequest<Name>.
+func requestMarkers(markers map[string]bool) bool {
+	return markers["assertRequest"] || markers["hydrateRequest"] || markers["assertAsyncRequest"]
+}
+
 // GenerateCompanionTypes generates TypeScript declaration (.tsgonest.d.ts) content
 // for the consolidated companion file, including Standard Schema v1 type info.
 func GenerateCompanionTypes(typeName string) string {
@@ -120,7 +226,98 @@ func GenerateCompanionTypes(typeName string) string {
 // Optional variadic bool controls Standard Schema generation (default: false).
 func GenerateCompanionTypesSelective(typeName string, includeValidation bool, includeSerialization bool, opts ...bool) string {
 	includeStandardSchema := len(opts) > 0 && opts[0]
+	return generateCompanionTypes(typeName, typeName, nil, includeValidation, includeSerialization, includeStandardSchema)
+}
+
+// companionOutputType returns the TypeScript type of validated data: typeName,
+// narrowed so that the properties filled with a default (see defaultedProperties)
+// are not optional.
+func companionOutputType(typeName string, meta *metadata.Metadata) string {
+	names := defaultedProperties(meta)
+	if len(names) == 0 {
+		return typeName
+	}
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = strconv.Quote(name)
+	}
+	return fmt.Sprintf("%s & Required<Pick<%s, %s>>", typeName, typeName, strings.Join(quoted, " | "))
+}
+
+// transformChains returns one "path: transform → transform" line per property
+// of meta with transforms, in the order validate/assert apply them. Array
+// elements are written as "[]" and named types are descended into once.
+func transformChains(meta *metadata.Metadata, registry *metadata.TypeRegistry) []string {
+	var lines []string
+	visited := make(map[string]bool)
+	var walk func(m *metadata.Metadata, prefix string)
+	walk = func(m *metadata.Metadata, prefix string) {
+		if m == nil {
+			return
+		}
+		switch m.Kind {
+		case metadata.KindObject:
+			for i := range m.Properties {
+				prop := &m.Properties[i]
+				path := prop.Name
+				if prefix != "" {
+					path = prefix + "." + prop.Name
+				}
+				if c := prop.Constraints; c != nil && (len(c.Transforms) > 0 || len(c.TransformFns) > 0) {
+					chain := append([]string{}, c.Transforms...)
+					for _, fn := range c.TransformFns {
+						chain = append(chain, fn.Name)
+					}
+					lines = append(lines, path+": "+strings.Join(chain, " → "))
+				}
+				walk(&prop.Type, path)
+			}
+		case metadata.KindArray:
+			walk(m.ElementType, prefix+"[]")
+		case metadata.KindTuple:
+			for i := range m.Elements {
+				walk(&m.Elements[i].Type, fmt.Sprintf("%s[%d]", prefix, i))
+			}
+		case metadata.KindUnion:
+			for i := range m.UnionMembers {
+				walk(&m.UnionMembers[i], prefix)
+			}
+		case metadata.KindIntersection:
+			for i := range m.IntersectionMembers {
+				walk(&m.IntersectionMembers[i], prefix)
+			}
+		case metadata.KindRef:
+			if registry == nil || visited[m.Ref] {
+				return
+			}
+			visited[m.Ref] = true
+			if resolved, ok := registry.Types[m.Ref]; ok {
+				walk(resolved, prefix)
+			}
+		}
+	}
+	walk(meta, "")
+	return lines
+}
 
+// emitTransformDoc emits a doc comment listing the transform chains applied
+// before validation (see transformChains).
+func emitTransformDoc(e *Emitter, chains []string) {
+	if len(chains) == 0 {
+		return
+	}
+	e.Line("/**")
+	e.Line(" * Transforms applied to the input before validation:")
+	for _, chain := range chains {
+		e.Line(" * - %s", strings.ReplaceAll(chain, "*/", "*\\/"))
+	}
+	e.Line(" */")
+}
+
+// generateCompanionTypes generates the companion type declarations, with
+// outputType as the type of validated data (see companionOutputType) and
+// transforms the transform chains documented on validate and assert.
+func generateCompanionTypes(typeName string, outputType string, transforms []string, includeValidation bool, includeSerialization bool, includeStandardSchema bool) string {
 	e := NewEmitter()
 	e.Line("// Auto-generated by tsgonest — do not edit")
 	e.Blank()
@@ -138,9 +335,11 @@ func GenerateCompanionTypesSelective(typeName string, includeValidation bool, in
 
 	if includeValidation {
 		e.Line("export declare function is%s(input: unknown): input is %s;", typeName, typeName)
-		validateResult := fmt.Sprintf("{ success: true; data: %s } | { success: false; errors: Array<{ path: string; message: string }> }", typeName)
-		e.Line("export declare function validate%s(input: unknown): %s;", typeName, validateResult)
-		e.Line("export declare function assert%s(input: unknown): %s;", typeName, typeName)
+		validateResult := fmt.Sprintf("{ success: true; data: %s } | { success: false; errors: Array<{ path: string; message: string }> }", outputType)
+		emitTransformDoc(e, transforms)
+		e.Line("export declare function validate%s(input: unknown, options?: %s): %s;", typeName, validateOptionsType, validateResult)
+		emitTransformDoc(e, transforms)
+		e.Line("export declare function assert%s(input: unknown): %s;", typeName, outputType)
 	}
 
 	if includeSerialization {
@@ -152,25 +351,97 @@ func GenerateCompanionTypesSelective(typeName string, includeValidation bool, in
 	}
 
 	if includeValidation && includeStandardSchema {
-		e.Line("export declare const schema%s: { readonly \"~standard\": StandardSchemaV1Props<%s, %s> };", typeName, typeName, typeName)
+		e.Line("export declare const schema%s: { readonly \"~standard\": StandardSchemaV1Props<%s, %s> };", typeName, typeName, outputType)
 	}
 
 	return e.String()
 }
 
+// GenerateMarkerTypes generates the declarations for on-demand marker functions
+// (see CompanionGenOptions.Markers), appended to the companion .d.ts content.
+func GenerateMarkerTypes(typeName string, markers map[string]bool) string {
+	return generateMarkerTypes(typeName, typeName, markers)
+}
+
+// generateMarkerTypes generates the marker declarations, with outputType as
+// the type of validated data (see companionOutputType).
+func generateMarkerTypes(typeName string, outputType string, markers map[string]bool) string {
+	if len(markers) == 0 {
+		return ""
+	}
+	e := NewEmitter()
+	if markers["equals"] {
+		e.Line("export declare function equals%s(input: unknown): input is %s;", typeName, typeName)
+	}
+	if markers["validateEquals"] {
+		validateResult := fmt.Sprintf("{ success: true; data: %s } | { success: false; errors: Array<{ path: string; message: string }> }", outputType)
+		e.Line("export declare function validateEquals%s(input: unknown, options?: %s): %s;", typeName, validateOptionsType, validateResult)
+	}
+	if markers["parse"] {
+		validateResult := fmt.Sprintf("{ success: true; data: %s } | { success: false; errors: Array<{ path: string; message: string }> }", outputType)
+		e.Line("export declare function parse%s(input: string, options?: { coerce?: boolean; maxErrors?: number; failFast?: boolean }): %s;", typeName, validateResult)
+	}
+	if markers["assertParse"] {
+		e.Line("export declare function assertParse%s(input: string, options?: { coerce?: boolean }): %s;", typeName, outputType)
+	}
+	if requestMarkers(markers) {
+		e.Line("export declare function assertRequest%s(input: unknown): %s;", typeName, outputType)
+	}
+	if markers["hydrate"] {
+		e.Line("export declare function hydrate%s(input: unknown): %s;", typeName, outputType)
+	}
+	if markers["hydrateRequest"] {
+		if markers["assertAsyncRequest"] {
+			e.Line("export declare function hydrateRequest%s(input: unknown): Promise<%s>;", typeName, outputType)
+		} else {
+			e.Line("export declare function hydrateRequest%s(input: unknown): %s;", typeName, outputType)
+		}
+	}
+	if markers["validateAsync"] || markers["assertAsync"] {
+		validateResult := fmt.Sprintf("{ success: true; data: %s } | { success: false; errors: Array<{ path: string; message: string }> }", outputType)
+		e.Line("export declare function validateAsync%s(input: unknown, options?: %s): Promise<%s>;", typeName, validateOptionsType, validateResult)
+		e.Line("export declare function assertAsync%s(input: unknown): Promise<%s>;", typeName, outputType)
+	}
+	if markers["assertAsyncRequest"] {
+		e.Line("export declare function assertAsyncRequest%s(input: unknown): Promise<%s>;", typeName, outputType)
+	}
+	if markers["prune"] {
+		e.Line("export declare function prune%s(input: %s): void;", typeName, typeName)
+	}
+	if markers["clone"] {
+		e.Line("export declare function clone%s(input: %s): %s;", typeName, typeName, typeName)
+	}
+	if markers["random"] {
+		e.Line("export declare function random%s(options?: { seed?: number; maxDepth?: number }): %s;", typeName, typeName)
+	}
+	return e.String()
+}
+
 // validateImport represents an import needed for a custom validator function.
 type validateImport struct {
 	FnName string
 	Module string
 }
 
-// collectValidateImports scans the metadata tree for Validate<typeof fn> constraints
-// and returns unique import entries.
+// collectValidateImports scans the metadata tree for Validate<typeof fn>,
+// ValidateAsync<typeof fn> and Transform<typeof fn> constraints and returns
+// unique import entries.
 func collectValidateImports(meta *metadata.Metadata, registry *metadata.TypeRegistry) []validateImport {
 	seen := make(map[string]bool)
 	visitedRefs := make(map[string]bool) // prevent infinite recursion on recursive types
 	var imports []validateImport
 
+	add := func(fnName, module string) {
+		key := fnName + "|" + module
+		if !seen[key] {
+			seen[key] = true
+			imports = append(imports, validateImport{
+				FnName: fnName,
+				Module: toRelativeImportPath(module),
+			})
+		}
+	}
+
 	var scan func(m *metadata.Metadata)
 	scan = func(m *metadata.Metadata) {
 		if m == nil {
@@ -179,16 +450,23 @@ func collectValidateImports(meta *metadata.Metadata, registry *metadata.TypeRegi
 
 		switch m.Kind {
 		case metadata.KindObject:
+			// Object-level Validate<typeof fn> (cross-field rules)
+			if m.Rules != nil && m.Rules.ValidateFn != "" && m.Rules.ValidateModule != "" {
+				add(m.Rules.ValidateFn, m.Rules.ValidateModule)
+			}
 			for i := range m.Properties {
 				prop := &m.Properties[i]
 				if prop.Constraints != nil && prop.Constraints.ValidateFn != nil && prop.Constraints.ValidateModule != nil {
-					key := *prop.Constraints.ValidateFn + "|" + *prop.Constraints.ValidateModule
-					if !seen[key] {
-						seen[key] = true
-						imports = append(imports, validateImport{
-							FnName: *prop.Constraints.ValidateFn,
-							Module: toRelativeImportPath(*prop.Constraints.ValidateModule),
-						})
+					add(*prop.Constraints.ValidateFn, *prop.Constraints.ValidateModule)
+				}
+				if prop.Constraints != nil && prop.Constraints.ValidateAsyncFn != nil && prop.Constraints.ValidateAsyncModule != nil {
+					add(*prop.Constraints.ValidateAsyncFn, *prop.Constraints.ValidateAsyncModule)
+				}
+				if prop.Constraints != nil {
+					for _, fn := range prop.Constraints.TransformFns {
+						if fn.Module != "" {
+							add(fn.Name, fn.Module)
+						}
 					}
 				}
 				scan(&prop.Type)
@@ -246,36 +524,85 @@ func toRelativeImportPath(filePath string) string {
 type validateCtx struct {
 	// generating tracks type names currently being generated to detect recursion.
 	generating map[string]bool
+	// exact rejects properties not declared on object types, regardless of
+	// Metadata.Strictness (equals/validateEquals markers).
+	exact bool
+	// skipKeys suppresses the unknown-key check of the next object, used for
+	// intersection members whose keys are checked once for the whole intersection.
+	skipKeys bool
+	// depthParam is set while generating a recursive function that takes the
+	// recursion depth as _d (see depthLimited).
+	depthParam bool
+	// redact is set while generating the checks of a sensitive value, whose
+	// errors report its type instead of the value (see redactBy).
+	redact bool
+	// request generates the assertRequest variant, handling read-only
+	// properties with Settings.ReadOnlyMode.
+	request bool
+	// depQueue names the array queuing deprecated property paths during a
+	// union member trial of assertRequest ("": reported to the hook directly).
+	depQueue string
+	// defaultLog names the array logging the defaults filled during a union
+	// member trial ("": defaults are final, see emitDefaultLog).
+	defaultLog string
+	// keys declares the known-key sets of exact mode (see generateUnknownKeysCheck).
+	keys *keySets
+	// cfg holds the settings of the generated code (nil: the defaults).
+	cfg *Settings
+}
+
+// settings returns the settings of the generated code.
+func (ctx *validateCtx) settings() *Settings {
+	if ctx == nil || ctx.cfg == nil {
+		return &Settings{}
+	}
+	return ctx.cfg
 }
 
-// generateValidateFunction generates: export function validate<Name>(input) { ... }
+// generateValidateFunction generates: export function validate<Name>(input, options) { ... }
 // For recursive types, generates an inner function with path+errors parameters
 // to avoid expensive regex path rewrites and spread operations.
 func generateValidateFunction(e *Emitter, typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry, ctx *validateCtx) {
-	fnName := "validate" + typeName
+	fnName := ctx.fnName("validate", typeName)
 
 	// Check if the type is recursive — if so, use inner function pattern
 	isRecursive := isRecursiveType(typeName, meta, registry)
 
 	if isRecursive {
-		// Generate inner function that takes path and errors parameters
-		innerFn := "_validate" + typeName
-		e.Block("function %s(input, _path, errors)", innerFn)
+		// Generate inner function that takes path, errors and error limit parameters
+		innerFn := ctx.fnName("_validate", typeName)
+		depthArg := ""
+		maxDepth := ctx.settings().Limits.MaxDepth
+		if maxDepth > 0 {
+			depthArg = ", _d"
+		}
+		e.Block("function %s(input, _path, errors, _max%s)", innerFn, depthArg)
+		e.Block("if (errors.length >= _max)")
+		e.Line("return;")
+		e.EndBlock()
+		if maxDepth > 0 {
+			emitDepthCheck(e, maxDepth)
+			depthArg = ", 1"
+		}
 		generateTypeCheckWithPath(e, "input", "_path", meta, registry, 0, ctx)
 		e.EndBlock()
-		e.Block("export function %s(input)", fnName)
+		e.Block("export function %s(input, options)", fnName)
 		e.Line("const errors = [];")
-		e.Line("%s(input, \"input\", errors);", innerFn)
+		emitErrorLimit(e, ctx.settings())
+		e.Line("%s(input, \"input\", errors, _max%s);", innerFn, depthArg)
 		e.Block("if (errors.length > 0)")
+		emitErrorLimitResult(e)
 		e.Line("return { success: false, errors };")
 		e.EndBlock()
 		e.Line("return { success: true, data: input };")
 		e.EndBlock()
 	} else {
-		e.Block("export function %s(input)", fnName)
+		e.Block("export function %s(input, options)", fnName)
 		e.Line("const errors = [];")
+		emitErrorLimit(e, ctx.settings())
 		generateTypeCheck(e, "input", "", meta, registry, 0, ctx)
 		e.Block("if (errors.length > 0)")
+		emitErrorLimitResult(e)
 		e.Line("return { success: false, errors };")
 		e.EndBlock()
 		e.Line("return { success: true, data: input };")
@@ -314,33 +641,45 @@ func generateTypeCheckWithPathInner(e *Emitter, accessor string, pathExpr string
 		switch meta.Atomic {
 		case "string":
 			e.Block("if (typeof %s !== \"string\")", accessor)
-			e.Line("errors.push({ path: %s, expected: \"string\", received: typeof %s });", pathExpr, accessor)
+			e.Line("errors.push({ path: %s, expected: \"string\", received: typeof %s%s });", pathExpr, accessor, errorFields("type", ""))
 			e.EndBlock()
+			if max := ctx.maxStringLength(meta); max > 0 {
+				emitStringLengthCheck(e, accessor, pathExpr, max)
+			}
 		case "number":
 			e.Block("if (typeof %s !== \"number\" || !Number.isFinite(%s))", accessor, accessor)
-			e.Line("errors.push({ path: %s, expected: \"number\", received: typeof %s });", pathExpr, accessor)
+			e.Line("errors.push({ path: %s, expected: \"number\", received: typeof %s%s });", pathExpr, accessor, errorFields("type", ""))
 			e.EndBlock()
 		case "boolean":
 			e.Block("if (typeof %s !== \"boolean\")", accessor)
-			e.Line("errors.push({ path: %s, expected: \"boolean\", received: typeof %s });", pathExpr, accessor)
+			e.Line("errors.push({ path: %s, expected: \"boolean\", received: typeof %s%s });", pathExpr, accessor, errorFields("type", ""))
 			e.EndBlock()
 		case "bigint":
 			e.Block("if (typeof %s !== \"bigint\")", accessor)
-			e.Line("errors.push({ path: %s, expected: \"bigint\", received: typeof %s });", pathExpr, accessor)
+			e.Line("errors.push({ path: %s, expected: \"bigint\", received: typeof %s%s });", pathExpr, accessor, errorFields("type", ""))
 			e.EndBlock()
 		}
 
 	case metadata.KindObject:
 		e.Block("if (typeof %s !== \"object\" || %s === null)", accessor, accessor)
-		e.Line("errors.push({ path: %s, expected: \"object\", received: typeof %s });", pathExpr, accessor)
+		e.Line("errors.push({ path: %s, expected: \"object\", received: typeof %s%s });", pathExpr, accessor, errorFields("type", ""))
 		e.EndBlockSuffix(" else {")
 		e.indent++
+		emitRulesErrorMark(e, meta, depth)
+		seq := openCheckSeq(e, depth, objectCheckCount(meta, ctx.rejectsUnknownKeys(meta)))
+		if ctx.rejectsUnknownKeys(meta) {
+			seq.next(e)
+			generateUnknownKeysCheck(e, accessor, pathExpr, meta.Properties, depth, ctx)
+		}
 		for _, prop := range meta.Properties {
+			seq.next(e)
 			propAccessor := jsPropAccess(accessor, prop.Name)
 			propPathExpr := fmt.Sprintf("%s + %q", pathExpr, jsPropPathSuffix(prop.Name))
+			emitDefaultAssignment(e, accessor, prop.Name, &prop, ctx)
+			redacted := ctx.redactBy(prop.Constraints)
 			if prop.Required && !prop.Type.Optional {
 				e.Block("if (%s === undefined)", propAccessor)
-				e.Line("errors.push({ path: %s, expected: \"%s\", received: \"undefined\" });", propPathExpr, describeType(&prop.Type))
+				e.Line("errors.push({ path: %s, expected: \"%s\", received: \"undefined\"%s });", propPathExpr, describeType(&prop.Type), errorFields("required", ""))
 				e.EndBlockSuffix(" else {")
 				e.indent++
 				generateTypeCheckWithPath(e, propAccessor, propPathExpr, &prop.Type, registry, depth+1, ctx)
@@ -349,31 +688,56 @@ func generateTypeCheckWithPathInner(e *Emitter, accessor string, pathExpr string
 			} else {
 				generateTypeCheckWithPath(e, propAccessor, propPathExpr, &prop.Type, registry, depth+1, ctx)
 			}
+			ctx.restoreRedact(redacted)
+		}
+		if meta.IndexSignature != nil && ctx.settings().Limits.MaxProperties > 0 {
+			seq.next(e)
+			emitPropertyCountCheck(e, accessor, pathExpr, ctx.settings().Limits.MaxProperties)
+			e.indent--
+			e.Line("}")
+		}
+		if meta.Rules != nil {
+			seq.next(e)
+			generateObjectRuleChecks(e, accessor, pathExpr, meta.Rules, depth)
 		}
+		seq.close(e)
 		e.indent--
 		e.Line("}")
 
 	case metadata.KindArray:
 		e.Block("if (!Array.isArray(%s))", accessor)
-		e.Line("errors.push({ path: %s, expected: \"array\", received: typeof %s });", pathExpr, accessor)
+		e.Line("errors.push({ path: %s, expected: \"array\", received: typeof %s%s });", pathExpr, accessor, errorFields("type", ""))
 		e.EndBlockSuffix(" else {")
 		e.indent++
+		max := ctx.maxArrayLength(meta)
+		if max > 0 {
+			emitArrayLengthCheck(e, accessor, pathExpr, max)
+		}
 		if meta.ElementType != nil {
 			idx := fmt.Sprintf("i%d", depth)
 			e.Block("for (let %s = 0; %s < %s.length; %s++)", idx, idx, accessor, idx)
+			emitErrorLimitGuard(e)
 			elemAccessor := fmt.Sprintf("%s[%s]", accessor, idx)
 			elemPathExpr := fmt.Sprintf("%s + \"[\" + %s + \"]\"", pathExpr, idx)
 			generateTypeCheckWithPath(e, elemAccessor, elemPathExpr, meta.ElementType, registry, depth+1, ctx)
 			e.EndBlock()
 		}
+		if max > 0 {
+			e.indent--
+			e.Line("}")
+		}
 		e.indent--
 		e.Line("}")
 
 	case metadata.KindRef:
 		if ctx != nil && ctx.generating[meta.Ref] {
 			// Recursive call — use inner function with shared errors array
-			innerFn := "_validate" + meta.Ref
-			e.Line("%s(%s, %s, errors);", innerFn, accessor, pathExpr)
+			innerFn := ctx.fnName("_validate", meta.Ref)
+			if ctx.settings().Limits.MaxDepth > 0 {
+				e.Line("%s(%s, %s, errors, _max, _d + 1);", innerFn, accessor, pathExpr)
+			} else {
+				e.Line("%s(%s, %s, errors, _max);", innerFn, accessor, pathExpr)
+			}
 		} else if resolved, ok := registry.Types[meta.Ref]; ok {
 			if ctx != nil {
 				ctx.generating[meta.Ref] = true
@@ -392,7 +756,7 @@ func generateTypeCheckWithPathInner(e *Emitter, accessor string, pathExpr string
 			}
 			setExpr := "[" + strings.Join(vals, ", ") + "]"
 			e.Block("if (!%s.includes(%s))", setExpr, accessor)
-			e.Line("errors.push({ path: %s, expected: \"enum value\", received: %s });", pathExpr, accessor)
+			e.Line("errors.push({ path: %s, expected: \"enum value\", received: %s%s });", pathExpr, ctx.received(accessor, accessor), errorFields("type", ""))
 			e.EndBlock()
 		}
 
@@ -400,37 +764,43 @@ func generateTypeCheckWithPathInner(e *Emitter, accessor string, pathExpr string
 		switch meta.NativeType {
 		case "Date":
 			e.Block("if (!(%s instanceof Date) || isNaN(%s.getTime()))", accessor, accessor)
-			e.Line("errors.push({ path: %s, expected: \"Date\", received: typeof %s });", pathExpr, accessor)
+			e.Line("errors.push({ path: %s, expected: \"Date\", received: typeof %s%s });", pathExpr, accessor, errorFields("type", ""))
 			e.EndBlock()
 		default:
-			e.Block("if (!(%s instanceof %s))", accessor, meta.NativeType)
-			e.Line("errors.push({ path: %s, expected: \"%s\", received: typeof %s });", pathExpr, meta.NativeType, accessor)
+			e.Block("if (!(%s))", nativeInstanceCheck(accessor, meta.NativeType))
+			e.Line("errors.push({ path: %s, expected: \"%s\", received: typeof %s%s });", pathExpr, meta.NativeType, accessor, errorFields("type", ""))
 			e.EndBlock()
 		}
 
 	case metadata.KindUnion:
-		// For recursive validate with path, use the same union pattern but with dynamic path
-		// Delegate to the standard generateTypeCheck since unions are complex
-		// and the path rewrite is mainly an issue for recursive refs, not unions
-		generateUnionCheck(e, accessor, pathExpr, meta, registry, depth, ctx)
+		generateUnionCheckAt(e, accessor, pathExpr, meta, registry, depth, ctx)
 
 	case metadata.KindLiteral:
 		e.Block("if (%s !== %s)", accessor, jsLiteral(meta.LiteralValue))
-		e.Line("errors.push({ path: %s, expected: %q, received: %s });", pathExpr, fmt.Sprintf("%v", meta.LiteralValue), accessor)
+		e.Line("errors.push({ path: %s, expected: %q, received: %s%s });", pathExpr, fmt.Sprintf("%v", meta.LiteralValue), ctx.received(accessor, accessor), errorFields("type", ""))
 		e.EndBlock()
 
 	case metadata.KindAny, metadata.KindUnknown:
 		// No validation
 
 	case metadata.KindNever:
-		e.Line("errors.push({ path: %s, expected: \"never\", received: typeof %s });", pathExpr, accessor)
+		e.Line("errors.push({ path: %s, expected: \"never\", received: typeof %s%s });", pathExpr, accessor, errorFields("type", ""))
 
 	case metadata.KindVoid:
 		e.Block("if (%s !== undefined)", accessor)
-		e.Line("errors.push({ path: %s, expected: \"void\", received: typeof %s });", pathExpr, accessor)
+		e.Line("errors.push({ path: %s, expected: \"void\", received: typeof %s%s });", pathExpr, accessor, errorFields("type", ""))
 		e.EndBlock()
 
 	case metadata.KindIntersection:
+		if generateIntersectionKeysCheck(e, accessor, pathExpr, meta, registry, depth, ctx) {
+			for _, member := range meta.IntersectionMembers {
+				memberCopy := member
+				ctx.skipKeys = true
+				generateTypeCheckWithPathInner(e, accessor, pathExpr, &memberCopy, registry, depth, ctx)
+				ctx.skipKeys = false
+			}
+			break
+		}
 		for _, member := range meta.IntersectionMembers {
 			memberCopy := member
 			generateTypeCheckWithPathInner(e, accessor, pathExpr, &memberCopy, registry, depth, ctx)
@@ -490,14 +860,15 @@ func hasRecursiveRef(meta *metadata.Metadata, registry *metadata.TypeRegistry, v
 	return false
 }
 
-// generateStringifyFunction generates a combined validate+serialize function.
+// generateStringifyFunction generates a combined validate+serialize function,
+// stringify<Name> or stringify<Name>_<view> for a serialization view.
 // mode controls the type checking behavior:
 //   - "safe" (default): full validation with detailed errors before serializing
 //   - "guard": lightweight boolean type guard check before serializing
 //   - "none": no runtime check, serialize directly (maximum performance)
-func generateStringifyFunction(e *Emitter, typeName string, mode string) {
-	fnName := "stringify" + typeName
-	serializeFn := "serialize" + typeName
+func generateStringifyFunction(e *Emitter, typeName string, view string, mode string) {
+	fnName := ViewFuncName("stringify", typeName, view)
+	serializeFn := ViewFuncName("serialize", typeName, view)
 	e.Block("export function %s(input)", fnName)
 
 	switch mode {
@@ -562,15 +933,20 @@ func generateTypeCheckInner(e *Emitter, accessor string, path string, meta *meta
 	switch meta.Kind {
 	case metadata.KindAtomic:
 		generateAtomicCheck(e, accessor, path, meta.Atomic)
+		if meta.Atomic == "string" {
+			if max := ctx.maxStringLength(meta); max > 0 {
+				emitStringLengthCheck(e, accessor, strconv.Quote(path), max)
+			}
+		}
 		// Template literal types produce a regex pattern — validate it at runtime
 		if meta.Atomic == "string" && meta.TemplatePattern != "" {
 			e.Block("if (typeof %s === \"string\" && !/%s/.test(%s))", accessor, escapeForRegexLiteral(meta.TemplatePattern), accessor)
-			e.Line("errors.push({ path: %q, expected: \"pattern %s\", received: %s });", path, jsStringEscape(meta.TemplatePattern), accessor)
+			e.Line("errors.push({ path: %q, expected: \"pattern %s\", received: %s%s });", path, jsStringEscape(meta.TemplatePattern), ctx.received(accessor, accessor), errorFields("type", ""))
 			e.EndBlock()
 		}
 
 	case metadata.KindLiteral:
-		generateLiteralCheck(e, accessor, path, meta.LiteralValue)
+		generateLiteralCheck(e, accessor, path, meta.LiteralValue, ctx)
 
 	case metadata.KindObject:
 		generateObjectCheck(e, accessor, path, meta, registry, depth, ctx)
@@ -590,7 +966,7 @@ func generateTypeCheckInner(e *Emitter, accessor string, path string, meta *meta
 		// infinite recursion during codegen.
 		if ctx != nil && ctx.generating[meta.Ref] {
 			// Emit a recursive call to the validate function being generated
-			fnName := "validate" + meta.Ref
+			fnName := ctx.fnName("validate", meta.Ref)
 			e.Line("{ const _r = %s(%s); if (!_r.success) errors.push(..._r.errors.map(e => ({ ...e, path: e.path.replace(/^input/, %q) }))); }", fnName, accessor, path)
 		} else if resolved, ok := registry.Types[meta.Ref]; ok {
 			// Non-recursive ref: inline the checks as before
@@ -606,7 +982,7 @@ func generateTypeCheckInner(e *Emitter, accessor string, path string, meta *meta
 	case metadata.KindEnum:
 		// Enum types are handled as unions of their literal values
 		if len(meta.EnumValues) > 0 {
-			generateEnumCheck(e, accessor, path, meta)
+			generateEnumCheck(e, accessor, path, meta, ctx)
 		}
 
 	case metadata.KindNative:
@@ -616,15 +992,24 @@ func generateTypeCheckInner(e *Emitter, accessor string, path string, meta *meta
 		// No validation for any/unknown
 
 	case metadata.KindNever:
-		e.Line("errors.push({ path: %q, expected: \"never\", received: typeof %s });", path, accessor)
+		e.Line("errors.push({ path: %q, expected: \"never\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 
 	case metadata.KindVoid:
 		e.Block("if (%s !== undefined)", accessor)
-		e.Line("errors.push({ path: %q, expected: \"void\", received: typeof %s });", path, accessor)
+		e.Line("errors.push({ path: %q, expected: \"void\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 		e.EndBlock()
 
 	case metadata.KindIntersection:
 		// Intersection: all members must pass validation
+		if generateIntersectionKeysCheck(e, accessor, strconv.Quote(path), meta, registry, depth, ctx) {
+			for _, member := range meta.IntersectionMembers {
+				memberCopy := member
+				ctx.skipKeys = true
+				generateTypeCheckInner(e, accessor, path, &memberCopy, registry, depth, ctx)
+				ctx.skipKeys = false
+			}
+			break
+		}
 		for _, member := range meta.IntersectionMembers {
 			memberCopy := member
 			generateTypeCheckInner(e, accessor, path, &memberCopy, registry, depth, ctx)
@@ -636,36 +1021,37 @@ func generateAtomicCheck(e *Emitter, accessor string, path string, atomic string
 	switch atomic {
 	case "string":
 		e.Block("if (typeof %s !== \"string\")", accessor)
-		e.Line("errors.push({ path: %q, expected: \"string\", received: typeof %s });", path, accessor)
+		e.Line("errors.push({ path: %q, expected: \"string\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 		e.EndBlock()
 	case "number":
 		e.Block("if (typeof %s !== \"number\" || !Number.isFinite(%s))", accessor, accessor)
-		e.Line("errors.push({ path: %q, expected: \"number\", received: typeof %s });", path, accessor)
+		e.Line("errors.push({ path: %q, expected: \"number\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 		e.EndBlock()
 	case "boolean":
 		e.Block("if (typeof %s !== \"boolean\")", accessor)
-		e.Line("errors.push({ path: %q, expected: \"boolean\", received: typeof %s });", path, accessor)
+		e.Line("errors.push({ path: %q, expected: \"boolean\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 		e.EndBlock()
 	case "bigint":
 		e.Block("if (typeof %s !== \"bigint\")", accessor)
-		e.Line("errors.push({ path: %q, expected: \"bigint\", received: typeof %s });", path, accessor)
+		e.Line("errors.push({ path: %q, expected: \"bigint\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 		e.EndBlock()
 	}
 }
 
-func generateLiteralCheck(e *Emitter, accessor string, path string, value any) {
+func generateLiteralCheck(e *Emitter, accessor string, path string, value any, ctx *validateCtx) {
+	received := ctx.received(accessor, accessor)
 	switch v := value.(type) {
 	case string:
 		e.Block("if (%s !== %q)", accessor, v)
-		e.Line("errors.push({ path: %q, expected: %q, received: %s });", path, v, accessor)
+		e.Line("errors.push({ path: %q, expected: %q, received: %s%s });", path, v, received, errorFields("type", ""))
 		e.EndBlock()
 	case float64:
 		e.Block("if (%s !== %v)", accessor, v)
-		e.Line("errors.push({ path: %q, expected: %v, received: %s });", path, v, accessor)
+		e.Line("errors.push({ path: %q, expected: %v, received: %s%s });", path, v, received, errorFields("type", ""))
 		e.EndBlock()
 	case bool:
 		e.Block("if (%s !== %v)", accessor, v)
-		e.Line("errors.push({ path: %q, expected: %v, received: %s });", path, v, accessor)
+		e.Line("errors.push({ path: %q, expected: %v, received: %s%s });", path, v, received, errorFields("type", ""))
 		e.EndBlock()
 	}
 }
@@ -673,12 +1059,19 @@ func generateLiteralCheck(e *Emitter, accessor string, path string, value any) {
 func generateObjectCheck(e *Emitter, accessor string, path string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *validateCtx) {
 	// Check it's an object
 	e.Block("if (typeof %s !== \"object\" || %s === null)", accessor, accessor)
-	e.Line("errors.push({ path: %q, expected: \"object\", received: typeof %s });", path, accessor)
+	e.Line("errors.push({ path: %q, expected: \"object\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 	e.EndBlockSuffix(" else {")
 	e.indent++
+	emitRulesErrorMark(e, meta, depth)
+	strict := (ctx == nil || !ctx.exact) && meta.Strictness == "strict"
+	seq := openCheckSeq(e, depth, objectCheckCount(meta, ctx.rejectsUnknownKeys(meta) || strict))
 
 	// Handle object strictness
-	if meta.Strictness == "strict" || meta.Strictness == "strip" {
+	if ctx.rejectsUnknownKeys(meta) {
+		// Exact mode (equals markers) rejects unknown properties whatever the type's strictness
+		seq.next(e)
+		generateUnknownKeysCheck(e, accessor, strconv.Quote(path), meta.Properties, depth, ctx)
+	} else if (ctx == nil || !ctx.exact) && (meta.Strictness == "strict" || meta.Strictness == "strip") {
 		// Build known keys set
 		knownKeys := make([]string, 0, len(meta.Properties))
 		for _, prop := range meta.Properties {
@@ -688,10 +1081,12 @@ func generateObjectCheck(e *Emitter, accessor string, path string, meta *metadat
 
 		if meta.Strictness == "strict" {
 			// Strict: reject unknown properties
+			seq.next(e)
 			kVar := fmt.Sprintf("_k%d", depth)
 			e.Block("for (const %s of Object.keys(%s))", kVar, accessor)
+			emitErrorLimitGuard(e)
 			e.Block("if (!%s.has(%s))", knownSetExpr, kVar)
-			e.Line("errors.push({ path: %q + \".\" + %s, expected: \"known property\", received: %s });", path, kVar, kVar)
+			e.Line("errors.push({ path: %q + \".\" + %s, expected: \"known property\", received: %s%s });", path, kVar, kVar, errorFields("unknownProperty", ""))
 			e.EndBlock()
 			e.EndBlock()
 		} else if meta.Strictness == "strip" {
@@ -707,32 +1102,30 @@ func generateObjectCheck(e *Emitter, accessor string, path string, meta *metadat
 
 	// Check each property
 	for _, prop := range meta.Properties {
+		seq.next(e)
 		propAccessor := jsPropAccess(accessor, prop.Name)
 		propPath := path + jsPropPathSuffix(prop.Name)
 
 		// Emit default value assignment BEFORE validation.
 		// When a property has @default and the value is undefined, fill it in.
-		if prop.Constraints != nil && prop.Constraints.Default != nil {
-			jsDefault := defaultToJSLiteral(*prop.Constraints.Default, &prop.Type)
-			e.Block("if (%s === undefined)", propAccessor)
-			e.Line("%s = %s;", propAccessor, jsDefault)
-			e.EndBlock()
-		}
+		emitDefaultAssignment(e, accessor, prop.Name, &prop, ctx)
+		redacted := ctx.redactBy(prop.Constraints)
 
 		if prop.Required && !prop.Type.Optional {
 			e.Block("if (%s === undefined)", propAccessor)
-			e.Line("errors.push({ path: %q, expected: \"%s\", received: \"undefined\" });", propPath, describeType(&prop.Type))
+			e.Line("errors.push({ path: %q, expected: \"%s\", received: \"undefined\"%s });", propPath, describeType(&prop.Type), errorFields("required", ""))
 			e.EndBlockSuffix(" else {")
 			e.indent++
-			generateTypeCheck(e, propAccessor, propPath, &prop.Type, registry, depth+1, ctx)
+			emitPreChecks(e, propAccessor, &prop)
+			generateTypeCheck(e, propAccessor, propPath, boundedType(&prop.Type, prop.Constraints), registry, depth+1, ctx)
 			// After type check, emit constraint checks.
 			// When the base type is atomic, the typeof check was already done
 			// by generateAtomicCheck, so constraints can skip the typeof guard.
 			if prop.Constraints != nil {
 				if isAtomicType(&prop.Type) {
-					generateConstraintChecksVerified(e, propAccessor, propPath, &prop)
+					generateConstraintChecksVerified(e, propAccessor, propPath, &prop, ctx)
 				} else {
-					generateConstraintChecks(e, propAccessor, propPath, &prop)
+					generateConstraintChecks(e, propAccessor, propPath, &prop, ctx)
 				}
 			}
 			e.indent--
@@ -741,46 +1134,53 @@ func generateObjectCheck(e *Emitter, accessor string, path string, meta *metadat
 			// exactOptionalPropertyTypes: property can be missing but not explicitly undefined
 			e.Block("if (%q in %s)", prop.Name, accessor)
 			e.Block("if (%s === undefined)", propAccessor)
-			e.Line("errors.push({ path: %q, expected: \"%s (not undefined)\", received: \"undefined\" });", propPath, describeType(&prop.Type))
+			e.Line("errors.push({ path: %q, expected: \"%s (not undefined)\", received: \"undefined\"%s });", propPath, describeType(&prop.Type), errorFields("required", ""))
 			e.EndBlockSuffix(" else {")
 			e.indent++
-			generateTypeCheck(e, propAccessor, propPath, &prop.Type, registry, depth+1, ctx)
+			emitPreChecks(e, propAccessor, &prop)
+			generateTypeCheck(e, propAccessor, propPath, boundedType(&prop.Type, prop.Constraints), registry, depth+1, ctx)
 			if prop.Constraints != nil {
 				if isAtomicType(&prop.Type) {
-					generateConstraintChecksVerified(e, propAccessor, propPath, &prop)
+					generateConstraintChecksVerified(e, propAccessor, propPath, &prop, ctx)
 				} else {
-					generateConstraintChecks(e, propAccessor, propPath, &prop)
+					generateConstraintChecks(e, propAccessor, propPath, &prop, ctx)
 				}
 			}
 			e.indent--
 			e.Line("}")
 			e.EndBlock()
 		} else {
-			generateTypeCheck(e, propAccessor, propPath, &prop.Type, registry, depth+1, ctx)
+			emitPreChecks(e, propAccessor, &prop)
+			generateTypeCheck(e, propAccessor, propPath, boundedType(&prop.Type, prop.Constraints), registry, depth+1, ctx)
 			// After type check, emit constraint checks (only if value is present)
 			if prop.Constraints != nil {
 				if prop.Type.Optional || !prop.Required {
 					e.Block("if (%s !== undefined)", propAccessor)
 					if isAtomicType(&prop.Type) {
-						generateConstraintChecksVerified(e, propAccessor, propPath, &prop)
+						generateConstraintChecksVerified(e, propAccessor, propPath, &prop, ctx)
 					} else {
-						generateConstraintChecks(e, propAccessor, propPath, &prop)
+						generateConstraintChecks(e, propAccessor, propPath, &prop, ctx)
 					}
 					e.EndBlock()
 				} else {
 					if isAtomicType(&prop.Type) {
-						generateConstraintChecksVerified(e, propAccessor, propPath, &prop)
+						generateConstraintChecksVerified(e, propAccessor, propPath, &prop, ctx)
 					} else {
-						generateConstraintChecks(e, propAccessor, propPath, &prop)
+						generateConstraintChecks(e, propAccessor, propPath, &prop, ctx)
 					}
 				}
 			}
 		}
+		ctx.restoreRedact(redacted)
 	}
 
 	// Index signature validation: for objects with [key: string]: T,
 	// validate that all keys not in the declared properties have values matching T.
 	if meta.IndexSignature != nil {
+		seq.next(e)
+		if ctx.settings().Limits.MaxProperties > 0 {
+			emitPropertyCountCheck(e, accessor, strconv.Quote(path), ctx.settings().Limits.MaxProperties)
+		}
 		kVar := fmt.Sprintf("_ik%d", depth)
 		// Build set of known property names to exclude
 		if len(meta.Properties) > 0 {
@@ -790,9 +1190,11 @@ func generateObjectCheck(e *Emitter, accessor string, path string, meta *metadat
 			}
 			knownSetExpr := "new Set([" + joinQuoted(knownKeys) + "])"
 			e.Block("for (const %s of Object.keys(%s))", kVar, accessor)
+			emitErrorLimitGuard(e)
 			e.Block("if (!%s.has(%s))", knownSetExpr, kVar)
 		} else {
 			e.Block("for (const %s of Object.keys(%s))", kVar, accessor)
+			emitErrorLimitGuard(e)
 		}
 
 		elemAccessor := fmt.Sprintf("%s[%s]", accessor, kVar)
@@ -803,7 +1205,18 @@ func generateObjectCheck(e *Emitter, accessor string, path string, meta *metadat
 			e.EndBlock() // close the if (!knownSet.has) block
 		}
 		e.EndBlock() // close the for loop
+		if ctx.settings().Limits.MaxProperties > 0 {
+			e.indent--
+			e.Line("}")
+		}
+	}
+
+	// Cross-field rules (RequireOneOf, DependentRequired, object-level Validate)
+	if meta.Rules != nil {
+		seq.next(e)
+		generateObjectRuleChecks(e, accessor, strconv.Quote(path), meta.Rules, depth)
 	}
+	seq.close(e)
 
 	e.indent--
 	e.Line("}")
@@ -811,18 +1224,27 @@ func generateObjectCheck(e *Emitter, accessor string, path string, meta *metadat
 
 func generateArrayCheck(e *Emitter, accessor string, path string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *validateCtx) {
 	e.Block("if (!Array.isArray(%s))", accessor)
-	e.Line("errors.push({ path: %q, expected: \"array\", received: typeof %s });", path, accessor)
+	e.Line("errors.push({ path: %q, expected: \"array\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 	e.EndBlockSuffix(" else {")
 	e.indent++
 
+	max := ctx.maxArrayLength(meta)
+	if max > 0 {
+		emitArrayLengthCheck(e, accessor, strconv.Quote(path), max)
+	}
 	if meta.ElementType != nil {
 		idx := fmt.Sprintf("i%d", depth)
 		e.Block("for (let %s = 0; %s < %s.length; %s++)", idx, idx, accessor, idx)
+		emitErrorLimitGuard(e)
 		elemAccessor := fmt.Sprintf("%s[%s]", accessor, idx)
 		elemPath := fmt.Sprintf("%s[\" + %s + \"]", path, idx)
 		generateTypeCheck(e, elemAccessor, elemPath, meta.ElementType, registry, depth+1, ctx)
 		e.EndBlock()
 	}
+	if max > 0 {
+		e.indent--
+		e.Line("}")
+	}
 
 	e.indent--
 	e.Line("}")
@@ -830,7 +1252,7 @@ func generateArrayCheck(e *Emitter, accessor string, path string, meta *metadata
 
 func generateTupleCheck(e *Emitter, accessor string, path string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *validateCtx) {
 	e.Block("if (!Array.isArray(%s))", accessor)
-	e.Line("errors.push({ path: %q, expected: \"tuple\", received: typeof %s });", path, accessor)
+	e.Line("errors.push({ path: %q, expected: \"tuple\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 	e.EndBlockSuffix(" else {")
 	e.indent++
 
@@ -843,7 +1265,7 @@ func generateTupleCheck(e *Emitter, accessor string, path string, meta *metadata
 	}
 	if minLen > 0 {
 		e.Block("if (%s.length < %d)", accessor, minLen)
-		e.Line("errors.push({ path: %q, expected: \"tuple of length >= %d\", received: %s.length });", path, minLen, accessor)
+		e.Line("errors.push({ path: %q, expected: \"tuple of length >= %d\", received: %s.length%s });", path, minLen, accessor, errorFields("type", ""))
 		e.EndBlock()
 	}
 
@@ -886,7 +1308,7 @@ func generateUnionCheck(e *Emitter, accessor string, path string, meta *metadata
 		e.Block("if (!%s.includes(%s))", setExpr, accessor)
 		// Use jsStringEscape to safely embed literal values inside a JS string
 		escapedDesc := jsStringEscape("one of " + strings.Join(vals, " | "))
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s });", path, escapedDesc, accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, escapedDesc, ctx.received(accessor, accessor), errorFields("type", ""))
 		e.EndBlock()
 		return
 	}
@@ -897,25 +1319,107 @@ func generateUnionCheck(e *Emitter, accessor string, path string, meta *metadata
 		return
 	}
 
-	// For type unions, try each member — if any passes, the value is valid.
-	// We use a "union check" pattern: save errors, try each branch.
-	e.Line("const %s = errors.length;", unionSaveVar(depth))
-	e.Line("let %s = false;", unionValidVar(depth))
+	// For type unions, try each member until one passes. The errors of a failed
+	// object member are kept when it is the best match so far (see
+	// emitUnionBestMatch), and reported instead of the generic union error.
+	save, valid, best, score := unionSaveVar(depth), unionValidVar(depth), unionBestVar(depth), unionScoreVar(depth)
+	e.Line("const %s = errors.length;", save)
+	e.Line("let %s = false;", valid)
+	e.Line("let %s = null;", best)
+	e.Line("let %s = -Infinity;", score)
 
 	for i, member := range meta.UnionMembers {
 		e.Line("// union member %d", i)
-		e.Line("errors.length = %s;", unionSaveVar(depth))
+		e.Block("if (!%s)", valid)
 		memberCopy := member
+		logged := hasDefaults(&member, registry)
+		var outerLog string
+		if logged {
+			outerLog = emitDefaultLog(e, depth, ctx)
+		}
 		generateTypeCheckInner(e, accessor, path, &memberCopy, registry, depth+1, ctx)
-		e.Block("if (errors.length === %s)", unionSaveVar(depth))
-		e.Line("%s = true;", unionValidVar(depth))
+		e.Block("if (errors.length === %s)", save)
+		e.Line("%s = true;", valid)
+		if logged {
+			closeDefaultLog(e, ctx, outerLog)
+		}
+		e.EndBlockSuffix(" else {")
+		e.indent++
+		if logged {
+			undoDefaults(e, fmt.Sprintf("_dl%d", depth))
+		}
+		if keys, ok := unionMemberKeys(&member, registry, false); ok {
+			emitUnionBestMatch(e, accessor, keys, depth)
+		}
+		e.Line("errors.length = %s;", save)
+		e.indent--
+		e.Line("}")
 		e.EndBlock()
 	}
 
-	e.Block("if (!%s)", unionValidVar(depth))
-	e.Line("errors.length = %s;", unionSaveVar(depth))
+	e.Block("if (!%s)", valid)
+	e.Block("if (%s !== null)", best)
+	e.Line("errors.push(...%s);", best)
+	e.EndBlockSuffix(" else {")
+	e.indent++
 	expected := describeUnion(meta)
-	e.Line("errors.push({ path: %q, expected: %q, received: typeof %s });", path, expected, accessor)
+	e.Line("errors.push({ path: %q, expected: %q, received: typeof %s%s });", path, expected, accessor, errorFields("type", ""))
+	e.indent--
+	e.Line("}")
+	e.EndBlock()
+}
+
+// generateUnionCheckAt emits the checks of generateUnionCheck for a value
+// whose path is the JS expression pathExpr: the checks report paths from
+// accessor, which are moved under pathExpr.
+func generateUnionCheckAt(e *Emitter, accessor string, pathExpr string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *validateCtx) {
+	start := fmt.Sprintf("_up%d", depth)
+	// A bare block scopes the union variables of sibling unions
+	e.Line("{")
+	e.indent++
+	e.Line("const %s = errors.length;", start)
+	generateUnionCheck(e, accessor, accessor, meta, registry, depth, ctx)
+	e.Block("for (let _j = %s; _j < errors.length; _j++)", start)
+	e.Line("errors[_j].path = %s + errors[_j].path.slice(%q.length);", pathExpr, accessor)
+	e.EndBlock()
+	e.indent--
+	e.Line("}")
+}
+
+// unionMemberKeys returns the declared property names of a union member that
+// is an object type (directly or through a ref), their JSON names when wire is
+// set; ok is false for other members.
+func unionMemberKeys(member *metadata.Metadata, registry *metadata.TypeRegistry, wire bool) (keys []string, ok bool) {
+	if member.Kind == metadata.KindRef && registry != nil {
+		if resolved, found := registry.Types[member.Ref]; found {
+			member = resolved
+		}
+	}
+	if member.Kind != metadata.KindObject || len(member.Properties) == 0 {
+		return nil, false
+	}
+	for _, prop := range member.Properties {
+		if wire {
+			keys = append(keys, prop.WireName())
+		} else {
+			keys = append(keys, prop.Name)
+		}
+	}
+	return keys, true
+}
+
+// emitUnionBestMatch keeps the errors of a failed object member when it scores
+// higher than the previous members: the number of its declared properties
+// present on the value, minus the number of errors it reported. Members sharing
+// no property with the value are never kept.
+func emitUnionBestMatch(e *Emitter, accessor string, keys []string, depth int) {
+	save, best, score := unionSaveVar(depth), unionBestVar(depth), unionScoreVar(depth)
+	e.Block("if (typeof %s === \"object\" && %s !== null)", accessor, accessor)
+	e.Line("const _n = [%s].filter((k) => %s[k] !== undefined).length;", joinQuoted(keys), accessor)
+	e.Block("if (_n > 0 && _n - (errors.length - %s) > %s)", save, score)
+	e.Line("%s = _n - (errors.length - %s);", score, save)
+	e.Line("%s = errors.slice(%s);", best, save)
+	e.EndBlock()
 	e.EndBlock()
 }
 
@@ -927,7 +1431,7 @@ func generateDiscriminatedUnionCheck(e *Emitter, accessor string, path string, m
 
 	// First check the value is an object
 	e.Block("if (typeof %s !== \"object\" || %s === null)", accessor, accessor)
-	e.Line("errors.push({ path: %q, expected: \"object\", received: typeof %s });", path, accessor)
+	e.Line("errors.push({ path: %q, expected: \"object\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 	e.EndBlockSuffix(" else {")
 	e.indent++
 
@@ -938,6 +1442,7 @@ func generateDiscriminatedUnionCheck(e *Emitter, accessor string, path string, m
 	}
 	sort.Strings(discValues)
 
+	e.Line("const %s = errors.length;", unionSaveVar(depth))
 	e.Line("switch (%s) {", discAccessor)
 	e.indent++
 
@@ -952,6 +1457,7 @@ func generateDiscriminatedUnionCheck(e *Emitter, accessor string, path string, m
 		e.Line("case %s:", jsLiteral(val))
 		e.indent++
 		generateTypeCheckInner(e, accessor, path, &memberCopy, registry, depth+1, ctx)
+		emitDiscriminantTag(e, disc.Property, val, depth)
 		e.Line("break;")
 		e.indent--
 	}
@@ -964,7 +1470,7 @@ func generateDiscriminatedUnionCheck(e *Emitter, accessor string, path string, m
 		expectedVals[i] = jsLiteral(v)
 	}
 	expectedStr := jsStringEscape("one of " + strings.Join(expectedVals, " | "))
-	e.Line("errors.push({ path: \"%s%s\", expected: \"%s\", received: %s });", path, jsStringEscape(jsPropPathSuffix(disc.Property)), expectedStr, discAccessor)
+	e.Line("errors.push({ path: \"%s%s\", expected: \"%s\", received: %s%s });", path, jsStringEscape(jsPropPathSuffix(disc.Property)), expectedStr, ctx.received(discAccessor, discAccessor), errorFields("type", ""))
 	e.indent--
 
 	e.indent--
@@ -973,14 +1479,24 @@ func generateDiscriminatedUnionCheck(e *Emitter, accessor string, path string, m
 	e.EndBlock() // close else block
 }
 
-func generateEnumCheck(e *Emitter, accessor string, path string, meta *metadata.Metadata) {
+// emitDiscriminantTag tags the errors of a discriminated union branch with the
+// discriminant value that selected it. Errors of nested branches keep their tag.
+func emitDiscriminantTag(e *Emitter, property string, value string, depth int) {
+	e.Block("for (let _j = %s; _j < errors.length; _j++)", unionSaveVar(depth))
+	e.Block("if (errors[_j].discriminant === undefined)")
+	e.Line("errors[_j].discriminant = { property: %s, value: %s };", jsParam(property), jsLiteral(value))
+	e.EndBlock()
+	e.EndBlock()
+}
+
+func generateEnumCheck(e *Emitter, accessor string, path string, meta *metadata.Metadata, ctx *validateCtx) {
 	vals := make([]string, len(meta.EnumValues))
 	for i, ev := range meta.EnumValues {
 		vals[i] = jsLiteral(ev.Value)
 	}
 	setExpr := "[" + strings.Join(vals, ", ") + "]"
 	e.Block("if (!%s.includes(%s))", setExpr, accessor)
-	e.Line("errors.push({ path: %q, expected: \"enum value\", received: %s });", path, accessor)
+	e.Line("errors.push({ path: %q, expected: \"enum value\", received: %s%s });", path, ctx.received(accessor, accessor), errorFields("type", ""))
 	e.EndBlock()
 }
 
@@ -988,24 +1504,24 @@ func generateNativeCheck(e *Emitter, accessor string, path string, meta *metadat
 	switch meta.NativeType {
 	case "Date":
 		e.Block("if (!(%s instanceof Date) || isNaN(%s.getTime()))", accessor, accessor)
-		e.Line("errors.push({ path: %q, expected: \"Date\", received: typeof %s });", path, accessor)
+		e.Line("errors.push({ path: %q, expected: \"Date\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 		e.EndBlock()
 	case "RegExp":
 		e.Block("if (!(%s instanceof RegExp))", accessor)
-		e.Line("errors.push({ path: %q, expected: \"RegExp\", received: typeof %s });", path, accessor)
+		e.Line("errors.push({ path: %q, expected: \"RegExp\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 		e.EndBlock()
 	case "Map":
 		e.Block("if (!(%s instanceof Map))", accessor)
-		e.Line("errors.push({ path: %q, expected: \"Map\", received: typeof %s });", path, accessor)
+		e.Line("errors.push({ path: %q, expected: \"Map\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 		e.EndBlock()
 	case "Set":
 		e.Block("if (!(%s instanceof Set))", accessor)
-		e.Line("errors.push({ path: %q, expected: \"Set\", received: typeof %s });", path, accessor)
+		e.Line("errors.push({ path: %q, expected: \"Set\", received: typeof %s%s });", path, accessor, errorFields("type", ""))
 		e.EndBlock()
 	default:
 		// TypedArrays, URL, etc.
-		e.Block("if (!(%s instanceof %s))", accessor, meta.NativeType)
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: typeof %s });", path, meta.NativeType, accessor)
+		e.Block("if (!(%s))", nativeInstanceCheck(accessor, meta.NativeType))
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: typeof %s%s });", path, meta.NativeType, accessor, errorFields("type", ""))
 		e.EndBlock()
 	}
 }
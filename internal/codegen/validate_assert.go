@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/tsgonest/tsgonest/internal/metadata"
@@ -11,20 +12,34 @@ import (
 // For non-recursive types: direct inline checks with throw.
 // For recursive types: inner function with path parameter.
 func generateAssertFunction(e *Emitter, typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry, ctx *validateCtx) {
-	fnName := "assert" + typeName
+	fnName := ctx.fnName("assert", typeName)
 
 	// Check if type is recursive
 	isRecursive := isRecursiveType(typeName, meta, registry)
+	maxDepth := ctx.settings().Limits.MaxDepth
 
 	if isRecursive {
 		// Generate inner function with path parameter
-		innerFn := "_assert" + typeName
-		e.Block("function %s(input, _path)", innerFn)
+		innerFn := ctx.fnName("_assert", typeName)
+		if maxDepth > 0 {
+			ctx.depthParam = true
+			e.Block("function %s(input, _path, _d)", innerFn)
+			e.Block("if (_d > %d)", maxDepth)
+			emitAssertThrowFields(e, "_path", fmt.Sprintf("maxDepth %d", maxDepth), "\"depth \" + _d", errorFields("maxDepth", "", "max", fmt.Sprint(maxDepth)))
+			e.EndBlock()
+		} else {
+			e.Block("function %s(input, _path)", innerFn)
+		}
 		generateAssertChecks(e, "input", "_path", meta, registry, 0, ctx, true)
 		e.Line("return input;")
 		e.EndBlock()
+		ctx.depthParam = false
 		e.Block("export function %s(input)", fnName)
-		e.Line("return %s(input, \"input\");", innerFn)
+		if maxDepth > 0 {
+			e.Line("return %s(input, \"input\", 1);", innerFn)
+		} else {
+			e.Line("return %s(input, \"input\");", innerFn)
+		}
 		e.EndBlock()
 	} else {
 		e.Block("export function %s(input)", fnName)
@@ -79,66 +94,110 @@ func generateAssertChecksInner(e *Emitter, accessor string, pathExpr string, met
 			emitAssertThrow(e, pathExpr, "bigint", fmt.Sprintf("typeof %s", accessor))
 			e.EndBlock()
 		}
+		if meta.Atomic == "string" {
+			if max := ctx.maxStringLength(meta); max > 0 {
+				expected, fields := lengthErrorFields("maxLength", max)
+				e.Block("if (typeof %s === \"string\" && %s.length > %d)", accessor, accessor, max)
+				emitAssertThrowFields(e, pathExpr, expected, fmt.Sprintf("\"length \" + %s.length", accessor), fields)
+				e.EndBlock()
+			}
+		}
 		if meta.Atomic == "string" && meta.TemplatePattern != "" {
 			e.Block("if (typeof %s === \"string\" && !/%s/.test(%s))", accessor, escapeForRegexLiteral(meta.TemplatePattern), accessor)
-			emitAssertThrow(e, pathExpr, fmt.Sprintf("pattern %s", jsStringEscape(meta.TemplatePattern)), fmt.Sprintf("\"\\\"\" + %s + \"\\\"\"", accessor))
+			emitAssertThrow(e, pathExpr, fmt.Sprintf("pattern %s", jsStringEscape(meta.TemplatePattern)), ctx.received(accessor, fmt.Sprintf("\"\\\"\" + %s + \"\\\"\"", accessor)))
 			e.EndBlock()
 		}
 
 	case metadata.KindLiteral:
 		e.Block("if (%s !== %s)", accessor, jsLiteral(meta.LiteralValue))
-		emitAssertThrow(e, pathExpr, jsStringEscape(fmt.Sprintf("%v", meta.LiteralValue)), fmt.Sprintf("String(%s)", accessor))
+		emitAssertThrow(e, pathExpr, jsStringEscape(fmt.Sprintf("%v", meta.LiteralValue)), ctx.received(accessor, fmt.Sprintf("String(%s)", accessor)))
 		e.EndBlock()
 
 	case metadata.KindObject:
 		e.Block("if (typeof %s !== \"object\" || %s === null)", accessor, accessor)
 		emitAssertThrow(e, pathExpr, "object", fmt.Sprintf("typeof %s", accessor))
+		maxProperties := ctx.settings().Limits.MaxProperties
+		if len(meta.Properties) == 0 && meta.Rules == nil && (meta.IndexSignature == nil || maxProperties == 0) {
+			// Nothing to check on the object itself
+			e.EndBlock()
+			break
+		}
 		e.EndBlockSuffix(" else {")
 		e.indent++
+		// Request bodies are read under JSON names, renamed once validated
+		var renamed []*metadata.Property
+		if ctx.request {
+			for i := range meta.Properties {
+				if prop := &meta.Properties[i]; prop.WireName() != prop.Name {
+					emitDropTSName(e, accessor, prop, meta.Properties)
+					renamed = append(renamed, prop)
+				}
+			}
+		}
 		for _, prop := range meta.Properties {
-			propAccessor := jsPropAccess(accessor, prop.Name)
+			key := ctx.requestKey(&prop)
+			propAccessor := jsPropAccess(accessor, key)
 			var propPathExpr string
 			if isRecursive {
-				propPathExpr = fmt.Sprintf("%s + %q", pathExpr, jsPropPathSuffix(prop.Name))
+				propPathExpr = fmt.Sprintf("%s + %q", pathExpr, jsPropPathSuffix(key))
 			} else {
-				propPathExpr = fmt.Sprintf("%s + %q", pathExpr, jsPropPathSuffix(prop.Name))
+				propPathExpr = fmt.Sprintf("%s + %q", pathExpr, jsPropPathSuffix(key))
+			}
+			if ctx.request && prop.Readonly && ctx.settings().ReadOnlyMode != "" {
+				emitReadOnlyRequestCheck(e, propAccessor, propPathExpr, ctx.settings().ReadOnlyMode)
+				continue
+			}
+			if ctx.request && prop.Deprecated && ctx.settings().DeprecationHook.Module != "" {
+				emitDeprecationReport(e, propAccessor, propPathExpr, ctx)
 			}
+			emitDefaultAssignment(e, accessor, key, &prop, ctx)
+			redacted := ctx.redactBy(prop.Constraints)
 			if prop.Required && !prop.Type.Optional {
 				e.Block("if (%s === undefined)", propAccessor)
-				emitAssertThrow(e, propPathExpr, describeType(&prop.Type), "\"undefined\"")
+				emitAssertThrowFields(e, propPathExpr, describeType(&prop.Type), "\"undefined\"", errorFields("required", ""))
 				e.EndBlockSuffix(" else {")
 				e.indent++
-				emitAssertPreChecks(e, propAccessor, &prop)
-				generateAssertChecks(e, propAccessor, propPathExpr, &prop.Type, registry, depth+1, ctx, isRecursive)
-				generateAssertConstraintChecks(e, propAccessor, propPathExpr, &prop)
+				emitPreChecks(e, propAccessor, &prop)
+				generateAssertChecks(e, propAccessor, propPathExpr, boundedType(&prop.Type, prop.Constraints), registry, depth+1, ctx, isRecursive)
+				generateAssertConstraintChecks(e, propAccessor, propPathExpr, &prop, ctx)
 				e.indent--
 				e.Line("}")
 			} else if prop.ExactOptional {
-				e.Block("if (%q in %s)", prop.Name, accessor)
+				e.Block("if (%q in %s)", key, accessor)
 				e.Block("if (%s === undefined)", propAccessor)
-				emitAssertThrow(e, propPathExpr, describeType(&prop.Type), "\"explicit undefined\"")
+				emitAssertThrowFields(e, propPathExpr, describeType(&prop.Type), "\"explicit undefined\"", errorFields("required", ""))
 				e.EndBlockSuffix(" else {")
 				e.indent++
-				emitAssertPreChecks(e, propAccessor, &prop)
-				generateAssertChecks(e, propAccessor, propPathExpr, &prop.Type, registry, depth+1, ctx, isRecursive)
-				generateAssertConstraintChecks(e, propAccessor, propPathExpr, &prop)
+				emitPreChecks(e, propAccessor, &prop)
+				generateAssertChecks(e, propAccessor, propPathExpr, boundedType(&prop.Type, prop.Constraints), registry, depth+1, ctx, isRecursive)
+				generateAssertConstraintChecks(e, propAccessor, propPathExpr, &prop, ctx)
 				e.indent--
 				e.Line("}")
 				e.EndBlock()
 			} else {
-				emitAssertPreChecks(e, propAccessor, &prop)
-				generateAssertChecks(e, propAccessor, propPathExpr, &prop.Type, registry, depth+1, ctx, isRecursive)
+				emitPreChecks(e, propAccessor, &prop)
+				generateAssertChecks(e, propAccessor, propPathExpr, boundedType(&prop.Type, prop.Constraints), registry, depth+1, ctx, isRecursive)
 				if prop.Constraints != nil {
 					if prop.Type.Optional || !prop.Required {
 						e.Block("if (%s !== undefined)", propAccessor)
-						generateAssertConstraintChecks(e, propAccessor, propPathExpr, &prop)
+						generateAssertConstraintChecks(e, propAccessor, propPathExpr, &prop, ctx)
 						e.EndBlock()
 					} else {
-						generateAssertConstraintChecks(e, propAccessor, propPathExpr, &prop)
+						generateAssertConstraintChecks(e, propAccessor, propPathExpr, &prop, ctx)
 					}
 				}
 			}
+			ctx.restoreRedact(redacted)
+		}
+		for _, prop := range renamed {
+			emitJSONNameRename(e, accessor, prop)
 		}
+		if meta.IndexSignature != nil && maxProperties > 0 {
+			e.Block("if (Object.keys(%s).length > %d)", accessor, maxProperties)
+			emitAssertThrowFields(e, pathExpr, fmt.Sprintf("maxProperties %d", maxProperties), fmt.Sprintf("\"properties \" + Object.keys(%s).length", accessor), errorFields("maxProperties", "", "max", fmt.Sprint(maxProperties)))
+			e.EndBlock()
+		}
+		generateAssertObjectRuleChecks(e, accessor, pathExpr, meta.Rules)
 		e.indent--
 		e.Line("}")
 
@@ -147,6 +206,12 @@ func generateAssertChecksInner(e *Emitter, accessor string, pathExpr string, met
 		emitAssertThrow(e, pathExpr, "array", fmt.Sprintf("typeof %s", accessor))
 		e.EndBlockSuffix(" else {")
 		e.indent++
+		if max := ctx.maxArrayLength(meta); max > 0 {
+			expected, fields := lengthErrorFields("maxItems", max)
+			e.Block("if (%s.length > %d)", accessor, max)
+			emitAssertThrowFields(e, pathExpr, expected, fmt.Sprintf("\"length \" + %s.length", accessor), fields)
+			e.EndBlock()
+		}
 		if meta.ElementType != nil {
 			idx := fmt.Sprintf("i%d", depth)
 			e.Block("for (let %s = 0; %s < %s.length; %s++)", idx, idx, accessor, idx)
@@ -196,18 +261,26 @@ func generateAssertChecksInner(e *Emitter, accessor string, pathExpr string, met
 				checks[i] = fmt.Sprintf("%s === %s", accessor, v)
 			}
 			e.Block("if (!(%s))", strings.Join(checks, " || "))
-			emitAssertThrow(e, pathExpr, jsStringEscape(strings.Join(vals, " | ")), fmt.Sprintf("String(%s)", accessor))
+			emitAssertThrow(e, pathExpr, jsStringEscape(strings.Join(vals, " | ")), ctx.received(accessor, fmt.Sprintf("String(%s)", accessor)))
 			e.EndBlock()
+		} else if ctx != nil && ctx.request {
+			generateAssertRequestUnion(e, accessor, pathExpr, meta, registry, depth, ctx, isRecursive)
 		} else {
-			// For complex unions, delegate to validate + throw
-			e.Line("// complex union — delegate to validate")
+			generateAssertUnion(e, accessor, pathExpr, meta, registry, depth, ctx)
 		}
 
 	case metadata.KindRef:
 		if ctx != nil && ctx.generating[meta.Ref] {
 			// Recursive ref — call inner assert function
-			innerFn := "_assert" + meta.Ref
-			e.Line("%s(%s, %s);", innerFn, accessor, pathExpr)
+			innerFn := ctx.fnName("_assert", meta.Ref)
+			switch {
+			case ctx.depthParam:
+				e.Line("%s(%s, %s, _d + 1);", innerFn, accessor, pathExpr)
+			case ctx.settings().Limits.MaxDepth > 0:
+				e.Line("%s(%s, %s, 1);", innerFn, accessor, pathExpr)
+			default:
+				e.Line("%s(%s, %s);", innerFn, accessor, pathExpr)
+			}
 		} else if resolved, ok := registry.Types[meta.Ref]; ok {
 			if ctx != nil {
 				ctx.generating[meta.Ref] = true
@@ -226,7 +299,7 @@ func generateAssertChecksInner(e *Emitter, accessor string, pathExpr string, met
 			}
 			setExpr := "[" + strings.Join(vals, ", ") + "]"
 			e.Block("if (!%s.includes(%s))", setExpr, accessor)
-			emitAssertThrow(e, pathExpr, "enum value", fmt.Sprintf("String(%s)", accessor))
+			emitAssertThrow(e, pathExpr, "enum value", ctx.received(accessor, fmt.Sprintf("String(%s)", accessor)))
 			e.EndBlock()
 		}
 
@@ -237,7 +310,7 @@ func generateAssertChecksInner(e *Emitter, accessor string, pathExpr string, met
 			emitAssertThrow(e, pathExpr, "Date", fmt.Sprintf("typeof %s", accessor))
 			e.EndBlock()
 		default:
-			e.Block("if (!(%s instanceof %s))", accessor, meta.NativeType)
+			e.Block("if (!(%s))", nativeInstanceCheck(accessor, meta.NativeType))
 			emitAssertThrow(e, pathExpr, meta.NativeType, fmt.Sprintf("typeof %s", accessor))
 			e.EndBlock()
 		}
@@ -261,24 +334,124 @@ func generateAssertChecksInner(e *Emitter, accessor string, pathExpr string, met
 	}
 }
 
-// emitAssertThrow emits a throw statement with TsgonestValidationError (__e).
+// generateAssertUnion emits the checks of a union whose members are not
+// literals with the validate checks (see generateUnionCheck), collected in a
+// local errors array, so a failed union throws the errors of its best
+// matching member.
+func generateAssertUnion(e *Emitter, accessor string, pathExpr string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *validateCtx) {
+	generating := make(map[string]bool, len(ctx.generating))
+	for name := range ctx.generating {
+		generating[name] = true
+	}
+	vctx := &validateCtx{generating: generating, exact: ctx.exact, redact: ctx.redact, keys: ctx.keys, cfg: ctx.cfg}
+	// A bare block scopes the errors of sibling unions
+	e.Line("{")
+	e.indent++
+	e.Line("const errors = [];")
+	e.Line("const _max = Infinity;")
+	generateUnionCheckAt(e, accessor, pathExpr, meta, registry, depth, vctx)
+	e.Block("if (errors.length > 0)")
+	e.Line("throw new __e(errors);")
+	e.EndBlock()
+	e.indent--
+	e.Line("}")
+}
+
+// generateAssertRequestUnion emits the request checks of a union whose
+// members are not literals. Request checks rename, strip or reject properties
+// (see generateAssertRequestFunction), so each member is tried on a copy of
+// the value, objects with more required properties first (see
+// memberSpecificity), and the first one passing replaces it. Deprecated
+// properties are only reported for the selected member.
+func generateAssertRequestUnion(e *Emitter, accessor string, pathExpr string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *validateCtx, isRecursive bool) {
+	members := make([]*metadata.Metadata, len(meta.UnionMembers))
+	for i := range meta.UnionMembers {
+		members[i] = &meta.UnionMembers[i]
+	}
+	sort.SliceStable(members, func(i, j int) bool {
+		return memberSpecificity(members[i], registry) > memberSpecificity(members[j], registry)
+	})
+
+	okVar := fmt.Sprintf("_ok%d", depth)
+	tryVar := fmt.Sprintf("_u%d", depth)
+	queue := fmt.Sprintf("_dq%d", depth)
+	best, score := unionBestVar(depth), unionScoreVar(depth)
+	outerQueue := ctx.depQueue
+	// A bare block scopes the trial variables of sibling unions
+	e.Line("{")
+	e.indent++
+	e.Line("let %s = false;", okVar)
+	e.Line("let %s = null;", best)
+	e.Line("let %s = -Infinity;", score)
+	for i, member := range members {
+		if i > 0 {
+			e.Block("if (!%s)", okVar)
+		}
+		e.Block("try")
+		e.Line("let %s = structuredClone(%s);", tryVar, accessor)
+		e.Line("const %s = [];", queue)
+		ctx.depQueue = queue
+		generateAssertChecks(e, tryVar, pathExpr, member, registry, depth+1, ctx, isRecursive)
+		ctx.depQueue = outerQueue
+		e.Line("%s = %s;", accessor, tryVar)
+		e.Line("%s = true;", okVar)
+		e.Line("for (const _dp of %s) %s;", queue, ctx.deprecationCall("_dp"))
+		e.EndBlockSuffix(" catch (_err) {")
+		e.indent++
+		e.Line("if (!(_err instanceof __e)) throw _err;")
+		if keys, ok := unionMemberKeys(member, registry, true); ok {
+			// The error of the object member sharing the most properties
+			// with the value is thrown instead of the union error
+			e.Block("if (typeof %s === \"object\" && %s !== null)", accessor, accessor)
+			e.Line("const _n = [%s].filter((k) => %s[k] !== undefined).length;", joinQuoted(keys), accessor)
+			e.Block("if (_n > 0 && _n - 1 > %s)", score)
+			e.Line("%s = _n - 1;", score)
+			e.Line("%s = _err.errors;", best)
+			e.EndBlock()
+			e.EndBlock()
+		}
+		e.indent--
+		e.Line("}")
+		if i > 0 {
+			e.EndBlock()
+		}
+	}
+	e.Block("if (!%s)", okVar)
+	e.Block("if (%s !== null)", best)
+	e.Line("throw new __e(%s);", best)
+	e.EndBlock()
+	emitAssertThrow(e, pathExpr, jsStringEscape(describeType(meta)), ctx.received(accessor, fmt.Sprintf("typeof %s", accessor)))
+	e.EndBlock()
+	e.indent--
+	e.Line("}")
+}
+
+// emitAssertThrow emits a throw statement with TsgonestValidationError (__e)
+// for a type mismatch.
 // pathExpr is a JS expression evaluating to the field path string.
 // expected is a Go string literal describing the expected type/value.
 // receivedExpr is a JS expression evaluating to a string describing the received value.
 func emitAssertThrow(e *Emitter, pathExpr string, expected string, receivedExpr string) {
-	e.Line("throw new __e([{path: %s, expected: \"%s\", received: %s}]);", pathExpr, expected, receivedExpr)
+	emitAssertThrowFields(e, pathExpr, expected, receivedExpr, errorFields("type", ""))
 }
 
-// emitAssertPreChecks emits transforms and coercion that must run BEFORE type checks.
-// Called at the property level before generateAssertChecks so that string values
-// are coerced to number/boolean before the typeof check rejects them.
-func emitAssertPreChecks(e *Emitter, accessor string, prop *metadata.Property) {
+// emitAssertThrowFields is emitAssertThrow for a check with an error code,
+// with fields as returned by errorFields.
+func emitAssertThrowFields(e *Emitter, pathExpr string, expected string, receivedExpr string, fields string) {
+	e.Line("throw new __e([{path: %s, expected: \"%s\", received: %s%s}]);", pathExpr, expected, receivedExpr, fields)
+}
+
+// emitPreChecks emits transforms and coercion that must run BEFORE type checks.
+// Called at the property level before the validate/assert type checks so that
+// string values are coerced to number/boolean before the typeof check rejects
+// them, and transform results are what gets validated.
+func emitPreChecks(e *Emitter, accessor string, prop *metadata.Property) {
 	c := prop.Constraints
 	if c == nil {
 		return
 	}
-	if len(c.Transforms) > 0 {
-		generateTransforms(e, accessor, c.Transforms)
+	if len(c.Transforms) > 0 || len(c.TransformFns) > 0 {
+		generateTransforms(e, accessor, c.Transforms, c.TransformFns)
 	}
 	if c.Coerce != nil && *c.Coerce {
 		generateCoercion(e, accessor, &prop.Type)
@@ -287,8 +460,8 @@ func emitAssertPreChecks(e *Emitter, accessor string, prop *metadata.Property) {
 
 // generateAssertConstraintChecks emits assert-style constraint checks (throw on first failure).
 // Uses custom error messages when configured (per-constraint or global).
-// Note: transforms and coercion are emitted by emitAssertPreChecks (called before type checks).
-func generateAssertConstraintChecks(e *Emitter, accessor string, pathExpr string, prop *metadata.Property) {
+// Note: transforms and coercion are emitted by emitPreChecks (called before type checks).
+func generateAssertConstraintChecks(e *Emitter, accessor string, pathExpr string, prop *metadata.Property, ctx *validateCtx) {
 	c := prop.Constraints
 	if c == nil {
 		return
@@ -306,44 +479,43 @@ func generateAssertConstraintChecks(e *Emitter, accessor string, pathExpr string
 		}
 		return defaultMsg
 	}
+	fields := func(constraintKey string, params ...string) string {
+		return errorFields(constraintKey, customMessage(c.Errors, c.ErrorMessage, constraintKey), params...)
+	}
+	// received and quoted describe the value in errors, redacted to its type
+	// for sensitive values.
+	received := ctx.received(accessor, fmt.Sprintf("String(%s)", accessor))
+	quoted := ctx.received(accessor, fmt.Sprintf("\"\\\"\" + %s + \"\\\"\"", accessor))
 
 	if c.Minimum != nil {
 		e.Block("if (typeof %s === \"number\" && %s < %v)", accessor, accessor, *c.Minimum)
-		emitAssertThrow(e, pathExpr, errMsg("minimum", fmt.Sprintf("minimum %v", *c.Minimum)), fmt.Sprintf("String(%s)", accessor))
+		emitAssertThrowFields(e, pathExpr, errMsg("minimum", fmt.Sprintf("minimum %v", *c.Minimum)), received, fields("minimum", "min", fmt.Sprint(*c.Minimum)))
 		e.EndBlock()
 	}
 	if c.Maximum != nil {
 		e.Block("if (typeof %s === \"number\" && %s > %v)", accessor, accessor, *c.Maximum)
-		emitAssertThrow(e, pathExpr, errMsg("maximum", fmt.Sprintf("maximum %v", *c.Maximum)), fmt.Sprintf("String(%s)", accessor))
+		emitAssertThrowFields(e, pathExpr, errMsg("maximum", fmt.Sprintf("maximum %v", *c.Maximum)), received, fields("maximum", "max", fmt.Sprint(*c.Maximum)))
 		e.EndBlock()
 	}
 	if c.MinLength != nil {
 		e.Block("if (typeof %s === \"string\" && %s.length < %d)", accessor, accessor, *c.MinLength)
-		emitAssertThrow(e, pathExpr, errMsg("minLength", fmt.Sprintf("minLength %d", *c.MinLength)), fmt.Sprintf("\"length \" + %s.length", accessor))
+		emitAssertThrowFields(e, pathExpr, errMsg("minLength", fmt.Sprintf("minLength %d", *c.MinLength)), fmt.Sprintf("\"length \" + %s.length", accessor), fields("minLength", "min", fmt.Sprint(*c.MinLength)))
 		e.EndBlock()
 	}
 	if c.MaxLength != nil {
 		e.Block("if (typeof %s === \"string\" && %s.length > %d)", accessor, accessor, *c.MaxLength)
-		emitAssertThrow(e, pathExpr, errMsg("maxLength", fmt.Sprintf("maxLength %d", *c.MaxLength)), fmt.Sprintf("\"length \" + %s.length", accessor))
+		emitAssertThrowFields(e, pathExpr, errMsg("maxLength", fmt.Sprintf("maxLength %d", *c.MaxLength)), fmt.Sprintf("\"length \" + %s.length", accessor), fields("maxLength", "max", fmt.Sprint(*c.MaxLength)))
 		e.EndBlock()
 	}
 	if c.Pattern != nil {
 		e.Block("if (typeof %s === \"string\" && !/%s/.test(%s))", accessor, escapeForRegexLiteral(*c.Pattern), accessor)
-		emitAssertThrow(e, pathExpr, errMsg("pattern", fmt.Sprintf("pattern %s", jsStringEscape(*c.Pattern))), fmt.Sprintf("\"\\\"\" + %s + \"\\\"\"", accessor))
+		emitAssertThrowFields(e, pathExpr, errMsg("pattern", fmt.Sprintf("pattern %s", jsStringEscape(*c.Pattern))), quoted, fields("pattern", "pattern", jsParam(*c.Pattern)))
 		e.EndBlock()
 	}
 	if c.Format != nil {
-		pattern, ok := formatRegexes[*c.Format]
-		if ok && pattern != "" {
-			flags := formatFlags[*c.Format]
-			var regexLiteral string
-			if flags != "" {
-				regexLiteral = fmt.Sprintf("/%s/%s", pattern, flags)
-			} else {
-				regexLiteral = fmt.Sprintf("/%s/", pattern)
-			}
-			e.Block("if (typeof %s === \"string\" && !%s.test(%s))", accessor, regexLiteral, accessor)
-			emitAssertThrow(e, pathExpr, errMsg("format", fmt.Sprintf("format %s", *c.Format)), fmt.Sprintf("\"\\\"\" + %s + \"\\\"\"", accessor))
+		if formatExpr, ok := formatTestExpr(*c.Format, ctx.settings()); ok {
+			e.Block("if (typeof %s === \"string\" && !%s.test(%s))", accessor, formatExpr, accessor)
+			emitAssertThrowFields(e, pathExpr, errMsg("format", fmt.Sprintf("format %s", *c.Format)), quoted, fields("format", "format", jsParam(*c.Format)))
 			e.EndBlock()
 		}
 	}
@@ -0,0 +1,165 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// HasAsyncValidators reports whether meta contains properties with an async
+// custom validator (ValidateAsync<typeof fn>).
+func HasAsyncValidators(meta *metadata.Metadata, registry *metadata.TypeRegistry) bool {
+	return newWalker(registry, asyncOp{}, nil).needs(meta)
+}
+
+// generateAsyncFunctions generates validateAsync<Name> and assertAsync<Name>.
+// Both run the synchronous validate/assert first and only call the async
+// validators when it succeeds, so async validators never see malformed input.
+// The validators run concurrently; their errors are reported in declaration
+// order. A rejected validator promise rejects the returned promise.
+// With the assertAsyncRequest marker, assertAsyncRequest<Name> runs the async
+// validators after assertRequest<Name>, for request bodies.
+// Requires the validate and assert (assertRequest) functions of the same companion.
+func generateAsyncFunctions(e *Emitter, typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry, markers map[string]bool, s *Settings) {
+	w := newWalker(registry, asyncOp{}, newKeySets("_ak_"+typeName+"_"))
+	hasAsync := w.needs(meta)
+	if hasAsync {
+		w.define(typeName, meta)
+	}
+	// Request bodies report errors under JSON names, like assertRequest
+	requestW := w
+	if hasAsync && markers["assertAsyncRequest"] && HasJSONNames(meta, registry) {
+		requestW = newWalker(registry, asyncOp{request: true}, newKeySets("_ark_"+typeName+"_"))
+		requestW.define(typeName, meta)
+	}
+
+	emitChecks := func(w *walker, data string) {
+		e.Line("const _p = [];")
+		e.Line("%s(%s, \"input\", _p);", w.op.funcName(typeName), data)
+		e.Line("const errors = [];")
+		e.Block("for (const _err of await Promise.all(_p))")
+		e.Block("if (_err)")
+		e.Line("errors.push(_err);")
+		e.EndBlock()
+		e.EndBlock()
+	}
+
+	emitAssert := func(w *walker, name string, assert string) {
+		e.Block("export async function %s%s(input)", name, typeName)
+		e.Line("const data = %s%s(input);", assert, typeName)
+		if hasAsync {
+			emitChecks(w, "data")
+			e.Block("if (errors.length > 0)")
+			e.Line("throw new __e(errors);")
+			e.EndBlock()
+		}
+		e.Line("return data;")
+		e.EndBlock()
+	}
+	plain := markers["validateAsync"] || markers["assertAsync"]
+	if plain {
+		e.Block("export async function validateAsync%s(input, options)", typeName)
+		e.Line("const result = validate%s(input, options);", typeName)
+		if hasAsync {
+			e.Block("if (!result.success)")
+			e.Line("return result;")
+			e.EndBlock()
+			emitChecks(w, "result.data")
+			e.Block("if (errors.length > 0)")
+			emitErrorLimit(e, s)
+			emitErrorLimitResult(e)
+			e.Line("return { success: false, errors };")
+			e.EndBlock()
+		}
+		e.Line("return result;")
+		e.EndBlock()
+
+		emitAssert(w, "assertAsync", "assert")
+	}
+	if markers["assertAsyncRequest"] {
+		emitAssert(requestW, "assertAsyncRequest", "assertRequest")
+	}
+
+	if plain || requestW == w {
+		w.emit(e)
+	}
+	if requestW != w {
+		requestW.emit(e)
+	}
+}
+
+// asyncOp calls the async validators of properties. Each local function
+// pushes one promise per validator call onto _p, resolving to an error object
+// or null.
+type asyncOp struct {
+	walkDefaults
+	request bool // paths of request bodies, under JSON names (see validateCtx.requestKey)
+}
+
+// funcName returns the local async check function name for a named type:
+// _va_<Name>, or _var_<Name> for request bodies.
+func (op asyncOp) funcName(typeName string) string {
+	if op.request {
+		return "_var_" + typeName
+	}
+	return "_va_" + typeName
+}
+
+// applies reports whether meta is an object with async validators.
+func (asyncOp) applies(meta *metadata.Metadata) bool {
+	if meta.Kind != metadata.KindObject {
+		return false
+	}
+	for i := range meta.Properties {
+		if hasAsyncConstraint(&meta.Properties[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (asyncOp) call(e *Emitter, fn string, accessor string, pathExpr string) {
+	e.Line("%s(%s, %s, _p);", fn, accessor, pathExpr)
+}
+
+func (asyncOp) params() string { return ", _path, _p" }
+
+func (op asyncOp) pathKey(prop *metadata.Property) string {
+	if op.request {
+		return prop.WireName()
+	}
+	return prop.Name
+}
+
+// hasAsyncConstraint reports whether a property has an async validator.
+func hasAsyncConstraint(prop *metadata.Property) bool {
+	return prop.Constraints != nil && prop.Constraints.ValidateAsyncFn != nil
+}
+
+// asyncExpected returns the expected message of an async validator.
+func asyncExpected(c *metadata.Constraints) string {
+	if msg, ok := c.Errors["validateAsync"]; ok {
+		return jsStringEscape(msg)
+	}
+	if c.ErrorMessage != nil {
+		return jsStringEscape(*c.ErrorMessage)
+	}
+	return fmt.Sprintf("validateAsync(%s)", *c.ValidateAsyncFn)
+}
+
+// property emits the async validator call of a property.
+func (asyncOp) property(e *Emitter, accessor string, pathExpr string, prop *metadata.Property) {
+	if !hasAsyncConstraint(prop) {
+		return
+	}
+	e.Block("if (%s !== undefined && %s !== null)", accessor, accessor)
+	e.Line("const _v = %s;", accessor)
+	c := prop.Constraints
+	received := "\"\" + _v"
+	if isSensitive(c) {
+		received = "typeof _v"
+	}
+	e.Line("_p.push(Promise.resolve(%s(_v)).then((_ok) => _ok ? null : { path: %s, expected: \"%s\", received: %s%s }));",
+		*c.ValidateAsyncFn, pathExpr, asyncExpected(c), received, errorFields("validateAsync", customMessage(c.Errors, c.ErrorMessage, "validateAsync"), "fn", jsParam(*c.ValidateAsyncFn)))
+	e.EndBlock()
+}
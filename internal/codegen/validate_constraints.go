@@ -7,7 +7,9 @@ import (
 )
 
 // generateTransforms emits JS statements that transform the value in-place before validation.
-func generateTransforms(e *Emitter, accessor string, transforms []string) {
+// Built-in transforms run first, then the user transform functions in order.
+// User transforms are skipped for absent values.
+func generateTransforms(e *Emitter, accessor string, transforms []string, fns []metadata.FunctionRef) {
 	for _, t := range transforms {
 		switch t {
 		case "trim":
@@ -24,6 +26,11 @@ func generateTransforms(e *Emitter, accessor string, transforms []string) {
 			e.EndBlock()
 		}
 	}
+	for _, fn := range fns {
+		e.Block("if (%s !== undefined)", accessor)
+		e.Line("%s = %s(%s);", accessor, fn.Name, accessor)
+		e.EndBlock()
+	}
 }
 
 // generateCoercion emits JS code to coerce string inputs to the declared type.
@@ -57,32 +64,23 @@ func generateCoercion(e *Emitter, accessor string, typeMeta *metadata.Metadata)
 }
 
 // generateConstraintChecks emits JS validation checks for JSDoc constraints.
+// Transforms and coercion are emitted by emitPreChecks (called before type checks).
 // When typeVerified is true, typeof guards on constraint checks are omitted because
 // the type has already been verified by a preceding type check.
-func generateConstraintChecks(e *Emitter, accessor string, path string, prop *metadata.Property) {
-	generateConstraintChecksInner(e, accessor, path, prop, false)
+func generateConstraintChecks(e *Emitter, accessor string, path string, prop *metadata.Property, ctx *validateCtx) {
+	generateConstraintChecksInner(e, accessor, path, prop, false, ctx)
 }
 
-func generateConstraintChecksVerified(e *Emitter, accessor string, path string, prop *metadata.Property) {
-	generateConstraintChecksInner(e, accessor, path, prop, true)
+func generateConstraintChecksVerified(e *Emitter, accessor string, path string, prop *metadata.Property, ctx *validateCtx) {
+	generateConstraintChecksInner(e, accessor, path, prop, true, ctx)
 }
 
-func generateConstraintChecksInner(e *Emitter, accessor string, path string, prop *metadata.Property, typeVerified bool) {
+func generateConstraintChecksInner(e *Emitter, accessor string, path string, prop *metadata.Property, typeVerified bool, ctx *validateCtx) {
 	c := prop.Constraints
 	if c == nil {
 		return
 	}
 
-	// Emit transforms BEFORE validation checks
-	if len(c.Transforms) > 0 {
-		generateTransforms(e, accessor, c.Transforms)
-	}
-
-	// Emit coercion BEFORE type checks
-	if c.Coerce != nil && *c.Coerce {
-		generateCoercion(e, accessor, &prop.Type)
-	}
-
 	// Helper: use per-constraint error if present, then global ErrorMessage, then default.
 	// constraintKey is the Constraints field name (e.g., "format", "minLength", "minimum").
 	errMsg := func(constraintKey string, defaultExpected string) string {
@@ -96,6 +94,14 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		}
 		return defaultExpected
 	}
+	// fields returns the code/params fields of the error of a check (see errorFields).
+	fields := func(constraintKey string, params ...string) string {
+		return errorFields(constraintKey, customMessage(c.Errors, c.ErrorMessage, constraintKey), params...)
+	}
+	// received and receivedRaw are the received fields of errors echoing the
+	// value (stringified or as is), redacted to its type for sensitive values.
+	received := ctx.received(accessor, "\"\" + "+accessor)
+	receivedRaw := ctx.received(accessor, accessor)
 
 	// Numeric constraints
 	if c.Minimum != nil {
@@ -104,7 +110,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (typeof %s === \"number\" && %s < %v)", accessor, accessor, *c.Minimum)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"\" + %s });", path, errMsg("minimum", fmt.Sprintf("minimum %v", *c.Minimum)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("minimum", fmt.Sprintf("minimum %v", *c.Minimum)), received, fields("minimum", "min", fmt.Sprint(*c.Minimum)))
 		e.EndBlock()
 	}
 	if c.Maximum != nil {
@@ -113,7 +119,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (typeof %s === \"number\" && %s > %v)", accessor, accessor, *c.Maximum)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"\" + %s });", path, errMsg("maximum", fmt.Sprintf("maximum %v", *c.Maximum)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("maximum", fmt.Sprintf("maximum %v", *c.Maximum)), received, fields("maximum", "max", fmt.Sprint(*c.Maximum)))
 		e.EndBlock()
 	}
 	if c.ExclusiveMinimum != nil {
@@ -122,7 +128,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (typeof %s === \"number\" && %s <= %v)", accessor, accessor, *c.ExclusiveMinimum)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"\" + %s });", path, errMsg("exclusiveMinimum", fmt.Sprintf("exclusiveMinimum %v", *c.ExclusiveMinimum)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("exclusiveMinimum", fmt.Sprintf("exclusiveMinimum %v", *c.ExclusiveMinimum)), received, fields("exclusiveMinimum", "min", fmt.Sprint(*c.ExclusiveMinimum)))
 		e.EndBlock()
 	}
 	if c.ExclusiveMaximum != nil {
@@ -131,7 +137,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (typeof %s === \"number\" && %s >= %v)", accessor, accessor, *c.ExclusiveMaximum)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"\" + %s });", path, errMsg("exclusiveMaximum", fmt.Sprintf("exclusiveMaximum %v", *c.ExclusiveMaximum)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("exclusiveMaximum", fmt.Sprintf("exclusiveMaximum %v", *c.ExclusiveMaximum)), received, fields("exclusiveMaximum", "max", fmt.Sprint(*c.ExclusiveMaximum)))
 		e.EndBlock()
 	}
 	if c.MultipleOf != nil {
@@ -155,11 +161,11 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 				e.Block("if (typeof %s === \"number\" && Math.abs(%s / %v - Math.round(%s / %v)) > 1e-10)", accessor, accessor, mul, accessor, mul)
 			}
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"\" + %s });", path, errMsg("multipleOf", fmt.Sprintf("multipleOf %v", mul)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("multipleOf", fmt.Sprintf("multipleOf %v", mul)), received, fields("multipleOf", "multipleOf", fmt.Sprint(mul)))
 		e.EndBlock()
 	}
 	if c.NumericType != nil {
-		generateNumericTypeCheck(e, accessor, path, *c.NumericType, c.ErrorMessage, c.Errors, typeVerified)
+		generateNumericTypeCheck(e, accessor, path, *c.NumericType, c.ErrorMessage, c.Errors, typeVerified, ctx)
 	}
 
 	// String length constraints
@@ -169,7 +175,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (typeof %s === \"string\" && %s.length < %d)", accessor, accessor, *c.MinLength)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"length \" + %s.length });", path, errMsg("minLength", fmt.Sprintf("minLength %d", *c.MinLength)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"length \" + %s.length%s });", path, errMsg("minLength", fmt.Sprintf("minLength %d", *c.MinLength)), accessor, fields("minLength", "min", fmt.Sprint(*c.MinLength)))
 		e.EndBlock()
 	}
 	if c.MaxLength != nil {
@@ -178,7 +184,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (typeof %s === \"string\" && %s.length > %d)", accessor, accessor, *c.MaxLength)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"length \" + %s.length });", path, errMsg("maxLength", fmt.Sprintf("maxLength %d", *c.MaxLength)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"length \" + %s.length%s });", path, errMsg("maxLength", fmt.Sprintf("maxLength %d", *c.MaxLength)), accessor, fields("maxLength", "max", fmt.Sprint(*c.MaxLength)))
 		e.EndBlock()
 	}
 
@@ -190,13 +196,13 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (typeof %s === \"string\" && !/%s/.test(%s))", accessor, escapedPattern, accessor)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s });", path, errMsg("pattern", fmt.Sprintf("pattern %s", *c.Pattern)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("pattern", fmt.Sprintf("pattern %s", *c.Pattern)), receivedRaw, fields("pattern", "pattern", jsParam(*c.Pattern)))
 		e.EndBlock()
 	}
 
 	// Format constraint
 	if c.Format != nil {
-		generateFormatCheck(e, accessor, path, *c.Format, c.ErrorMessage, c.Errors, typeVerified)
+		generateFormatCheck(e, accessor, path, *c.Format, c.ErrorMessage, c.Errors, typeVerified, ctx)
 	}
 
 	// Array constraints
@@ -206,7 +212,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (Array.isArray(%s) && %s.length < %d)", accessor, accessor, *c.MinItems)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"length \" + %s.length });", path, errMsg("minItems", fmt.Sprintf("minItems %d", *c.MinItems)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"length \" + %s.length%s });", path, errMsg("minItems", fmt.Sprintf("minItems %d", *c.MinItems)), accessor, fields("minItems", "min", fmt.Sprint(*c.MinItems)))
 		e.EndBlock()
 	}
 	if c.MaxItems != nil {
@@ -215,7 +221,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (Array.isArray(%s) && %s.length > %d)", accessor, accessor, *c.MaxItems)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"length \" + %s.length });", path, errMsg("maxItems", fmt.Sprintf("maxItems %d", *c.MaxItems)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"length \" + %s.length%s });", path, errMsg("maxItems", fmt.Sprintf("maxItems %d", *c.MaxItems)), accessor, fields("maxItems", "max", fmt.Sprint(*c.MaxItems)))
 		e.EndBlock()
 	}
 	if c.UniqueItems != nil && *c.UniqueItems {
@@ -224,7 +230,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (Array.isArray(%s) && new Set(%s).size !== %s.length)", accessor, accessor, accessor)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"duplicate items\" });", path, errMsg("uniqueItems", "uniqueItems"))
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"duplicate items\"%s });", path, errMsg("uniqueItems", "uniqueItems"), fields("uniqueItems"))
 		e.EndBlock()
 	}
 
@@ -236,7 +242,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (typeof %s === \"string\" && !%s.startsWith(\"%s\"))", accessor, accessor, escaped)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s });", path, errMsg("startsWith", fmt.Sprintf("startsWith %s", escaped)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("startsWith", fmt.Sprintf("startsWith %s", escaped)), receivedRaw, fields("startsWith", "value", jsParam(*c.StartsWith)))
 		e.EndBlock()
 	}
 	if c.EndsWith != nil {
@@ -246,7 +252,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (typeof %s === \"string\" && !%s.endsWith(\"%s\"))", accessor, accessor, escaped)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s });", path, errMsg("endsWith", fmt.Sprintf("endsWith %s", escaped)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("endsWith", fmt.Sprintf("endsWith %s", escaped)), receivedRaw, fields("endsWith", "value", jsParam(*c.EndsWith)))
 		e.EndBlock()
 	}
 	if c.Includes != nil {
@@ -256,7 +262,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (typeof %s === \"string\" && !%s.includes(\"%s\"))", accessor, accessor, escaped)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s });", path, errMsg("includes", fmt.Sprintf("includes %s", escaped)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("includes", fmt.Sprintf("includes %s", escaped)), receivedRaw, fields("includes", "value", jsParam(*c.Includes)))
 		e.EndBlock()
 	}
 	if c.Uppercase != nil && *c.Uppercase {
@@ -265,7 +271,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (typeof %s === \"string\" && %s !== %s.toUpperCase())", accessor, accessor, accessor)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s });", path, errMsg("uppercase", "uppercase"), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("uppercase", "uppercase"), receivedRaw, fields("uppercase"))
 		e.EndBlock()
 	}
 	if c.Lowercase != nil && *c.Lowercase {
@@ -274,7 +280,7 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 		} else {
 			e.Block("if (typeof %s === \"string\" && %s !== %s.toLowerCase())", accessor, accessor, accessor)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s });", path, errMsg("lowercase", "lowercase"), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("lowercase", "lowercase"), receivedRaw, fields("lowercase"))
 		e.EndBlock()
 	}
 
@@ -282,14 +288,14 @@ func generateConstraintChecksInner(e *Emitter, accessor string, path string, pro
 	if c.ValidateFn != nil {
 		fnName := *c.ValidateFn
 		e.Block("if (!%s(%s))", fnName, accessor)
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"\" + %s });", path, errMsg("validate", fmt.Sprintf("validate(%s)", fnName)), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("validate", fmt.Sprintf("validate(%s)", fnName)), received, fields("validate", "fn", jsParam(fnName)))
 		e.EndBlock()
 	}
 }
 
 // generateNumericTypeCheck emits validation for @type int32/uint32/int64/uint64/float/double.
 // Checks perConstraintErrors["type"] first, then customError (global), then default.
-func generateNumericTypeCheck(e *Emitter, accessor string, path string, numType string, customError *string, perConstraintErrors map[string]string, typeVerified bool) {
+func generateNumericTypeCheck(e *Emitter, accessor string, path string, numType string, customError *string, perConstraintErrors map[string]string, typeVerified bool, ctx *validateCtx) {
 	errMsg := func(defaultExpected string) string {
 		if perConstraintErrors != nil {
 			if msg, ok := perConstraintErrors["type"]; ok {
@@ -301,6 +307,8 @@ func generateNumericTypeCheck(e *Emitter, accessor string, path string, numType
 		}
 		return defaultExpected
 	}
+	fields := errorFields("type", customMessage(perConstraintErrors, customError, "type"), "type", jsParam(numType))
+	received := ctx.received(accessor, "\"\" + "+accessor)
 	switch numType {
 	case "int32":
 		if typeVerified {
@@ -308,7 +316,7 @@ func generateNumericTypeCheck(e *Emitter, accessor string, path string, numType
 		} else {
 			e.Block("if (typeof %s === \"number\" && (!Number.isInteger(%s) || %s < -2147483648 || %s > 2147483647))", accessor, accessor, accessor, accessor)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"\" + %s });", path, errMsg("int32"), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("int32"), received, fields)
 		e.EndBlock()
 	case "uint32":
 		if typeVerified {
@@ -316,7 +324,7 @@ func generateNumericTypeCheck(e *Emitter, accessor string, path string, numType
 		} else {
 			e.Block("if (typeof %s === \"number\" && (!Number.isInteger(%s) || %s < 0 || %s > 4294967295))", accessor, accessor, accessor, accessor)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"\" + %s });", path, errMsg("uint32"), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("uint32"), received, fields)
 		e.EndBlock()
 	case "int64":
 		if typeVerified {
@@ -324,7 +332,7 @@ func generateNumericTypeCheck(e *Emitter, accessor string, path string, numType
 		} else {
 			e.Block("if (typeof %s === \"number\" && (!Number.isInteger(%s) || %s < -9007199254740991 || %s > 9007199254740991))", accessor, accessor, accessor, accessor)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"\" + %s });", path, errMsg("int64"), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("int64"), received, fields)
 		e.EndBlock()
 	case "uint64":
 		if typeVerified {
@@ -332,7 +340,7 @@ func generateNumericTypeCheck(e *Emitter, accessor string, path string, numType
 		} else {
 			e.Block("if (typeof %s === \"number\" && (!Number.isInteger(%s) || %s < 0 || %s > 9007199254740991))", accessor, accessor, accessor, accessor)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"\" + %s });", path, errMsg("uint64"), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("uint64"), received, fields)
 		e.EndBlock()
 	case "float":
 		if typeVerified {
@@ -340,7 +348,7 @@ func generateNumericTypeCheck(e *Emitter, accessor string, path string, numType
 		} else {
 			e.Block("if (typeof %s === \"number\" && !Number.isFinite(%s))", accessor, accessor)
 		}
-		e.Line("errors.push({ path: %q, expected: \"%s\", received: \"\" + %s });", path, errMsg("float"), accessor)
+		e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg("float"), received, fields)
 		e.EndBlock()
 	case "double":
 		// double always passes — no extra check needed (any finite number is valid)
@@ -349,7 +357,7 @@ func generateNumericTypeCheck(e *Emitter, accessor string, path string, numType
 
 // generateFormatCheck emits validation code for a string format constraint.
 // Checks perConstraintErrors["format"] first, then customError (global), then default.
-func generateFormatCheck(e *Emitter, accessor string, path string, format string, customError *string, perConstraintErrors map[string]string, typeVerified bool) {
+func generateFormatCheck(e *Emitter, accessor string, path string, format string, customError *string, perConstraintErrors map[string]string, typeVerified bool, ctx *validateCtx) {
 	errMsg := func(defaultExpected string) string {
 		if perConstraintErrors != nil {
 			if msg, ok := perConstraintErrors["format"]; ok {
@@ -362,6 +370,9 @@ func generateFormatCheck(e *Emitter, accessor string, path string, format string
 		return defaultExpected
 	}
 
+	fields := errorFields("format", customMessage(perConstraintErrors, customError, "format"), "format", jsParam(format))
+	received := ctx.received(accessor, accessor)
+
 	switch format {
 	case "password":
 		// No validation — any string passes
@@ -369,34 +380,26 @@ func generateFormatCheck(e *Emitter, accessor string, path string, format string
 	case "regex":
 		// Use try/catch to validate regex
 		if typeVerified {
-			e.Line("try { new RegExp(%s); } catch (_e) { errors.push({ path: %q, expected: \"%s\", received: %s }); }", accessor, path, errMsg("format regex"), accessor)
+			e.Line("try { new RegExp(%s); } catch (_e) { errors.push({ path: %q, expected: \"%s\", received: %s%s }); }", accessor, path, errMsg("format regex"), received, fields)
 		} else {
 			e.Block("if (typeof %s === \"string\")", accessor)
-			e.Line("try { new RegExp(%s); } catch (_e) { errors.push({ path: %q, expected: \"%s\", received: %s }); }", accessor, path, errMsg("format regex"), accessor)
+			e.Line("try { new RegExp(%s); } catch (_e) { errors.push({ path: %q, expected: \"%s\", received: %s%s }); }", accessor, path, errMsg("format regex"), received, fields)
 			e.EndBlock()
 		}
 		return
 	}
 
-	// All other formats use regex validation
-	pattern, ok := formatRegexes[format]
-	if !ok || pattern == "" {
+	// All other formats use regex validation (or the helpers constant of a custom format)
+	formatExpr, ok := formatTestExpr(format, ctx.settings())
+	if !ok {
 		return
 	}
 
-	flags := formatFlags[format]
-	var regexLiteral string
-	if flags != "" {
-		regexLiteral = fmt.Sprintf("/%s/%s", pattern, flags)
-	} else {
-		regexLiteral = fmt.Sprintf("/%s/", pattern)
-	}
-
 	if typeVerified {
-		e.Block("if (!%s.test(%s))", regexLiteral, accessor)
+		e.Block("if (!%s.test(%s))", formatExpr, accessor)
 	} else {
-		e.Block("if (typeof %s === \"string\" && !%s.test(%s))", accessor, regexLiteral, accessor)
+		e.Block("if (typeof %s === \"string\" && !%s.test(%s))", accessor, formatExpr, accessor)
 	}
-	e.Line("errors.push({ path: %q, expected: \"%s\", received: %s });", path, errMsg(fmt.Sprintf("format %s", format)), accessor)
+	e.Line("errors.push({ path: %q, expected: \"%s\", received: %s%s });", path, errMsg(fmt.Sprintf("format %s", format)), received, fields)
 	e.EndBlock()
 }
@@ -0,0 +1,199 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// generateEqualsFunctions generates the exact-shape variants of is/validate:
+//
+//	export function equals<Name>(input)                  → boolean, false on any undeclared property
+//	export function validateEquals<Name>(input, options) → { success, data, errors } with one
+//	                                                       error per unexpected property path
+//
+// Unknown properties are rejected on every object in the type, whatever its
+// Metadata.Strictness; objects with an index signature accept any key.
+func generateEqualsFunctions(e *Emitter, typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry, markers map[string]bool, s *Settings) {
+	keys := newKeySets("_ek_" + typeName + "_")
+	body := NewEmitter()
+	if markers["equals"] {
+		ctx := &validateCtx{generating: map[string]bool{typeName: true}, exact: true, cfg: s, keys: keys}
+		generateIsFunction(body, typeName, meta, registry, ctx)
+	}
+	if markers["validateEquals"] {
+		ctx := &validateCtx{generating: map[string]bool{typeName: true}, exact: true, cfg: s, keys: keys}
+		generateValidateFunction(body, typeName, meta, registry, ctx)
+	}
+	keys.emit(e)
+	e.Raw(body.String())
+}
+
+// keySets collects the known-key sets of generated key loops, declared once as
+// module-level constants (const <prefix><n> = new Set([...])) rather than
+// allocated for every key.
+type keySets struct {
+	prefix string
+	names  map[string]string // quoted key list → constant name
+	decls  []string
+}
+
+func newKeySets(prefix string) *keySets {
+	return &keySets{prefix: prefix, names: make(map[string]string)}
+}
+
+// ref returns the name of the constant holding the set of keys.
+func (k *keySets) ref(keys []string) string {
+	list := joinQuoted(keys)
+	if name, ok := k.names[list]; ok {
+		return name
+	}
+	name := fmt.Sprintf("%s%d", k.prefix, len(k.decls))
+	k.names[list] = name
+	k.decls = append(k.decls, fmt.Sprintf("const %s = new Set([%s]);", name, list))
+	return name
+}
+
+// emit declares the collected sets.
+func (k *keySets) emit(e *Emitter) {
+	for _, decl := range k.decls {
+		e.Line("%s", decl)
+	}
+	if len(k.decls) > 0 {
+		e.Blank()
+	}
+}
+
+// fnName returns the name of a generated validator function for typeName,
+// mapping "is"/"_is"/"validate"/"_validate" to their exact variants in exact mode,
+// and "assert"/"_assert" to their request variants in request mode.
+func (ctx *validateCtx) fnName(prefix, typeName string) string {
+	if ctx != nil && ctx.exact {
+		switch prefix {
+		case "is":
+			prefix = "equals"
+		case "_is":
+			prefix = "_equals"
+		case "validate":
+			prefix = "validateEquals"
+		case "_validate":
+			prefix = "_validateEquals"
+		}
+	}
+	if ctx != nil && ctx.request {
+		switch prefix {
+		case "assert":
+			prefix = "assertRequest"
+		case "_assert":
+			prefix = "_assertRequest"
+		}
+	}
+	return prefix + typeName
+}
+
+// rejectsUnknownKeys reports whether the unknown-key check should be emitted
+// for an object type. It consumes skipKeys, so only the object directly under
+// an intersection member is affected.
+func (ctx *validateCtx) rejectsUnknownKeys(meta *metadata.Metadata) bool {
+	if ctx == nil || !ctx.exact {
+		return false
+	}
+	skip := ctx.skipKeys
+	ctx.skipKeys = false
+	return !skip && meta.IndexSignature == nil
+}
+
+// generateUnknownKeysCheck pushes an error for every own key of the object at
+// accessor that is not a declared property. pathExpr is a JS string expression.
+// The declared keys are looked up in a set of ctx.keys.
+func generateUnknownKeysCheck(e *Emitter, accessor string, pathExpr string, props []metadata.Property, depth int, ctx *validateCtx) {
+	knownKeys := make([]string, 0, len(props))
+	for _, prop := range props {
+		knownKeys = append(knownKeys, prop.Name)
+	}
+	kVar := fmt.Sprintf("_k%d", depth)
+	e.Block("for (const %s of Object.keys(%s))", kVar, accessor)
+	emitErrorLimitGuard(e)
+	e.Block("if (!%s.has(%s))", ctx.keys.ref(knownKeys), kVar)
+	e.Line("errors.push({ path: %s + \".\" + %s, expected: \"known property\", received: %s%s });", pathExpr, kVar, kVar, errorFields("unknownProperty", ""))
+	e.EndBlock()
+	e.EndBlock()
+}
+
+// intersectionKeys resolves the unknown-key check of an intersection in exact
+// mode, against the properties of all members combined (A & B accepts the keys
+// of both). exact reports whether exact mode is active, in which case the
+// members must be generated with skipKeys set; check reports whether the
+// combined check should be emitted. A nested intersection is covered by the
+// check of the outer one.
+func (ctx *validateCtx) intersectionKeys(meta *metadata.Metadata, registry *metadata.TypeRegistry) (props []metadata.Property, check bool, exact bool) {
+	if ctx == nil || !ctx.exact {
+		return nil, false, false
+	}
+	nested := ctx.skipKeys
+	ctx.skipKeys = false
+	if nested {
+		return nil, false, true
+	}
+	props, check = intersectionProperties(meta, registry)
+	return props, check, true
+}
+
+// generateIntersectionKeysCheck emits the combined unknown-key check of an
+// intersection (see intersectionKeys) and reports whether exact mode is active.
+func generateIntersectionKeysCheck(e *Emitter, accessor string, pathExpr string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *validateCtx) bool {
+	props, check, exact := ctx.intersectionKeys(meta, registry)
+	if check {
+		e.Block("if (typeof %s === \"object\" && %s !== null)", accessor, accessor)
+		generateUnknownKeysCheck(e, accessor, pathExpr, props, depth, ctx)
+		e.EndBlock()
+	}
+	return exact
+}
+
+// isKnownKeysExpr returns a JS boolean expression that is true when the object
+// at accessor has no own keys besides the declared properties.
+func isKnownKeysExpr(accessor string, props []metadata.Property) string {
+	if len(props) == 0 {
+		return fmt.Sprintf("Object.keys(%s).length === 0", accessor)
+	}
+	checks := make([]string, len(props))
+	for i, prop := range props {
+		checks[i] = fmt.Sprintf("_k === %q", prop.Name)
+	}
+	return fmt.Sprintf("Object.keys(%s).every(_k => %s)", accessor, strings.Join(checks, " || "))
+}
+
+// intersectionProperties collects the declared properties of all intersection
+// members. Returns false when a member is not an object (or a reference to one)
+// or has an index signature, in which case the key set is open.
+func intersectionProperties(meta *metadata.Metadata, registry *metadata.TypeRegistry) ([]metadata.Property, bool) {
+	var props []metadata.Property
+	for i := range meta.IntersectionMembers {
+		member := &meta.IntersectionMembers[i]
+		if member.Kind == metadata.KindRef {
+			resolved, ok := registry.Types[member.Ref]
+			if !ok {
+				return nil, false
+			}
+			member = resolved
+		}
+		switch member.Kind {
+		case metadata.KindObject:
+			if member.IndexSignature != nil {
+				return nil, false
+			}
+			props = append(props, member.Properties...)
+		case metadata.KindIntersection:
+			nested, ok := intersectionProperties(member, registry)
+			if !ok {
+				return nil, false
+			}
+			props = append(props, nested...)
+		default:
+			return nil, false
+		}
+	}
+	return props, true
+}
@@ -11,7 +11,20 @@ import (
 // generateIsFunction generates a pure boolean type-check function with zero allocations.
 // Returns a single expression composed with && chains.
 func generateIsFunction(e *Emitter, typeName string, meta *metadata.Metadata, registry *metadata.TypeRegistry, ctx *validateCtx) {
-	fnName := "is" + typeName
+	fnName := ctx.fnName("is", typeName)
+	if depthLimited(typeName, meta, registry, ctx.settings()) {
+		// Recursive calls go through an inner function taking the depth
+		innerFn := ctx.fnName("_is", typeName)
+		ctx.depthParam = true
+		e.Block("function %s(input, _d)", innerFn)
+		e.Line("return _d <= %d && %s;", ctx.settings().Limits.MaxDepth, generateIsExpr("input", meta, registry, 0, ctx))
+		e.EndBlock()
+		ctx.depthParam = false
+		e.Block("export function %s(input)", fnName)
+		e.Line("return %s(input, 1);", innerFn)
+		e.EndBlock()
+		return
+	}
 	e.Block("export function %s(input)", fnName)
 	expr := generateIsExpr("input", meta, registry, 0, ctx)
 	e.Line("return %s;", expr)
@@ -48,7 +61,13 @@ func generateIsExpr(accessor string, meta *metadata.Metadata, registry *metadata
 func generateIsExprInner(accessor string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *validateCtx) string {
 	switch meta.Kind {
 	case metadata.KindAtomic:
-		return generateIsAtomicExpr(accessor, meta)
+		expr := generateIsAtomicExpr(accessor, meta)
+		if meta.Atomic == "string" {
+			if max := ctx.maxStringLength(meta); max > 0 {
+				expr += fmt.Sprintf(" && %s.length <= %d", accessor, max)
+			}
+		}
+		return expr
 
 	case metadata.KindLiteral:
 		return fmt.Sprintf("%s === %s", accessor, jsLiteral(meta.LiteralValue))
@@ -80,7 +99,10 @@ func generateIsExprInner(accessor string, meta *metadata.Metadata, registry *met
 	case metadata.KindRef:
 		if ctx != nil && ctx.generating[meta.Ref] {
 			// Recursive ref — call is function
-			return fmt.Sprintf("is%s(%s)", meta.Ref, accessor)
+			if ctx.depthParam {
+				return fmt.Sprintf("%s(%s, _d + 1)", ctx.fnName("_is", meta.Ref), accessor)
+			}
+			return fmt.Sprintf("%s(%s)", ctx.fnName("is", meta.Ref), accessor)
 		}
 		if resolved, ok := registry.Types[meta.Ref]; ok {
 			if ctx != nil {
@@ -109,7 +131,7 @@ func generateIsExprInner(accessor string, meta *metadata.Metadata, registry *met
 		case "Date":
 			return fmt.Sprintf("(%s instanceof Date && !isNaN(%s.getTime()))", accessor, accessor)
 		default:
-			return fmt.Sprintf("(%s instanceof %s)", accessor, meta.NativeType)
+			return "(" + nativeInstanceCheck(accessor, meta.NativeType) + ")"
 		}
 
 	case metadata.KindAny, metadata.KindUnknown:
@@ -122,10 +144,20 @@ func generateIsExprInner(accessor string, meta *metadata.Metadata, registry *met
 		return fmt.Sprintf("%s === undefined", accessor)
 
 	case metadata.KindIntersection:
+		props, check, exact := ctx.intersectionKeys(meta, registry)
 		parts := make([]string, len(meta.IntersectionMembers))
 		for i, member := range meta.IntersectionMembers {
 			memberCopy := member
+			if exact {
+				ctx.skipKeys = true
+			}
 			parts[i] = generateIsExprInner(accessor, &memberCopy, registry, depth+1, ctx)
+			if exact {
+				ctx.skipKeys = false
+			}
+		}
+		if check {
+			parts = append(parts, fmt.Sprintf("(typeof %s !== \"object\" || %s === null || %s)", accessor, accessor, isKnownKeysExpr(accessor, props)))
 		}
 		if len(parts) == 0 {
 			return "true"
@@ -159,14 +191,17 @@ func generateIsAtomicExpr(accessor string, meta *metadata.Metadata) string {
 
 func generateIsObjectExpr(accessor string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *validateCtx) string {
 	parts := []string{fmt.Sprintf("typeof %s === \"object\" && %s !== null", accessor, accessor)}
+	if ctx.rejectsUnknownKeys(meta) {
+		parts = append(parts, isKnownKeysExpr(accessor, meta.Properties))
+	}
 	for _, prop := range meta.Properties {
 		propAccessor := jsPropAccess(accessor, prop.Name)
 		if prop.Required && !prop.Type.Optional {
 			parts = append(parts, fmt.Sprintf("%s !== undefined", propAccessor))
-			parts = append(parts, generateIsExpr(propAccessor, &prop.Type, registry, depth+1, ctx))
+			parts = append(parts, generateIsExpr(propAccessor, boundedType(&prop.Type, prop.Constraints), registry, depth+1, ctx))
 		} else {
 			// Optional — only validate if present
-			innerExpr := generateIsExpr(propAccessor, &prop.Type, registry, depth+1, ctx)
+			innerExpr := generateIsExpr(propAccessor, boundedType(&prop.Type, prop.Constraints), registry, depth+1, ctx)
 			if prop.ExactOptional {
 				parts = append(parts, fmt.Sprintf("(!(%q in %s) || %s)", prop.Name, accessor, innerExpr))
 			} else {
@@ -175,15 +210,19 @@ func generateIsObjectExpr(accessor string, meta *metadata.Metadata, registry *me
 		}
 		// Inline constraint checks for is()
 		if prop.Constraints != nil {
-			constraintExprs := generateIsConstraintExprs(propAccessor, &prop)
+			constraintExprs := generateIsConstraintExprs(propAccessor, &prop, ctx)
 			parts = append(parts, constraintExprs...)
 		}
 	}
+	if meta.IndexSignature != nil && ctx.settings().Limits.MaxProperties > 0 {
+		parts = append(parts, fmt.Sprintf("Object.keys(%s).length <= %d", accessor, ctx.settings().Limits.MaxProperties))
+	}
+	parts = append(parts, generateIsObjectRuleExprs(accessor, meta.Rules)...)
 	return "(" + strings.Join(parts, " && ") + ")"
 }
 
 // generateIsConstraintExprs returns JS boolean expressions for constraints.
-func generateIsConstraintExprs(accessor string, prop *metadata.Property) []string {
+func generateIsConstraintExprs(accessor string, prop *metadata.Property, ctx *validateCtx) []string {
 	c := prop.Constraints
 	if c == nil {
 		return nil
@@ -208,20 +247,18 @@ func generateIsConstraintExprs(accessor string, prop *metadata.Property) []strin
 	if c.MaxLength != nil {
 		exprs = append(exprs, fmt.Sprintf("(typeof %s !== \"string\" || %s.length <= %d)", accessor, accessor, *c.MaxLength))
 	}
+	if c.MinItems != nil {
+		exprs = append(exprs, fmt.Sprintf("(!Array.isArray(%s) || %s.length >= %d)", accessor, accessor, *c.MinItems))
+	}
+	if c.MaxItems != nil {
+		exprs = append(exprs, fmt.Sprintf("(!Array.isArray(%s) || %s.length <= %d)", accessor, accessor, *c.MaxItems))
+	}
 	if c.Pattern != nil {
 		exprs = append(exprs, fmt.Sprintf("(typeof %s !== \"string\" || /%s/.test(%s))", accessor, escapeForRegexLiteral(*c.Pattern), accessor))
 	}
 	if c.Format != nil {
-		pattern, ok := formatRegexes[*c.Format]
-		if ok && pattern != "" {
-			flags := formatFlags[*c.Format]
-			var regexLiteral string
-			if flags != "" {
-				regexLiteral = fmt.Sprintf("/%s/%s", pattern, flags)
-			} else {
-				regexLiteral = fmt.Sprintf("/%s/", pattern)
-			}
-			exprs = append(exprs, fmt.Sprintf("(typeof %s !== \"string\" || %s.test(%s))", accessor, regexLiteral, accessor))
+		if formatExpr, ok := formatTestExpr(*c.Format, ctx.settings()); ok {
+			exprs = append(exprs, fmt.Sprintf("(typeof %s !== \"string\" || %s.test(%s))", accessor, formatExpr, accessor))
 		}
 	}
 
@@ -229,12 +266,19 @@ func generateIsConstraintExprs(accessor string, prop *metadata.Property) []strin
 }
 
 func generateIsArrayExpr(accessor string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *validateCtx) string {
+	lengthExpr := ""
+	if max := ctx.maxArrayLength(meta); max > 0 {
+		lengthExpr = fmt.Sprintf(" && %s.length <= %d", accessor, max)
+	}
 	if meta.ElementType == nil {
+		if lengthExpr != "" {
+			return fmt.Sprintf("(Array.isArray(%s)%s)", accessor, lengthExpr)
+		}
 		return fmt.Sprintf("Array.isArray(%s)", accessor)
 	}
 	elemVar := fmt.Sprintf("_v%d", depth)
 	elemExpr := generateIsExpr(elemVar, meta.ElementType, registry, depth+1, ctx)
-	return fmt.Sprintf("(Array.isArray(%s) && %s.every(%s => %s))", accessor, accessor, elemVar, elemExpr)
+	return fmt.Sprintf("(Array.isArray(%s)%s && %s.every(%s => %s))", accessor, lengthExpr, accessor, elemVar, elemExpr)
 }
 
 func generateIsUnionExpr(accessor string, meta *metadata.Metadata, registry *metadata.TypeRegistry, depth int, ctx *validateCtx) string {
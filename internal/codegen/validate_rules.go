@@ -0,0 +1,159 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// Cross-field rules (metadata.ObjectRules) run after the property checks of an
+// object: RequireOneOf groups, DependentRequired keys, then the object-level
+// Validate<typeof fn> predicate, which is only called when the properties of the
+// object produced no error (it may rely on their types).
+
+// requireOneOfCountExpr returns a JS expression counting the present keys of a group.
+func requireOneOfCountExpr(accessor string, keys []string) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("(%s !== undefined)", jsPropAccess(accessor, k))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// requireOneOfExpected returns the expected message of a RequireOneOf group.
+func requireOneOfExpected(group metadata.KeyGroup) string {
+	if group.Error != "" {
+		return jsStringEscape(group.Error)
+	}
+	return jsStringEscape("exactly one of " + strings.Join(group.Keys, ", "))
+}
+
+// requireOneOfFields returns the error code fields of a RequireOneOf group.
+func requireOneOfFields(group metadata.KeyGroup) string {
+	var custom string
+	if group.Error != "" {
+		custom = jsStringEscape(group.Error)
+	}
+	return errorFields("requireOneOf", custom, "keys", jsParam(strings.Join(group.Keys, ", ")))
+}
+
+// dependentRequiredFields returns the error code fields of a DependentRequired
+// dependency dep of key.
+func dependentRequiredFields(key string, dep string) string {
+	return errorFields("dependentRequired", "", "property", jsParam(dep), "with", jsParam(key))
+}
+
+// dependentRequiredKeys returns the DependentRequired trigger keys, sorted.
+func dependentRequiredKeys(rules *metadata.ObjectRules) []string {
+	keys := make([]string, 0, len(rules.DependentRequired))
+	for k := range rules.DependentRequired {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateRuleExpected returns the expected message of the object-level predicate.
+func validateRuleExpected(rules *metadata.ObjectRules) string {
+	if rules.ValidateError != "" {
+		return jsStringEscape(rules.ValidateError)
+	}
+	return fmt.Sprintf("validate(%s)", rules.ValidateFn)
+}
+
+// validateRuleFields returns the error code fields of the object-level predicate.
+func validateRuleFields(rules *metadata.ObjectRules) string {
+	var custom string
+	if rules.ValidateError != "" {
+		custom = jsStringEscape(rules.ValidateError)
+	}
+	return errorFields("validate", custom, "fn", jsParam(rules.ValidateFn))
+}
+
+// emitRulesErrorMark records the error count before the property checks of an
+// object with an object-level predicate. Pairs with generateObjectRuleChecks.
+func emitRulesErrorMark(e *Emitter, meta *metadata.Metadata, depth int) {
+	if meta.Rules != nil && meta.Rules.ValidateFn != "" {
+		e.Line("const _ne%d = errors.length;", depth)
+	}
+}
+
+// generateObjectRuleChecks emits validate-style (errors.push) checks of the
+// object rules. pathExpr is a JS expression evaluating to the object path.
+func generateObjectRuleChecks(e *Emitter, accessor string, pathExpr string, rules *metadata.ObjectRules, depth int) {
+	if rules == nil {
+		return
+	}
+	for i, group := range rules.RequireOneOf {
+		nVar := fmt.Sprintf("_n%d_%d", depth, i)
+		e.Line("const %s = %s;", nVar, requireOneOfCountExpr(accessor, group.Keys))
+		e.Block("if (%s !== 1)", nVar)
+		e.Line("errors.push({ path: %s, expected: \"%s\", received: %s + \" present\"%s });", pathExpr, requireOneOfExpected(group), nVar, requireOneOfFields(group))
+		e.EndBlock()
+	}
+	for _, key := range dependentRequiredKeys(rules) {
+		e.Block("if (%s !== undefined)", jsPropAccess(accessor, key))
+		for _, dep := range rules.DependentRequired[key] {
+			e.Block("if (%s === undefined)", jsPropAccess(accessor, dep))
+			e.Line("errors.push({ path: %s + %q, expected: \"%s\", received: \"undefined\"%s });", pathExpr, jsPropPathSuffix(dep), jsStringEscape(dep+" (required with "+key+")"), dependentRequiredFields(key, dep))
+			e.EndBlock()
+		}
+		e.EndBlock()
+	}
+	if rules.ValidateFn != "" {
+		e.Block("if (errors.length === _ne%d && !%s(%s))", depth, rules.ValidateFn, accessor)
+		e.Line("errors.push({ path: %s, expected: \"%s\", received: \"object\"%s });", pathExpr, validateRuleExpected(rules), validateRuleFields(rules))
+		e.EndBlock()
+	}
+}
+
+// generateAssertObjectRuleChecks emits assert-style (throw) checks of the object
+// rules. The property checks have all passed when they run.
+func generateAssertObjectRuleChecks(e *Emitter, accessor string, pathExpr string, rules *metadata.ObjectRules) {
+	if rules == nil {
+		return
+	}
+	for _, group := range rules.RequireOneOf {
+		countExpr := requireOneOfCountExpr(accessor, group.Keys)
+		e.Block("if (%s !== 1)", countExpr)
+		emitAssertThrowFields(e, pathExpr, requireOneOfExpected(group), fmt.Sprintf("(%s) + \" present\"", countExpr), requireOneOfFields(group))
+		e.EndBlock()
+	}
+	for _, key := range dependentRequiredKeys(rules) {
+		for _, dep := range rules.DependentRequired[key] {
+			e.Block("if (%s !== undefined && %s === undefined)", jsPropAccess(accessor, key), jsPropAccess(accessor, dep))
+			emitAssertThrowFields(e, fmt.Sprintf("%s + %q", pathExpr, jsPropPathSuffix(dep)), jsStringEscape(dep+" (required with "+key+")"), "\"undefined\"", dependentRequiredFields(key, dep))
+			e.EndBlock()
+		}
+	}
+	if rules.ValidateFn != "" {
+		e.Block("if (!%s(%s))", rules.ValidateFn, accessor)
+		emitAssertThrowFields(e, pathExpr, validateRuleExpected(rules), "\"object\"", validateRuleFields(rules))
+		e.EndBlock()
+	}
+}
+
+// generateIsObjectRuleExprs returns JS boolean expressions for the object rules,
+// to be appended after the property checks of is().
+func generateIsObjectRuleExprs(accessor string, rules *metadata.ObjectRules) []string {
+	if rules == nil {
+		return nil
+	}
+	var exprs []string
+	for _, group := range rules.RequireOneOf {
+		exprs = append(exprs, fmt.Sprintf("(%s) === 1", requireOneOfCountExpr(accessor, group.Keys)))
+	}
+	for _, key := range dependentRequiredKeys(rules) {
+		deps := make([]string, len(rules.DependentRequired[key]))
+		for i, dep := range rules.DependentRequired[key] {
+			deps[i] = fmt.Sprintf("%s !== undefined", jsPropAccess(accessor, dep))
+		}
+		exprs = append(exprs, fmt.Sprintf("(%s === undefined || %s)", jsPropAccess(accessor, key), strings.Join(deps, " && ")))
+	}
+	if rules.ValidateFn != "" {
+		exprs = append(exprs, fmt.Sprintf("%s(%s)", rules.ValidateFn, accessor))
+	}
+	return exprs
+}
@@ -17,6 +17,14 @@ func unionValidVar(depth int) string {
 	return fmt.Sprintf("_uv%d", depth)
 }
 
+func unionBestVar(depth int) string {
+	return fmt.Sprintf("_ub%d", depth)
+}
+
+func unionScoreVar(depth int) string {
+	return fmt.Sprintf("_us%d", depth)
+}
+
 func jsLiteral(v any) string {
 	switch val := v.(type) {
 	case string:
@@ -33,6 +41,17 @@ func jsLiteral(v any) string {
 	}
 }
 
+// nativeInstanceCheck returns the JavaScript condition that accessor holds an
+// instance of a native type. NestJS StreamableFile and stream.Readable are not
+// globals the companion can reference, so they are only checked to be objects.
+func nativeInstanceCheck(accessor, nativeType string) string {
+	switch nativeType {
+	case "StreamableFile", "Readable":
+		return fmt.Sprintf("(typeof %s === \"object\" && %s !== null)", accessor, accessor)
+	}
+	return fmt.Sprintf("%s instanceof %s", accessor, nativeType)
+}
+
 // joinQuoted joins strings as JavaScript quoted values: "a", "b", "c"
 func joinQuoted(keys []string) string {
 	parts := make([]string, len(keys))
@@ -42,14 +61,14 @@ func joinQuoted(keys []string) string {
 	return strings.Join(parts, ", ")
 }
 
-// defaultToJSLiteral converts a @default string value to an appropriate JS literal.
+// DefaultToJSLiteral converts a @default string value to an appropriate JS literal.
 // The type hint is used to determine parsing:
 //   - string type: wraps in quotes ("hello")
 //   - number type: outputs as-is (42, 3.14)
 //   - boolean type: outputs true/false
 //   - "null" string: outputs null
 //   - otherwise: wraps in quotes as fallback
-func defaultToJSLiteral(raw string, propType *metadata.Metadata) string {
+func DefaultToJSLiteral(raw string, propType *metadata.Metadata) string {
 	// Strip surrounding quotes from the raw value if present
 	raw = stripDefaultQuotes(raw)
 
@@ -81,6 +100,72 @@ func defaultToJSLiteral(raw string, propType *metadata.Metadata) string {
 	return fmt.Sprintf("\"%s\"", jsStringEscape(raw))
 }
 
+// emitDefaultAssignment fills the absent property key of the object at
+// accessor with its Default<V> / @default value, so the checks that follow see
+// the default. Within a union member trial, the filled property is logged to
+// be removed if the member is rejected (see emitDefaultLog).
+func emitDefaultAssignment(e *Emitter, accessor string, key string, prop *metadata.Property, ctx *validateCtx) {
+	if prop.Constraints == nil || prop.Constraints.Default == nil {
+		return
+	}
+	propAccessor := jsPropAccess(accessor, key)
+	e.Block("if (%s === undefined)", propAccessor)
+	e.Line("%s = %s;", propAccessor, DefaultToJSLiteral(*prop.Constraints.Default, &prop.Type))
+	if ctx != nil && ctx.defaultLog != "" {
+		e.Line("%s.push([%s, %q]);", ctx.defaultLog, accessor, key)
+	}
+	e.EndBlock()
+}
+
+// hasDefaults reports whether meta contains properties with a default value.
+func hasDefaults(meta *metadata.Metadata, registry *metadata.TypeRegistry) bool {
+	return hasProperty(meta, registry, func(p *metadata.Property) bool {
+		return p.Constraints != nil && p.Constraints.Default != nil
+	}, make(map[string]bool))
+}
+
+// emitDefaultLog opens the default log of a union member trial: defaults
+// filled by the member are removed again when it is rejected, so that they
+// neither end up on the value nor count for the members tried next. It
+// returns the log of the enclosing trial, restored by closeDefaultLog.
+// undoDefaults removes the logged defaults of a rejected member.
+func emitDefaultLog(e *Emitter, depth int, ctx *validateCtx) string {
+	outer := ctx.defaultLog
+	ctx.defaultLog = fmt.Sprintf("_dl%d", depth)
+	e.Line("const %s = [];", ctx.defaultLog)
+	return outer
+}
+
+// undoDefaults emits the removal of the defaults logged in log.
+func undoDefaults(e *Emitter, log string) {
+	e.Line("for (const [_o, _k] of %s) delete _o[_k];", log)
+}
+
+// closeDefaultLog emits, for an accepted member, the transfer of its logged
+// defaults to the log of the enclosing trial, then restores that log.
+func closeDefaultLog(e *Emitter, ctx *validateCtx, outer string) {
+	if outer != "" {
+		e.Line("%s.push(...%s);", outer, ctx.defaultLog)
+	}
+	ctx.defaultLog = outer
+}
+
+// defaultedProperties returns the names of the optional properties of an object
+// type that validation fills with a default value, i.e. the properties that are
+// always present on validated data.
+func defaultedProperties(meta *metadata.Metadata) []string {
+	if meta == nil || meta.Kind != metadata.KindObject {
+		return nil
+	}
+	var names []string
+	for _, prop := range meta.Properties {
+		if prop.Constraints != nil && prop.Constraints.Default != nil && (!prop.Required || prop.Type.Optional) {
+			names = append(names, prop.Name)
+		}
+	}
+	return names
+}
+
 // stripDefaultQuotes removes surrounding double or single quotes from a @default value.
 func stripDefaultQuotes(s string) string {
 	if len(s) >= 2 {
@@ -368,3 +453,49 @@ func generateStandardSchemaWrapper(e *Emitter, typeName string) {
 	e.Dedent()
 	e.Line("};")
 }
+
+// hasProperty reports whether meta contains a property matching pred, on
+// objects at any depth, including referenced types.
+func hasProperty(meta *metadata.Metadata, registry *metadata.TypeRegistry, pred func(*metadata.Property) bool, visited map[string]bool) bool {
+	if meta == nil {
+		return false
+	}
+	switch meta.Kind {
+	case metadata.KindObject:
+		for i := range meta.Properties {
+			if pred(&meta.Properties[i]) || hasProperty(&meta.Properties[i].Type, registry, pred, visited) {
+				return true
+			}
+		}
+		return meta.IndexSignature != nil && hasProperty(&meta.IndexSignature.ValueType, registry, pred, visited)
+	case metadata.KindArray:
+		return hasProperty(meta.ElementType, registry, pred, visited)
+	case metadata.KindTuple:
+		for i := range meta.Elements {
+			if hasProperty(&meta.Elements[i].Type, registry, pred, visited) {
+				return true
+			}
+		}
+	case metadata.KindUnion:
+		for i := range meta.UnionMembers {
+			if hasProperty(&meta.UnionMembers[i], registry, pred, visited) {
+				return true
+			}
+		}
+	case metadata.KindIntersection:
+		for i := range meta.IntersectionMembers {
+			if hasProperty(&meta.IntersectionMembers[i], registry, pred, visited) {
+				return true
+			}
+		}
+	case metadata.KindRef:
+		if visited[meta.Ref] || registry == nil {
+			return false
+		}
+		visited[meta.Ref] = true
+		if resolved, ok := registry.Types[meta.Ref]; ok {
+			return hasProperty(resolved, registry, pred, visited)
+		}
+	}
+	return false
+}
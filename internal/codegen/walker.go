@@ -0,0 +1,302 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tsgonest/tsgonest/internal/metadata"
+)
+
+// walkOp is an operation a walker applies in place to every position of a
+// value (prune, hydrate, JSON revival, async checks). The walker descends the
+// containers; the operation only supplies the code at the positions it acts
+// on. Embed walkDefaults for the hooks an operation does not use.
+type walkOp interface {
+	// funcName returns the local function name of a named type.
+	funcName(typeName string) string
+	// applies reports whether the operation acts on meta itself. Values that
+	// contain no such position are skipped.
+	applies(meta *metadata.Metadata) bool
+	// call emits the call of fn, the local function of a named type, on the
+	// value at accessor.
+	call(e *Emitter, fn string, accessor string, pathExpr string)
+	// params returns the parameters of the local functions after v.
+	params() string
+	// returns reports whether the local functions return v.
+	returns() bool
+	// pathKey returns the path segment of a property.
+	pathKey(prop *metadata.Property) string
+	// leaf emits the operation on a value that is not a container.
+	leaf(e *Emitter, accessor string, meta *metadata.Metadata, depth int)
+	// enter and exit emit the operation on an object whose declared
+	// properties are props, before and after its values are walked.
+	enter(e *Emitter, accessor string, meta *metadata.Metadata, props []metadata.Property, depth int)
+	exit(e *Emitter, accessor string, meta *metadata.Metadata)
+	// property emits the operation on a declared property, before its value is walked.
+	property(e *Emitter, accessor string, pathExpr string, prop *metadata.Property)
+	// unionMembers returns the members walked of a union without discriminant.
+	unionMembers(meta *metadata.Metadata) []*metadata.Metadata
+	// intersectionMembers returns the members walked of an intersection
+	// whose properties cannot be merged (see intersectionProperties).
+	intersectionMembers(meta *metadata.Metadata) []*metadata.Metadata
+}
+
+// walkDefaults implements the optional hooks of walkOp.
+type walkDefaults struct{}
+
+func (walkDefaults) params() string { return "" }
+
+func (walkDefaults) returns() bool { return false }
+
+func (walkDefaults) pathKey(prop *metadata.Property) string { return prop.Name }
+
+func (walkDefaults) leaf(e *Emitter, accessor string, meta *metadata.Metadata, depth int) {}
+
+func (walkDefaults) enter(e *Emitter, accessor string, meta *metadata.Metadata, props []metadata.Property, depth int) {
+}
+
+func (walkDefaults) exit(e *Emitter, accessor string, meta *metadata.Metadata) {}
+
+func (walkDefaults) property(e *Emitter, accessor string, pathExpr string, prop *metadata.Property) {
+}
+
+// unionMembers returns the single object-like member of the union, if any:
+// without a discriminant, only such a member can be told apart from the
+// others (by the typeof/Array.isArray guards).
+func (walkDefaults) unionMembers(meta *metadata.Metadata) []*metadata.Metadata {
+	var target *metadata.Metadata
+	for i := range meta.UnionMembers {
+		m := &meta.UnionMembers[i]
+		switch m.Kind {
+		case metadata.KindAtomic, metadata.KindLiteral, metadata.KindEnum:
+			continue
+		}
+		if target != nil {
+			return nil
+		}
+		target = m
+	}
+	if target == nil {
+		return nil
+	}
+	return []*metadata.Metadata{target}
+}
+
+func (walkDefaults) intersectionMembers(meta *metadata.Metadata) []*metadata.Metadata {
+	members := make([]*metadata.Metadata, len(meta.IntersectionMembers))
+	for i := range meta.IntersectionMembers {
+		members[i] = &meta.IntersectionMembers[i]
+	}
+	return members
+}
+
+// walker emits one local function per named type for a walkOp, so recursive
+// types are walked through their own function.
+type walker struct {
+	registry *metadata.TypeRegistry
+	op       walkOp
+	keys     *keySets // declared keys, skipped by index signature loops
+	order    []string
+	bodies   map[string]*Emitter
+}
+
+func newWalker(registry *metadata.TypeRegistry, op walkOp, keys *keySets) *walker {
+	return &walker{registry: registry, op: op, keys: keys, bodies: make(map[string]*Emitter)}
+}
+
+// needs reports whether meta contains a position the operation acts on.
+func (w *walker) needs(meta *metadata.Metadata) bool {
+	return w.contains(meta, make(map[string]bool))
+}
+
+func (w *walker) contains(meta *metadata.Metadata, visited map[string]bool) bool {
+	if meta == nil {
+		return false
+	}
+	if w.op.applies(meta) {
+		return true
+	}
+	switch meta.Kind {
+	case metadata.KindObject:
+		for i := range meta.Properties {
+			if w.contains(&meta.Properties[i].Type, visited) {
+				return true
+			}
+		}
+		return meta.IndexSignature != nil && w.contains(&meta.IndexSignature.ValueType, visited)
+	case metadata.KindArray:
+		return w.contains(meta.ElementType, visited)
+	case metadata.KindTuple:
+		for i := range meta.Elements {
+			if w.contains(&meta.Elements[i].Type, visited) {
+				return true
+			}
+		}
+	case metadata.KindUnion:
+		for i := range meta.UnionMembers {
+			if w.contains(&meta.UnionMembers[i], visited) {
+				return true
+			}
+		}
+	case metadata.KindIntersection:
+		for i := range meta.IntersectionMembers {
+			if w.contains(&meta.IntersectionMembers[i], visited) {
+				return true
+			}
+		}
+	case metadata.KindRef:
+		if visited[meta.Ref] || w.registry == nil {
+			return false
+		}
+		visited[meta.Ref] = true
+		if resolved, ok := w.registry.Types[meta.Ref]; ok {
+			return w.contains(resolved, visited)
+		}
+	}
+	return false
+}
+
+// define emits the local function of a named type once.
+func (w *walker) define(name string, meta *metadata.Metadata) {
+	if _, ok := w.bodies[name]; ok {
+		return
+	}
+	body := NewEmitter()
+	body.indent = 1
+	w.bodies[name] = body
+	w.order = append(w.order, name)
+	w.walk(body, "v", "_path", meta, 0)
+}
+
+// emit emits the key sets and the local functions defined in w.
+func (w *walker) emit(e *Emitter) {
+	w.keys.emit(e)
+	for _, name := range w.order {
+		e.Block("function %s(v%s)", w.op.funcName(name), w.op.params())
+		e.Raw(w.bodies[name].String())
+		if w.op.returns() {
+			e.Line("return v;")
+		}
+		e.EndBlock()
+	}
+}
+
+// walk emits the operation on the value at accessor, whose path is the JS
+// expression pathExpr. Every step is guarded by a shape check, so values of
+// the wrong shape are left untouched.
+func (w *walker) walk(e *Emitter, accessor string, pathExpr string, meta *metadata.Metadata, depth int) {
+	if !w.needs(meta) {
+		return
+	}
+	switch meta.Kind {
+	case metadata.KindObject:
+		w.object(e, accessor, pathExpr, meta, meta.Properties, depth)
+	case metadata.KindArray:
+		iVar := fmt.Sprintf("_i%d", depth)
+		e.Block("if (Array.isArray(%s))", accessor)
+		e.Block("for (let %s = 0; %s < %s.length; %s++)", iVar, iVar, accessor, iVar)
+		w.walk(e, fmt.Sprintf("%s[%s]", accessor, iVar), fmt.Sprintf("%s + \"[\" + %s + \"]\"", pathExpr, iVar), meta.ElementType, depth+1)
+		e.EndBlock()
+		e.EndBlock()
+	case metadata.KindTuple:
+		e.Block("if (Array.isArray(%s))", accessor)
+		for i := range meta.Elements {
+			if meta.Elements[i].Rest {
+				continue
+			}
+			w.walk(e, fmt.Sprintf("%s[%d]", accessor, i), fmt.Sprintf("%s + \"[%d]\"", pathExpr, i), &meta.Elements[i].Type, depth+1)
+		}
+		e.EndBlock()
+	case metadata.KindUnion:
+		if meta.Discriminant != nil && len(meta.Discriminant.Mapping) > 0 {
+			w.discriminatedUnion(e, accessor, pathExpr, meta, depth)
+			return
+		}
+		for _, m := range w.op.unionMembers(meta) {
+			w.walk(e, accessor, pathExpr, m, depth+1)
+		}
+	case metadata.KindIntersection:
+		// A & B is walked as one object with the properties of both members.
+		if props, ok := intersectionProperties(meta, w.registry); ok {
+			w.object(e, accessor, pathExpr, meta, props, depth)
+			return
+		}
+		for _, m := range w.op.intersectionMembers(meta) {
+			w.walk(e, accessor, pathExpr, m, depth+1)
+		}
+	case metadata.KindRef:
+		resolved, ok := w.registry.Types[meta.Ref]
+		if !ok {
+			return
+		}
+		w.define(meta.Ref, resolved)
+		w.op.call(e, w.op.funcName(meta.Ref), accessor, pathExpr)
+	default:
+		w.op.leaf(e, accessor, meta, depth)
+	}
+}
+
+// object walks the declared properties props of the object at accessor, then
+// its index signature values (meta is an object) other than the declared keys.
+func (w *walker) object(e *Emitter, accessor string, pathExpr string, meta *metadata.Metadata, props []metadata.Property, depth int) {
+	e.Block("if (typeof %s === \"object\" && %s !== null)", accessor, accessor)
+	w.op.enter(e, accessor, meta, props, depth)
+	for i := range props {
+		prop := &props[i]
+		propAccessor := jsPropAccess(accessor, prop.Name)
+		propPath := fmt.Sprintf("%s + %q", pathExpr, jsPropPathSuffix(w.op.pathKey(prop)))
+		w.op.property(e, propAccessor, propPath, prop)
+		w.walk(e, propAccessor, propPath, &prop.Type, depth+1)
+	}
+	if meta.Kind == metadata.KindObject && meta.IndexSignature != nil && w.needs(&meta.IndexSignature.ValueType) {
+		kVar := fmt.Sprintf("_k%d", depth)
+		e.Block("for (const %s of Object.keys(%s))", kVar, accessor)
+		if len(props) > 0 {
+			e.Block("if (!%s.has(%s))", w.keys.ref(propNames(props)), kVar)
+		}
+		w.walk(e, fmt.Sprintf("%s[%s]", accessor, kVar), fmt.Sprintf("%s + \"[\" + %s + \"]\"", pathExpr, kVar), &meta.IndexSignature.ValueType, depth+1)
+		if len(props) > 0 {
+			e.EndBlock()
+		}
+		e.EndBlock()
+	}
+	w.op.exit(e, accessor, meta)
+	e.EndBlock()
+}
+
+// discriminatedUnion walks the member selected by the discriminant value.
+func (w *walker) discriminatedUnion(e *Emitter, accessor string, pathExpr string, meta *metadata.Metadata, depth int) {
+	disc := meta.Discriminant
+	values := make([]string, 0, len(disc.Mapping))
+	for v := range disc.Mapping {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+
+	e.Block("if (typeof %s === \"object\" && %s !== null)", accessor, accessor)
+	e.Line("switch (%s) {", jsPropAccess(accessor, disc.Property))
+	e.Indent()
+	for _, val := range values {
+		idx := disc.Mapping[val]
+		if idx < 0 || idx >= len(meta.UnionMembers) || !w.needs(&meta.UnionMembers[idx]) {
+			continue
+		}
+		e.Line("case %s:", jsLiteral(val))
+		e.Indent()
+		w.walk(e, accessor, pathExpr, &meta.UnionMembers[idx], depth+1)
+		e.Line("break;")
+		e.Dedent()
+	}
+	e.Dedent()
+	e.Line("}")
+	e.EndBlock()
+}
+
+// propNames returns the names of props.
+func propNames(props []metadata.Property) []string {
+	names := make([]string, 0, len(props))
+	for _, prop := range props {
+		names = append(names, prop.Name)
+	}
+	return names
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
@@ -18,6 +20,7 @@ type Config struct {
 	OpenAPI     OpenAPIConfig     `json:"openapi"`
 	SDK         SDKConfig         `json:"sdk,omitempty"`
 	NestJS      NestJSConfig      `json:"nestjs,omitempty"`
+	SchemaNames SchemaNamesConfig `json:"schemaNames,omitempty"`
 
 	// Dev/build settings (matching nest-cli.json conventions)
 	EntryFile     string `json:"entryFile,omitempty"`     // Entry point name without extension (default: "main")
@@ -32,6 +35,23 @@ type SDKConfig struct {
 	Input  string `json:"input,omitempty"`  // Path to OpenAPI JSON input (defaults to openapi.output)
 }
 
+// SchemaNamesConfig controls how type names map to schema names shared by
+// companions, OpenAPI components and the generated SDK.
+type SchemaNamesConfig struct {
+	// Strategy disambiguates same-named types declared in different modules:
+	// "path" (default) prefixes the distinguishing directory segments (BillingUserDto),
+	// "namespace" prefixes the enclosing namespace, falling back to "path".
+	// An explicit @schemaName JSDoc tag on a declaration always takes precedence.
+	Strategy string `json:"strategy,omitempty"`
+	// Generics is the template for generic instantiation names, using {base} and
+	// {args} placeholders: "{base}_{args}" (default) → Page_UserDto,
+	// "{base}Of{args}" → PageOfUserDto, "{args}{base}" → UserDtoPage.
+	Generics string `json:"generics,omitempty"`
+	// GenericArgSeparator joins multiple type argument names in {args} (default: "_").
+	// Both must only add identifier characters, as names are part of function names.
+	GenericArgSeparator string `json:"genericArgSeparator,omitempty"`
+}
+
 // ControllersConfig specifies which controller files to analyze.
 type ControllersConfig struct {
 	Include []string `json:"include"`
@@ -46,6 +66,91 @@ type TransformsConfig struct {
 	ResponseTypeCheck string   `json:"responseTypeCheck,omitempty"` // "safe" (default), "guard", or "none" — controls type checking on response serialization
 	Include           []string `json:"include,omitempty"`           // Glob patterns for source files to generate companions for (e.g., ["src/**/*.dto.ts"])
 	Exclude           []string `json:"exclude,omitempty"`           // Type name patterns to exclude from codegen (e.g., "Legacy*", "SomeInternalDto")
+	// Hydrate constructs @Body() class DTOs (and nested class properties) as
+	// instances of their class after validation, via hydrate<T>() (default: false).
+	Hydrate bool `json:"hydrate,omitempty"`
+	// Formats registers custom string formats by name, usable with Format<"name">
+	// and @format like the built-in ones (e.g. {"iban": "^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$"}).
+	Formats map[string]FormatConfig `json:"formats,omitempty"`
+	// Locales maps locale tags to message catalogs: templates by validation
+	// error code, with {param} placeholders (e.g. {"de": {"minLength": "mindestens {min} Zeichen"}}).
+	Locales map[string]map[string]string `json:"locales,omitempty"`
+	// DefaultLocale names the catalog used when none of the requested locales
+	// has one (default: the built-in English messages).
+	DefaultLocale string `json:"defaultLocale,omitempty"`
+	// ValidationError sets the HTTP response of failed request validation in
+	// controllers. When unset, thrown validation errors are left to the
+	// application's exception filters.
+	ValidationError *ValidationErrorConfig `json:"validationError,omitempty"`
+	// FailFast makes validate functions stop at the first error, like
+	// maxErrors: 1 (default: false).
+	FailFast bool `json:"failFast,omitempty"`
+	// MaxErrors stops validate functions once that many errors are collected
+	// (default: 0, all errors). Overridable per call with { maxErrors, failFast }.
+	MaxErrors int `json:"maxErrors,omitempty"`
+	// Limits bounds the structure of validated input against hostile payloads,
+	// on top of the declared constraints (nil: unlimited).
+	Limits *LimitsConfig `json:"limits,omitempty"`
+	// SensitiveMask is serialized in place of Sensitive properties by
+	// serialize functions (default: "", the value is serialized).
+	SensitiveMask string `json:"sensitiveMask,omitempty"`
+	// ReadOnly sets how controller request bodies handle read-only properties
+	// (readonly / @readOnly): "strip" deletes them, "reject" fails validation
+	// when they are sent. Default: "", they are validated like other properties.
+	ReadOnly string `json:"readOnly,omitempty"`
+	// OnDeprecated is called with the path of each deprecated property
+	// (@deprecated) sent in a controller request body (nil: not reported).
+	OnDeprecated *HookConfig `json:"onDeprecated,omitempty"`
+	// JSONNaming derives the JSON names of properties from their TypeScript
+	// names: "camelCase", "PascalCase", "snake_case" or "kebab-case". JSON
+	// names are read from request bodies, written by serializers and used in
+	// OpenAPI. JsonName<N> overrides it per property. Default: "", unchanged.
+	JSONNaming string `json:"jsonNaming,omitempty"`
+}
+
+// HookConfig names a runtime hook: the function exported as Export by Module,
+// a path relative to the config file.
+type HookConfig struct {
+	Module string `json:"module"`
+	Export string `json:"export"`
+}
+
+// LimitsConfig defines the structural limits enforced by generated validators
+// and documented in OpenAPI. Zero fields are unlimited.
+type LimitsConfig struct {
+	MaxDepth        int `json:"maxDepth,omitempty"`        // Nesting depth of recursive types
+	MaxArrayLength  int `json:"maxArrayLength,omitempty"`  // Array length, unless the property declares MaxItems
+	MaxStringLength int `json:"maxStringLength,omitempty"` // String length, unless the property declares MaxLength
+	MaxProperties   int `json:"maxProperties,omitempty"`   // Key count of objects with an index signature
+}
+
+// ValidationErrorConfig defines the HTTP response of failed request validation.
+type ValidationErrorConfig struct {
+	Status int    `json:"status,omitempty"` // 4xx status code (default: 400)
+	Format string `json:"format,omitempty"` // "json" (default) or "problem+json" (RFC 9457)
+	Type   string `json:"type,omitempty"`   // problem+json type URI (default: "about:blank")
+	Title  string `json:"title,omitempty"`  // problem+json title (default: "Validation failed")
+}
+
+// FormatConfig defines a custom string format: a regular expression (Pattern,
+// or the config value itself when it is a string), or a predicate exported as
+// Export by Module, a path relative to the config file.
+type FormatConfig struct {
+	Pattern string `json:"pattern,omitempty"` // JavaScript regular expression source
+	Flags   string `json:"flags,omitempty"`   // JavaScript regular expression flags (e.g. "i")
+	Module  string `json:"module,omitempty"`  // Module exporting the predicate (value: string) => boolean
+	Export  string `json:"export,omitempty"`  // Exported predicate name
+}
+
+// UnmarshalJSON accepts a plain string as the Pattern of the format.
+func (f *FormatConfig) UnmarshalJSON(data []byte) error {
+	var pattern string
+	if err := json.Unmarshal(data, &pattern); err == nil {
+		*f = FormatConfig{Pattern: pattern}
+		return nil
+	}
+	type plain FormatConfig
+	return json.Unmarshal(data, (*plain)(f))
 }
 
 // OpenAPIConfig specifies OpenAPI generation settings.
@@ -293,5 +398,192 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("transforms.responseTypeCheck must be one of \"safe\", \"guard\", \"none\", got %q", c.Transforms.ResponseTypeCheck)
 	}
 
+	if err := validateFormats(c.Transforms.Formats); err != nil {
+		return err
+	}
+	if err := validateLocales(c.Transforms.Locales, c.Transforms.DefaultLocale); err != nil {
+		return err
+	}
+	if ve := c.Transforms.ValidationError; ve != nil {
+		if ve.Status != 0 && (ve.Status < 400 || ve.Status > 499) {
+			return fmt.Errorf("transforms.validationError.status must be a 4xx status code, got %d", ve.Status)
+		}
+		switch ve.Format {
+		case "", "json", "problem+json":
+			// valid — empty defaults to "json"
+		default:
+			return fmt.Errorf("transforms.validationError.format must be one of \"json\", \"problem+json\", got %q", ve.Format)
+		}
+		if (ve.Type != "" || ve.Title != "") && ve.Format != "problem+json" {
+			return fmt.Errorf("transforms.validationError.type and title require format \"problem+json\"")
+		}
+	}
+
+	if c.Transforms.MaxErrors < 0 {
+		return fmt.Errorf("transforms.maxErrors must not be negative, got %d", c.Transforms.MaxErrors)
+	}
+	if c.Transforms.FailFast && c.Transforms.MaxErrors > 1 {
+		return fmt.Errorf("transforms.failFast conflicts with transforms.maxErrors %d", c.Transforms.MaxErrors)
+	}
+	switch c.Transforms.ReadOnly {
+	case "", "strip", "reject":
+	default:
+		return fmt.Errorf("transforms.readOnly must be one of \"strip\", \"reject\", got %q", c.Transforms.ReadOnly)
+	}
+	switch c.Transforms.JSONNaming {
+	case "", "camelCase", "PascalCase", "snake_case", "kebab-case":
+	default:
+		return fmt.Errorf("transforms.jsonNaming must be one of \"camelCase\", \"PascalCase\", \"snake_case\", \"kebab-case\", got %q", c.Transforms.JSONNaming)
+	}
+	if h := c.Transforms.OnDeprecated; h != nil {
+		if h.Module == "" {
+			return fmt.Errorf("transforms.onDeprecated.module is required")
+		}
+		if !identifierRe.MatchString(h.Export) {
+			return fmt.Errorf("transforms.onDeprecated.export must name the function exported by %q, got %q", h.Module, h.Export)
+		}
+		for name, f := range c.Transforms.Formats {
+			if f.Module != "" && f.Export == h.Export && f.Module != h.Module {
+				return fmt.Errorf("transforms.onDeprecated.export %q is also imported from %q by transforms.formats.%s", h.Export, f.Module, name)
+			}
+		}
+	}
+	if l := c.Transforms.Limits; l != nil {
+		for _, f := range []struct {
+			name  string
+			value int
+		}{
+			{"maxDepth", l.MaxDepth},
+			{"maxArrayLength", l.MaxArrayLength},
+			{"maxStringLength", l.MaxStringLength},
+			{"maxProperties", l.MaxProperties},
+		} {
+			if f.value < 0 {
+				return fmt.Errorf("transforms.limits.%s must not be negative, got %d", f.name, f.value)
+			}
+		}
+	}
+
+	// Validate schemaNames.strategy
+	switch c.SchemaNames.Strategy {
+	case "", "path", "namespace":
+		// valid — empty defaults to "path"
+	default:
+		return fmt.Errorf("schemaNames.strategy must be one of \"path\", \"namespace\", got %q", c.SchemaNames.Strategy)
+	}
+
+	// Validate schemaNames.generics template
+	if g := c.SchemaNames.Generics; g != "" && (!strings.Contains(g, "{base}") || !strings.Contains(g, "{args}")) {
+		return fmt.Errorf("schemaNames.generics must contain both {base} and {args} placeholders, got %q", g)
+	}
+	// Generic names are part of function names too, so the literal text must
+	// keep them identifiers.
+	if g := c.SchemaNames.Generics; g != "" && !identifierRe.MatchString(strings.NewReplacer("{base}", "A", "{args}", "A").Replace(g)) {
+		return fmt.Errorf("schemaNames.generics must only add identifier characters (letters, digits, _ and $) around {base} and {args}, got %q", g)
+	}
+	if s := c.SchemaNames.GenericArgSeparator; !identifierCharsRe.MatchString(s) {
+		return fmt.Errorf("schemaNames.genericArgSeparator must only contain identifier characters (letters, digits, _ and $), got %q", s)
+	}
+
+	return nil
+}
+
+var (
+	formatNameRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+	identifierRe = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	// identifierCharsRe matches text that may appear inside an identifier.
+	identifierCharsRe = regexp.MustCompile(`^[A-Za-z0-9_$]*$`)
+	localeTagRe       = regexp.MustCompile(`^[A-Za-z]{2,8}(-[A-Za-z0-9]{1,8})*$`)
+)
+
+// ErrorCodes lists the codes of generated validation errors, the keys of
+// transforms.locales catalogs.
+var ErrorCodes = []string{
+	"type", "required", "unknownProperty",
+	"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf",
+	"minLength", "maxLength", "pattern", "format",
+	"startsWith", "endsWith", "includes", "uppercase", "lowercase",
+	"minItems", "maxItems", "uniqueItems", "maxProperties", "maxDepth",
+	"validate", "validateAsync", "requireOneOf", "dependentRequired", "readOnly",
+}
+
+// validateLocales checks transforms.locales and transforms.defaultLocale, in
+// locale order.
+func validateLocales(locales map[string]map[string]string, defaultLocale string) error {
+	known := make(map[string]bool, len(ErrorCodes))
+	for _, code := range ErrorCodes {
+		known[code] = true
+	}
+	tags := make([]string, 0, len(locales))
+	seen := make(map[string]string) // lowercased tag → tag
+	for tag := range locales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		if !localeTagRe.MatchString(tag) {
+			return fmt.Errorf("transforms.locales: invalid locale tag %q (e.g. \"de\", \"pt-BR\")", tag)
+		}
+		if other, ok := seen[strings.ToLower(tag)]; ok {
+			return fmt.Errorf("transforms.locales: %q and %q are the same locale", other, tag)
+		}
+		seen[strings.ToLower(tag)] = tag
+		codes := make([]string, 0, len(locales[tag]))
+		for code := range locales[tag] {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			if !known[code] {
+				return fmt.Errorf("transforms.locales.%s: unknown error code %q", tag, code)
+			}
+		}
+	}
+	if defaultLocale != "" {
+		if _, ok := seen[strings.ToLower(defaultLocale)]; !ok {
+			return fmt.Errorf("transforms.defaultLocale %q has no catalog in transforms.locales", defaultLocale)
+		}
+	}
+	return nil
+}
+
+// validateFormats checks transforms.formats, in name order.
+func validateFormats(formats map[string]FormatConfig) error {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	exports := make(map[string]string) // export name → module
+	consts := make(map[string]string)  // generated constant name → format name
+	for _, name := range names {
+		f := formats[name]
+		key := "transforms.formats." + name
+		if !formatNameRe.MatchString(name) {
+			return fmt.Errorf("transforms.formats: invalid format name %q (letters, digits, \"-\" and \"_\", starting with a letter)", name)
+		}
+		// "-" and "_" map to the same character of the generated JS constant
+		constName := strings.ReplaceAll(name, "-", "_")
+		if other, ok := consts[constName]; ok {
+			return fmt.Errorf("transforms.formats: format names %q and %q differ only by \"-\" and \"_\"", other, name)
+		}
+		consts[constName] = name
+		if (f.Pattern == "") == (f.Module == "") {
+			return fmt.Errorf("%s must set exactly one of pattern or module", key)
+		}
+		if f.Module != "" {
+			if !identifierRe.MatchString(f.Export) {
+				return fmt.Errorf("%s.export must name the predicate exported by %q, got %q", key, f.Module, f.Export)
+			}
+			if module, ok := exports[f.Export]; ok && module != f.Module {
+				return fmt.Errorf("%s.export %q is also imported from %q", key, f.Export, module)
+			}
+			exports[f.Export] = f.Module
+		}
+		if strings.Trim(f.Flags, "dimsuv") != "" {
+			return fmt.Errorf("%s.flags must only contain \"d\", \"i\", \"m\", \"s\", \"u\" or \"v\", got %q", key, f.Flags)
+		}
+	}
 	return nil
 }
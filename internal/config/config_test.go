@@ -532,6 +532,435 @@ func TestLoadConfig_ResponseTypeCheck(t *testing.T) {
 	})
 }
 
+func TestLoadConfig_TransformsHydrate(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "tsgonest.config.json")
+	content := `{
+		"controllers": { "include": ["src/**/*.controller.ts"] },
+		"transforms": { "validation": true, "serialization": true, "hydrate": true },
+		"openapi": { "output": "dist/openapi.json" }
+	}`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.Transforms.Hydrate {
+		t.Error("expected transforms.hydrate to be true")
+	}
+}
+
+func TestLoadConfig_TransformsFormats(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "tsgonest.config.json")
+	content := `{
+		"controllers": { "include": ["src/**/*.controller.ts"] },
+		"transforms": {
+			"validation": true,
+			"formats": {
+				"slug": "^[a-z0-9]+(?:-[a-z0-9]+)*$",
+				"e164": { "pattern": "^\\+[1-9][0-9]{1,14}$" },
+				"iban": { "module": "./src/validators/iban.ts", "export": "isIban" }
+			}
+		},
+		"openapi": { "output": "dist/openapi.json" }
+	}`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	formats := cfg.Transforms.Formats
+	if formats["slug"].Pattern != "^[a-z0-9]+(?:-[a-z0-9]+)*$" {
+		t.Errorf("expected string shorthand to set the pattern, got %+v", formats["slug"])
+	}
+	if formats["e164"].Pattern != `^\+[1-9][0-9]{1,14}$` {
+		t.Errorf("unexpected e164 format: %+v", formats["e164"])
+	}
+	if formats["iban"].Module != "./src/validators/iban.ts" || formats["iban"].Export != "isIban" {
+		t.Errorf("unexpected iban format: %+v", formats["iban"])
+	}
+
+	invalid := map[string]FormatConfig{
+		"both":       {Pattern: "^a$", Module: "./a.ts", Export: "isA"},
+		"neither":    {},
+		"no-export":  {Module: "./a.ts"},
+		"bad-flags":  {Pattern: "^a$", Flags: "g"},
+		"1st":        {Pattern: "^a$"},
+		"has space ": {Pattern: "^a$"},
+	}
+	for name, f := range invalid {
+		cfg.Transforms.Formats = map[string]FormatConfig{name: f}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "transforms.formats") {
+			t.Errorf("%s: expected error about transforms.formats, got: %v", name, err)
+		}
+	}
+
+	cfg.Transforms.Formats = map[string]FormatConfig{
+		"a": {Module: "./a.ts", Export: "check"},
+		"b": {Module: "./b.ts", Export: "check"},
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "transforms.formats.b.export") {
+		t.Errorf("expected error about the conflicting export, got: %v", err)
+	}
+
+	cfg.Transforms.Formats = map[string]FormatConfig{
+		"iso-week": {Pattern: "^a$"},
+		"iso_week": {Pattern: "^b$"},
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), `"iso-week" and "iso_week"`) {
+		t.Errorf("expected error about the colliding format names, got: %v", err)
+	}
+}
+
+func TestLoadConfig_TransformsLocales(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "tsgonest.config.json")
+	content := `{
+		"controllers": { "include": ["src/**/*.controller.ts"] },
+		"transforms": {
+			"validation": true,
+			"locales": {
+				"de": { "minLength": "mindestens {min} Zeichen", "required": "Pflichtfeld" },
+				"pt-BR": { "format": "formato {format} inválido" }
+			},
+			"defaultLocale": "de"
+		},
+		"openapi": { "output": "dist/openapi.json" }
+	}`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Transforms.Locales["de"]["minLength"] != "mindestens {min} Zeichen" {
+		t.Errorf("unexpected de catalog: %v", cfg.Transforms.Locales["de"])
+	}
+	if cfg.Transforms.DefaultLocale != "de" {
+		t.Errorf("expected defaultLocale de, got %q", cfg.Transforms.DefaultLocale)
+	}
+
+	invalid := []struct {
+		locales       map[string]map[string]string
+		defaultLocale string
+		want          string
+	}{
+		{map[string]map[string]string{"de": {"minLenght": "x"}}, "", `unknown error code "minLenght"`},
+		{map[string]map[string]string{"de_DE": {}}, "", `invalid locale tag "de_DE"`},
+		{map[string]map[string]string{"pt-BR": {}, "pt-br": {}}, "", "are the same locale"},
+		{map[string]map[string]string{"de": {}}, "fr", "transforms.defaultLocale"},
+	}
+	for _, tt := range invalid {
+		cfg.Transforms.Locales = tt.locales
+		cfg.Transforms.DefaultLocale = tt.defaultLocale
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: expected error containing %q, got: %v", tt.locales, tt.want, err)
+		}
+	}
+}
+
+func TestLoadConfig_TransformsValidationError(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "tsgonest.config.json")
+	content := `{
+		"controllers": { "include": ["src/**/*.controller.ts"] },
+		"transforms": {
+			"validation": true,
+			"validationError": {
+				"status": 422,
+				"format": "problem+json",
+				"type": "https://example.com/problems/validation"
+			}
+		},
+		"openapi": { "output": "dist/openapi.json" }
+	}`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ve := cfg.Transforms.ValidationError
+	if ve == nil || ve.Status != 422 || ve.Format != "problem+json" || ve.Type != "https://example.com/problems/validation" {
+		t.Fatalf("unexpected validationError: %+v", ve)
+	}
+
+	invalid := []struct {
+		ve   ValidationErrorConfig
+		want string
+	}{
+		{ValidationErrorConfig{Status: 500}, "must be a 4xx status code"},
+		{ValidationErrorConfig{Format: "xml"}, "transforms.validationError.format"},
+		{ValidationErrorConfig{Title: "Invalid"}, "require format \"problem+json\""},
+	}
+	for _, tt := range invalid {
+		cfg.Transforms.ValidationError = &tt.ve
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%+v: expected error containing %q, got: %v", tt.ve, tt.want, err)
+		}
+	}
+}
+
+func TestLoadConfig_TransformsMaxErrors(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "tsgonest.config.json")
+	content := `{
+		"controllers": { "include": ["src/**/*.controller.ts"] },
+		"transforms": { "validation": true, "maxErrors": 20 },
+		"openapi": { "output": "dist/openapi.json" }
+	}`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Transforms.MaxErrors != 20 || cfg.Transforms.FailFast {
+		t.Fatalf("unexpected limits: maxErrors=%d failFast=%v", cfg.Transforms.MaxErrors, cfg.Transforms.FailFast)
+	}
+
+	cfg.Transforms.MaxErrors = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "must not be negative") {
+		t.Errorf("expected negative maxErrors error, got: %v", err)
+	}
+	cfg.Transforms.MaxErrors = 5
+	cfg.Transforms.FailFast = true
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "conflicts with") {
+		t.Errorf("expected failFast conflict error, got: %v", err)
+	}
+	cfg.Transforms.MaxErrors = 1
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("failFast with maxErrors 1 should be valid, got: %v", err)
+	}
+}
+
+func TestLoadConfig_TransformsLimits(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "tsgonest.config.json")
+	content := `{
+		"controllers": { "include": ["src/**/*.controller.ts"] },
+		"transforms": {
+			"validation": true,
+			"limits": { "maxDepth": 32, "maxArrayLength": 10000, "maxStringLength": 100000, "maxProperties": 1000 }
+		},
+		"openapi": { "output": "dist/openapi.json" }
+	}`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := LimitsConfig{MaxDepth: 32, MaxArrayLength: 10000, MaxStringLength: 100000, MaxProperties: 1000}
+	if l := cfg.Transforms.Limits; l == nil || *l != want {
+		t.Fatalf("unexpected limits: %+v", l)
+	}
+
+	cfg.Transforms.Limits.MaxArrayLength = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "transforms.limits.maxArrayLength must not be negative") {
+		t.Errorf("expected negative maxArrayLength error, got: %v", err)
+	}
+}
+
+func TestLoadConfig_TransformsSensitiveMask(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "tsgonest.config.json")
+	content := `{
+		"controllers": { "include": ["src/**/*.controller.ts"] },
+		"transforms": { "serialization": true, "sensitiveMask": "***" }
+	}`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Transforms.SensitiveMask != "***" {
+		t.Errorf("expected sensitiveMask \"***\", got %q", cfg.Transforms.SensitiveMask)
+	}
+}
+
+func TestLoadConfig_TransformsReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "tsgonest.config.json")
+	content := `{
+		"controllers": { "include": ["src/**/*.controller.ts"] },
+		"transforms": { "validation": true, "readOnly": "reject" }
+	}`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Transforms.ReadOnly != "reject" {
+		t.Errorf("expected readOnly \"reject\", got %q", cfg.Transforms.ReadOnly)
+	}
+
+	cfg.Transforms.ReadOnly = "ignore"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "transforms.readOnly must be one of") {
+		t.Errorf("expected invalid readOnly error, got: %v", err)
+	}
+}
+
+func TestLoadConfig_TransformsOnDeprecated(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "tsgonest.config.json")
+	content := `{
+		"controllers": { "include": ["src/**/*.controller.ts"] },
+		"transforms": {
+			"validation": true,
+			"onDeprecated": { "module": "./src/deprecation.ts", "export": "onDeprecated" }
+		}
+	}`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := HookConfig{Module: "./src/deprecation.ts", Export: "onDeprecated"}
+	if h := cfg.Transforms.OnDeprecated; h == nil || *h != want {
+		t.Fatalf("unexpected onDeprecated: %+v", h)
+	}
+
+	cfg.Transforms.OnDeprecated.Export = "on-deprecated"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "transforms.onDeprecated.export must name") {
+		t.Errorf("expected invalid export error, got: %v", err)
+	}
+	cfg.Transforms.OnDeprecated.Export = "isIban"
+	cfg.Transforms.Formats = map[string]FormatConfig{"iban": {Module: "./src/iban.ts", Export: "isIban"}}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "is also imported from") {
+		t.Errorf("expected export conflict error, got: %v", err)
+	}
+}
+
+func TestLoadConfig_TransformsJSONNaming(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "tsgonest.config.json")
+	content := `{
+		"controllers": { "include": ["src/**/*.controller.ts"] },
+		"transforms": { "validation": true, "serialization": true, "jsonNaming": "snake_case" }
+	}`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Transforms.JSONNaming != "snake_case" {
+		t.Errorf("expected jsonNaming \"snake_case\", got %q", cfg.Transforms.JSONNaming)
+	}
+
+	cfg.Transforms.JSONNaming = "SCREAMING_SNAKE"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "transforms.jsonNaming must be one of") {
+		t.Errorf("expected invalid jsonNaming error, got: %v", err)
+	}
+}
+
+func TestLoadConfig_SchemaNamesStrategy(t *testing.T) {
+	for _, strategy := range []string{"path", "namespace"} {
+		t.Run(strategy, func(t *testing.T) {
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, "tsgonest.config.json")
+			content := fmt.Sprintf(`{
+				"controllers": { "include": ["src/**/*.controller.ts"] },
+				"openapi": { "output": "dist/openapi.json" },
+				"schemaNames": { "strategy": %q }
+			}`, strategy)
+			if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := Load(configPath)
+			if err != nil {
+				t.Fatalf("unexpected error for strategy %q: %v", strategy, err)
+			}
+			if cfg.SchemaNames.Strategy != strategy {
+				t.Errorf("expected schemaNames.strategy=%q, got %q", strategy, cfg.SchemaNames.Strategy)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		dir := t.TempDir()
+		configPath := filepath.Join(dir, "tsgonest.config.json")
+		content := `{
+			"controllers": { "include": ["src/**/*.controller.ts"] },
+			"openapi": { "output": "dist/openapi.json" },
+			"schemaNames": { "strategy": "hash" }
+		}`
+		if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := Load(configPath)
+		if err == nil || !strings.Contains(err.Error(), "schemaNames.strategy") {
+			t.Fatalf("expected error about schemaNames.strategy, got: %v", err)
+		}
+	})
+}
+
+func TestLoadConfig_SchemaNamesGenerics(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "tsgonest.config.json")
+	content := `{
+		"controllers": { "include": ["src/**/*.controller.ts"] },
+		"openapi": { "output": "dist/openapi.json" },
+		"schemaNames": { "generics": "{base}Of{args}", "genericArgSeparator": "And" }
+	}`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SchemaNames.Generics != "{base}Of{args}" || cfg.SchemaNames.GenericArgSeparator != "And" {
+		t.Errorf("unexpected schemaNames config: %+v", cfg.SchemaNames)
+	}
+
+	cfg.SchemaNames.Generics = "{base}Page"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "schemaNames.generics") {
+		t.Fatalf("expected error about schemaNames.generics, got: %v", err)
+	}
+	for _, g := range []string{"{base}<{args}>", "{base}-{args}", "1{base}{args}"} {
+		cfg.SchemaNames.Generics = g
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "schemaNames.generics") {
+			t.Errorf("%s: expected error about schemaNames.generics, got: %v", g, err)
+		}
+	}
+	cfg.SchemaNames.Generics = "{args}{base}"
+	cfg.SchemaNames.GenericArgSeparator = ", "
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "schemaNames.genericArgSeparator") {
+		t.Fatalf("expected error about schemaNames.genericArgSeparator, got: %v", err)
+	}
+}
+
 func TestLoadConfig_SDKConfig(t *testing.T) {
 	dir := t.TempDir()
 	configPath := filepath.Join(dir, "tsgonest.config.json")
@@ -80,6 +80,19 @@ type Metadata struct {
 	// "serialization" = skip serialization only
 	Ignore string `json:"ignore,omitempty"`
 
+	// ClassName is the name of the exported class an object type was declared as,
+	// and ClassModule the source file path declaring it. Only set for KindObject
+	// types of named (non-default) class exports; hydrate uses them to construct
+	// instances with the class prototype.
+	ClassName   string `json:"className,omitempty"`
+	ClassModule string `json:"classModule,omitempty"`
+
+	// Rules holds cross-field validation rules of an object type, from
+	// Validate<typeof fn>, RequireOneOf<[...]> and DependentRequired<{...}> tags
+	// intersected with the object or the equivalent JSDoc tags on its declaration.
+	// Only set when Kind == KindObject.
+	Rules *ObjectRules `json:"rules,omitempty"`
+
 	// Constraints holds validation constraints extracted from branded phantom types
 	// (e.g., `string & tags.Format<"email">`). These are merged with JSDoc constraints
 	// at the property level during object analysis. Only set on atomic types returned
@@ -121,6 +134,34 @@ type Property struct {
 	WriteOnly bool `json:"writeOnly,omitempty"`
 	// Example is from @example JSDoc on the property declaration.
 	Example *string `json:"example,omitempty"`
+	// Deprecated is from @deprecated JSDoc on the property declaration.
+	Deprecated bool `json:"deprecated,omitempty"`
+	// JsonName is the name of the property in JSON bodies when it differs from
+	// Name (JsonName<N> / @jsonName, or the transforms.jsonNaming policy).
+	JsonName string `json:"jsonName,omitempty"`
+}
+
+// WireName returns the name of the property in JSON bodies.
+func (p *Property) WireName() string {
+	if p.JsonName != "" {
+		return p.JsonName
+	}
+	return p.Name
+}
+
+// InView reports whether the property is serialized in the given view ("" for
+// routes without one): properties without groups are in every view, grouped
+// properties (Groups<G> / @groups) only in the views named by their groups.
+func (p *Property) InView(view string) bool {
+	if p.Constraints == nil || len(p.Constraints.Groups) == 0 {
+		return true
+	}
+	for _, group := range p.Constraints.Groups {
+		if group == view {
+			return true
+		}
+	}
+	return false
 }
 
 // Constraints represents validation constraints extracted from JSDoc tags.
@@ -156,12 +197,17 @@ type Constraints struct {
 	// String/number transforms (applied before validation)
 	Transforms []string `json:"transforms,omitempty"` // "trim", "toLowerCase", "toUpperCase"
 
+	// User transform functions (Transform<typeof fn>), applied in order after
+	// the built-in Transforms and before validation.
+	TransformFns []FunctionRef `json:"transformFns,omitempty"`
+
 	// Array constraints
 	MinItems    *int  `json:"minItems,omitempty"`
 	MaxItems    *int  `json:"maxItems,omitempty"`
 	UniqueItems *bool `json:"uniqueItems,omitempty"`
 
-	// Schema-only (no runtime validation)
+	// Default value (Default<V> / @default). Emitted in schemas, and filled in by
+	// validate/assert when the property is absent, before the other checks.
 	Default *string `json:"default,omitempty"`
 
 	// Coercion: when true, string inputs are coerced to their declared type
@@ -176,6 +222,24 @@ type Constraints struct {
 	ValidateFn     *string `json:"validateFn,omitempty"`
 	ValidateModule *string `json:"validateModule,omitempty"`
 
+	// Async custom validator function reference (ValidateAsync<typeof fn>).
+	// The function returns a Promise<boolean> and only runs in validateAsync/
+	// assertAsync, once the synchronous checks have passed.
+	ValidateAsyncFn     *string `json:"validateAsyncFn,omitempty"`
+	ValidateAsyncModule *string `json:"validateAsyncModule,omitempty"`
+
+	// Sensitive (Sensitive / @sensitive): validation errors report only the
+	// received type, never the value, and serializers may mask it.
+	Sensitive *bool `json:"sensitive,omitempty"`
+
+	// JSON name of the property (JsonName<N> / @jsonName), overriding the
+	// naming policy. Resolved into Property.JsonName by the type walker.
+	JsonName *string `json:"jsonName,omitempty"`
+
+	// Serialization groups (Groups<G> / @groups): the property is only
+	// serialized in the views named by one of them. See Property.InView.
+	Groups []string `json:"groups,omitempty"`
+
 	// Custom error message (global fallback for all checks on this property)
 	ErrorMessage *string `json:"errorMessage,omitempty"`
 
@@ -185,6 +249,39 @@ type Constraints struct {
 	Errors map[string]string `json:"errors,omitempty"`
 }
 
+// FunctionRef references an exported function of a user module.
+type FunctionRef struct {
+	// Name is the exported function name (e.g., "normalizePhone").
+	Name string `json:"name"`
+	// Module is the source file path declaring the function.
+	Module string `json:"module,omitempty"`
+}
+
+// ObjectRules represents validation rules spanning several properties of an object.
+// They are checked after the property checks.
+type ObjectRules struct {
+	// ValidateFn is an exported predicate called with the whole object, and
+	// ValidateModule the source file path declaring it. It is only called when
+	// the properties of the object are valid.
+	ValidateFn     string `json:"validateFn,omitempty"`
+	ValidateModule string `json:"validateModule,omitempty"`
+	// ValidateError replaces the default error message of ValidateFn.
+	ValidateError string `json:"validateError,omitempty"`
+
+	// RequireOneOf lists groups of properties of which exactly one must be present.
+	RequireOneOf []KeyGroup `json:"requireOneOf,omitempty"`
+
+	// DependentRequired maps a property to the properties that must be present
+	// whenever it is (JSON Schema dependentRequired).
+	DependentRequired map[string][]string `json:"dependentRequired,omitempty"`
+}
+
+// KeyGroup is a set of property names with an optional custom error message.
+type KeyGroup struct {
+	Keys  []string `json:"keys"`
+	Error string   `json:"error,omitempty"`
+}
+
 // Discriminant describes the discriminant property of a discriminated union.
 // The property has a unique literal value in each union member.
 type Discriminant struct {
@@ -210,6 +210,12 @@ type GenerateOptions struct {
 	VersioningType string // "URI", "HEADER", "MEDIA_TYPE", ""
 	VersionPrefix  string // default "v" for URI versioning
 	DefaultVersion string
+	// ValidationError documents the response of failed request validation on
+	// every route with request validation (nil: not documented).
+	ValidationError *ValidationErrorResponse
+	// Limits documents the structural limits of generated validators on
+	// schemas without a declared bound (nil: not documented).
+	Limits *SchemaLimits
 }
 
 // Generator creates OpenAPI documents from controller analysis results.
@@ -394,7 +400,7 @@ func (g *Generator) buildOperation(route analyzer.Route, controllerName string)
 			Description: respDescription,
 		}
 	} else {
-		responseSchema := g.schemaGen.MetadataToSchema(&route.ReturnType)
+		responseSchema := g.schemaGen.ViewSchema(&route.ReturnType, route.View)
 		op.Responses[statusStr] = &Response{
 			Description: respDescription,
 			Content: map[string]MediaType{
@@ -417,7 +423,7 @@ func (g *Generator) buildOperation(route analyzer.Route, controllerName string)
 		if ar.ReturnType.Kind == metadata.KindVoid {
 			op.Responses[arStatusStr] = &Response{Description: arDescription}
 		} else {
-			arSchema := g.schemaGen.MetadataToSchema(&ar.ReturnType)
+			arSchema := g.schemaGen.ViewSchema(&ar.ReturnType, route.View)
 			op.Responses[arStatusStr] = &Response{
 				Description: arDescription,
 				Content:     map[string]MediaType{arContentType: {Schema: arSchema}},
@@ -573,7 +579,8 @@ func (g *Generator) buildEventStreamSchema(variants []analyzer.SSEEventVariant)
 
 		// Add contentSchema for typed data payload
 		if v.DataType.Kind != metadata.KindAny && v.DataType.Kind != metadata.KindVoid && v.DataType.Kind != "" {
-			dataSchema := g.schemaGen.MetadataToSchema(&v.DataType)
+			// Event data is written by the default-view stringify companion
+			dataSchema := g.schemaGen.ViewSchema(&v.DataType, "")
 			dataProp.ContentMediaType = "application/json"
 			dataProp.ContentSchema = dataSchema
 		}
@@ -739,8 +746,14 @@ func (g *Generator) GenerateWithOptions(controllers []analyzer.ControllerInfo, o
 		doc.Info.XTsgonestVersionPrefix = opts.VersionPrefix
 	}
 
+	if opts != nil && opts.Limits != nil {
+		g.schemaGen.limits = *opts.Limits
+	}
+
 	// Collect all unique tags
 	tagSet := make(map[string]bool)
+	// Response bodies of validation errors, referenced once the schemas are known
+	var validationRefs []*Schema
 
 	for _, ctrl := range controllers {
 		// Skip controllers annotated with @tsgonest-ignore openapi, @hidden, or @exclude
@@ -788,6 +801,11 @@ func (g *Generator) GenerateWithOptions(controllers []analyzer.ControllerInfo, o
 
 				// Create operation
 				op := g.buildOperation(route, ctrl.Name)
+				if opts != nil && opts.ValidationError != nil && route.ValidatesRequest(opts.ValidationError.HasCompanion) {
+					if ref := addValidationErrorResponse(op, opts.ValidationError); ref != nil {
+						validationRefs = append(validationRefs, ref)
+					}
+				}
 
 				// Synthesize missing path parameters.
 				// Controller-level params (e.g., @Controller(':workspaceID')) appear in
@@ -853,6 +871,18 @@ func (g *Generator) GenerateWithOptions(controllers []analyzer.ControllerInfo, o
 	if len(schemas) > 0 {
 		doc.Components = &Components{Schemas: schemas}
 	}
+	if len(validationRefs) > 0 {
+		if doc.Components == nil {
+			doc.Components = &Components{Schemas: make(map[string]*Schema)}
+		}
+		names := validationErrorNames(doc.Components.Schemas)
+		for name, schema := range validationErrorSchemas(opts.ValidationError, names) {
+			doc.Components.Schemas[name] = schema
+		}
+		for _, ref := range validationRefs {
+			ref.Ref = "#/components/schemas/" + validationErrorBodyName(opts.ValidationError, names)
+		}
+	}
 
 	return doc
 }
@@ -2353,3 +2353,162 @@ func TestIntegration_Bug2_InterfacePropertyTypesInOpenAPI(t *testing.T) {
 		t.Error("slug: expected maxLength constraint")
 	}
 }
+
+// ---- transforms.validationError in OpenAPI ----
+
+func TestIntegration_ValidationErrorResponse(t *testing.T) {
+	registry := metadata.NewTypeRegistry()
+	registry.Register("CreateUserDto", &metadata.Metadata{
+		Kind: metadata.KindObject,
+		Name: "CreateUserDto",
+		Properties: []metadata.Property{
+			{Name: "name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+		},
+	})
+	gen := NewGenerator(registry)
+
+	controllers := []analyzer.ControllerInfo{
+		{
+			Name: "UserController",
+			Path: "users",
+			Routes: []analyzer.Route{
+				{
+					Method:      "POST",
+					Path:        "/users",
+					OperationID: "createUser",
+					Parameters: []analyzer.RouteParameter{
+						{Category: "body", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "CreateUserDto"}, Required: true},
+					},
+					ReturnType: metadata.Metadata{Kind: metadata.KindVoid},
+					StatusCode: 201,
+				},
+				{
+					Method:      "GET",
+					Path:        "/users",
+					OperationID: "listUsers",
+					ReturnType:  metadata.Metadata{Kind: metadata.KindVoid},
+					StatusCode:  200,
+				},
+			},
+		},
+	}
+
+	doc := gen.GenerateWithOptions(controllers, &GenerateOptions{
+		ValidationError: &ValidationErrorResponse{Status: 422, Format: "problem+json"},
+	})
+	data := requireValidDoc(t, doc)
+
+	raw := parseJSON(t, data)
+	pathItem := raw["paths"].(map[string]any)["/users"].(map[string]any)
+	createResponses := pathItem["post"].(map[string]any)["responses"].(map[string]any)
+	resp422, ok := createResponses["422"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected 422 response on validated route, got %v", createResponses)
+	}
+	content := resp422["content"].(map[string]any)
+	media, ok := content["application/problem+json"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected application/problem+json content, got %v", content)
+	}
+	if ref := media["schema"].(map[string]any)["$ref"]; ref != "#/components/schemas/ValidationProblem" {
+		t.Errorf("expected ValidationProblem ref, got %v", ref)
+	}
+
+	listResponses := pathItem["get"].(map[string]any)["responses"].(map[string]any)
+	if _, ok := listResponses["422"]; ok {
+		t.Error("expected no validation error response on route without validation")
+	}
+
+	schemas := raw["components"].(map[string]any)["schemas"].(map[string]any)
+	problem, ok := schemas["ValidationProblem"].(map[string]any)
+	if !ok {
+		t.Fatal("expected ValidationProblem component schema")
+	}
+	for _, prop := range []string{"type", "title", "status", "detail", "errors"} {
+		if _, ok := problem["properties"].(map[string]any)[prop]; !ok {
+			t.Errorf("expected ValidationProblem.%s", prop)
+		}
+	}
+	if _, ok := schemas["ValidationErrorDetail"]; !ok {
+		t.Error("expected ValidationErrorDetail component schema")
+	}
+}
+
+func TestIntegration_ValidationErrorResponseNameClash(t *testing.T) {
+	registry := metadata.NewTypeRegistry()
+	for _, name := range []string{"CreateUserDto", "ValidationErrorBody"} {
+		registry.Register(name, &metadata.Metadata{
+			Kind: metadata.KindObject,
+			Name: name,
+			Properties: []metadata.Property{
+				{Name: "name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+			},
+		})
+	}
+	gen := NewGenerator(registry)
+
+	controllers := []analyzer.ControllerInfo{
+		{
+			Name: "UserController",
+			Path: "users",
+			Routes: []analyzer.Route{
+				{
+					Method:      "POST",
+					Path:        "/users",
+					OperationID: "createUser",
+					Parameters: []analyzer.RouteParameter{
+						{Category: "body", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "CreateUserDto"}, Required: true},
+					},
+					ReturnType: metadata.Metadata{Kind: metadata.KindRef, Ref: "ValidationErrorBody"},
+					StatusCode: 201,
+				},
+				{
+					Method:      "PUT",
+					Path:        "/users",
+					OperationID: "replaceUser",
+					Parameters: []analyzer.RouteParameter{
+						{Category: "body", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "ValidationErrorBody"}, Required: true},
+					},
+					ReturnType: metadata.Metadata{Kind: metadata.KindVoid},
+					StatusCode: 200,
+				},
+			},
+		},
+	}
+
+	doc := gen.GenerateWithOptions(controllers, &GenerateOptions{
+		ValidationError: &ValidationErrorResponse{
+			// Only CreateUserDto has a companion, so only createUser is validated
+			HasCompanion: func(typeName string) bool { return typeName == "CreateUserDto" },
+		},
+	})
+	data := requireValidDoc(t, doc)
+
+	raw := parseJSON(t, data)
+	pathItem := raw["paths"].(map[string]any)["/users"].(map[string]any)
+	createResponses := pathItem["post"].(map[string]any)["responses"].(map[string]any)
+	resp400, ok := createResponses["400"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected 400 response on validated route, got %v", createResponses)
+	}
+	media := resp400["content"].(map[string]any)["application/json"].(map[string]any)
+	if ref := media["schema"].(map[string]any)["$ref"]; ref != "#/components/schemas/TsgonestValidationErrorBody" {
+		t.Errorf("expected TsgonestValidationErrorBody ref, got %v", ref)
+	}
+	replaceResponses := pathItem["put"].(map[string]any)["responses"].(map[string]any)
+	if _, ok := replaceResponses["400"]; ok {
+		t.Error("expected no validation error response on route whose body has no companion")
+	}
+
+	schemas := raw["components"].(map[string]any)["schemas"].(map[string]any)
+	user := schemas["ValidationErrorBody"].(map[string]any)
+	if _, ok := user["properties"].(map[string]any)["name"]; !ok {
+		t.Errorf("expected the user ValidationErrorBody schema to be kept, got %v", user)
+	}
+	if _, ok := schemas["TsgonestValidationErrorBody"]; !ok {
+		t.Error("expected TsgonestValidationErrorBody component schema")
+	}
+	if _, ok := schemas["ValidationErrorDetail"]; !ok {
+		t.Error("expected ValidationErrorDetail component schema")
+	}
+}
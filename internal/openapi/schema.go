@@ -47,6 +47,9 @@ type Schema struct {
 	// Additional schema properties
 	AdditionalProperties *SchemaOrBool `json:"additionalProperties,omitempty"`
 
+	// DependentRequired lists the properties required when a property is present.
+	DependentRequired map[string][]string `json:"dependentRequired,omitempty"`
+
 	// Validation constraints (from JSDoc tags)
 	Minimum          *float64 `json:"minimum,omitempty"`
 	Maximum          *float64 `json:"maximum,omitempty"`
@@ -59,12 +62,18 @@ type Schema struct {
 	MinItems         *int     `json:"minItems,omitempty"`
 	MaxItems         *int     `json:"maxItems,omitempty"`
 	UniqueItems      *bool    `json:"uniqueItems,omitempty"`
+	MaxProperties    *int     `json:"maxProperties,omitempty"`
 	Default          any      `json:"default,omitempty"`
 	ContentMediaType string   `json:"contentMediaType,omitempty"`
 	ContentSchema    *Schema  `json:"contentSchema,omitempty"` // JSON Schema for content-encoded data (e.g., SSE data field)
 	ReadOnly         *bool    `json:"readOnly,omitempty"`
 	WriteOnly        *bool    `json:"writeOnly,omitempty"`
 	Example          *string  `json:"example,omitempty"`
+	Deprecated       bool     `json:"deprecated,omitempty"`
+	// Sensitive marks values redacted from validation errors (Sensitive / @sensitive).
+	Sensitive bool `json:"x-sensitive,omitempty"`
+	// Groups lists the serialization views writing the property (Groups<G> / @groups).
+	Groups []string `json:"x-groups,omitempty"`
 }
 
 // Discriminator represents an OpenAPI discriminator object for discriminated unions.
@@ -79,14 +88,50 @@ type SchemaGenerator struct {
 	schemas map[string]*Schema
 	// registry holds the type metadata registry for resolving $ref types.
 	registry *metadata.TypeRegistry
+	// limits are documented on request schemas without a declared bound.
+	limits SchemaLimits
+	// responseOnly are the components registered by responses, without limits.
+	responseOnly map[string]bool
+	// response is set while a response is converted, in the serialization
+	// view view ("" for routes without one). See ViewSchema.
+	response bool
+	view     string
+}
+
+// SchemaLimits are the structural limits enforced by generated validators
+// (transforms.limits), documented as maxLength on strings, maxItems on arrays
+// and maxProperties on objects with an index signature of request schemas.
+// Responses are not validated, so their schemas don't document them. Declared
+// MaxLength and MaxItems constraints replace them. Zero fields are not documented.
+type SchemaLimits struct {
+	MaxArrayLength  int
+	MaxStringLength int
+	MaxProperties   int
 }
 
 // NewSchemaGenerator creates a new schema generator.
 func NewSchemaGenerator(registry *metadata.TypeRegistry) *SchemaGenerator {
 	return &SchemaGenerator{
-		schemas:  make(map[string]*Schema),
-		registry: registry,
+		schemas:      make(map[string]*Schema),
+		registry:     registry,
+		responseOnly: make(map[string]bool),
+	}
+}
+
+// register converts the component name with build unless it is registered,
+// behind a placeholder for recursive references. With limits, a component
+// registered by a response is converted again when a request uses it, so that
+// it documents the limits.
+func (g *SchemaGenerator) register(name string, build func() *Schema) {
+	if _, exists := g.schemas[name]; exists && (g.response || !g.responseOnly[name]) {
+		return
+	}
+	delete(g.responseOnly, name)
+	if g.response && g.limits != (SchemaLimits{}) {
+		g.responseOnly[name] = true
 	}
+	g.schemas[name] = &Schema{}
+	g.schemas[name] = build()
 }
 
 // Schemas returns all collected named schemas.
@@ -115,6 +160,78 @@ func (g *SchemaGenerator) MetadataToSchema(m *metadata.Metadata) *Schema {
 	return schema
 }
 
+// ViewSchema converts the response type m as serialized in view ("" for routes
+// without one): named types with serialization groups are registered as
+// <Name>_<view> components (<Name>_Response without a view), without the
+// grouped properties outside the view. The <Name> components keep every
+// property, for request bodies.
+func (g *SchemaGenerator) ViewSchema(m *metadata.Metadata, view string) *Schema {
+	prevResponse, prevView := g.response, g.view
+	g.response, g.view = true, view
+	defer func() { g.response, g.view = prevResponse, prevView }()
+	return g.MetadataToSchema(m)
+}
+
+// viewName returns the component name of the named type m in the current
+// view: name, suffixed with the view when a response of m has serialization
+// groups.
+func (g *SchemaGenerator) viewName(name string, m *metadata.Metadata) string {
+	if !g.response || !g.hasGroups(m, make(map[string]bool)) {
+		return name
+	}
+	if g.view == "" {
+		return name + "_Response"
+	}
+	return name + "_" + g.view
+}
+
+// hasGroups reports whether m contains properties with serialization groups,
+// at any depth, including referenced types.
+func (g *SchemaGenerator) hasGroups(m *metadata.Metadata, visited map[string]bool) bool {
+	if m == nil {
+		return false
+	}
+	switch m.Kind {
+	case metadata.KindObject:
+		for i := range m.Properties {
+			prop := &m.Properties[i]
+			if (prop.Constraints != nil && len(prop.Constraints.Groups) > 0) || g.hasGroups(&prop.Type, visited) {
+				return true
+			}
+		}
+		return m.IndexSignature != nil && g.hasGroups(&m.IndexSignature.ValueType, visited)
+	case metadata.KindArray:
+		return g.hasGroups(m.ElementType, visited)
+	case metadata.KindTuple:
+		for i := range m.Elements {
+			if g.hasGroups(&m.Elements[i].Type, visited) {
+				return true
+			}
+		}
+	case metadata.KindUnion:
+		for i := range m.UnionMembers {
+			if g.hasGroups(&m.UnionMembers[i], visited) {
+				return true
+			}
+		}
+	case metadata.KindIntersection:
+		for i := range m.IntersectionMembers {
+			if g.hasGroups(&m.IntersectionMembers[i], visited) {
+				return true
+			}
+		}
+	case metadata.KindRef:
+		if visited[m.Ref] {
+			return false
+		}
+		visited[m.Ref] = true
+		if resolved, ok := g.registry.Types[m.Ref]; ok {
+			return g.hasGroups(resolved, visited)
+		}
+	}
+	return false
+}
+
 // convertType handles the core type conversion.
 func (g *SchemaGenerator) convertType(m *metadata.Metadata) *Schema {
 	switch m.Kind {
@@ -155,7 +272,12 @@ func (g *SchemaGenerator) convertType(m *metadata.Metadata) *Schema {
 func (g *SchemaGenerator) convertAtomic(m *metadata.Metadata) *Schema {
 	switch m.Atomic {
 	case "string":
-		return &Schema{Type: "string"}
+		schema := &Schema{Type: "string"}
+		if g.limits.MaxStringLength > 0 && !g.response {
+			max := g.limits.MaxStringLength
+			schema.MaxLength = &max
+		}
+		return schema
 	case "number":
 		return &Schema{Type: "number"}
 	case "boolean":
@@ -182,13 +304,9 @@ func (g *SchemaGenerator) convertLiteral(m *metadata.Metadata) *Schema {
 func (g *SchemaGenerator) convertObject(m *metadata.Metadata) *Schema {
 	// If this is a named type, register it and return a $ref
 	if m.Name != "" {
-		if _, exists := g.schemas[m.Name]; !exists {
-			// Register a placeholder first to handle recursion
-			g.schemas[m.Name] = &Schema{}
-			schema := g.buildObjectSchema(m)
-			g.schemas[m.Name] = schema
-		}
-		return &Schema{Ref: "#/components/schemas/" + m.Name}
+		name := g.viewName(m.Name, m)
+		g.register(name, func() *Schema { return g.buildObjectSchema(m) })
+		return &Schema{Ref: "#/components/schemas/" + name}
 	}
 
 	// Anonymous inline object
@@ -204,6 +322,10 @@ func (g *SchemaGenerator) buildObjectSchema(m *metadata.Metadata) *Schema {
 
 	var requiredProps []string
 	for _, prop := range m.Properties {
+		// Response views only document the properties they serialize
+		if g.response && !prop.InView(g.view) {
+			continue
+		}
 		propSchema := g.MetadataToSchema(&prop.Type)
 
 		// Apply JSDoc constraints to the property schema
@@ -218,7 +340,7 @@ func (g *SchemaGenerator) buildObjectSchema(m *metadata.Metadata) *Schema {
 		if prop.Example != nil {
 			propSchema.Example = prop.Example
 		}
-		schema.Properties[prop.Name] = propSchema
+		schema.Properties[prop.WireName()] = propSchema
 		if prop.Readonly {
 			t := true
 			propSchema.ReadOnly = &t
@@ -227,9 +349,15 @@ func (g *SchemaGenerator) buildObjectSchema(m *metadata.Metadata) *Schema {
 			t := true
 			propSchema.WriteOnly = &t
 		}
+		if prop.Deprecated {
+			propSchema.Deprecated = true
+		}
+		if !g.response && prop.Constraints != nil {
+			propSchema.Groups = prop.Constraints.Groups
+		}
 
 		if prop.Required {
-			requiredProps = append(requiredProps, prop.Name)
+			requiredProps = append(requiredProps, prop.WireName())
 		}
 	}
 
@@ -241,6 +369,10 @@ func (g *SchemaGenerator) buildObjectSchema(m *metadata.Metadata) *Schema {
 	if m.IndexSignature != nil {
 		valSchema := g.MetadataToSchema(&m.IndexSignature.ValueType)
 		schema.AdditionalProperties = &SchemaOrBool{Schema: valSchema}
+		if g.limits.MaxProperties > 0 && !g.response {
+			max := g.limits.MaxProperties
+			schema.MaxProperties = &max
+		}
 	}
 
 	// @strict → additionalProperties: false
@@ -249,18 +381,67 @@ func (g *SchemaGenerator) buildObjectSchema(m *metadata.Metadata) *Schema {
 		schema.AdditionalProperties = &SchemaOrBool{Bool: &f}
 	}
 
+	if m.Rules != nil {
+		applyObjectRules(schema, m)
+	}
+
 	return schema
 }
 
+// applyObjectRules adds the cross-field rules of an object to its schema:
+// DependentRequired → dependentRequired, and each RequireOneOf group → oneOf of
+// single-key required sets (combined with allOf when there are several groups).
+// Object-level Validate predicates have no schema equivalent. Rules name the
+// TypeScript properties; the schema uses their wire names.
+func applyObjectRules(schema *Schema, m *metadata.Metadata) {
+	rules := m.Rules
+	wire := make(map[string]string, len(m.Properties))
+	for i := range m.Properties {
+		wire[m.Properties[i].Name] = m.Properties[i].WireName()
+	}
+	wireName := func(name string) string {
+		if w, ok := wire[name]; ok {
+			return w
+		}
+		return name
+	}
+	if len(rules.DependentRequired) > 0 {
+		schema.DependentRequired = make(map[string][]string, len(rules.DependentRequired))
+		for key, deps := range rules.DependentRequired {
+			wireDeps := make([]string, len(deps))
+			for i, dep := range deps {
+				wireDeps[i] = wireName(dep)
+			}
+			schema.DependentRequired[wireName(key)] = wireDeps
+		}
+	}
+	var groups []*Schema
+	for _, group := range rules.RequireOneOf {
+		oneOf := make([]*Schema, len(group.Keys))
+		for i, key := range group.Keys {
+			oneOf[i] = &Schema{Required: []string{wireName(key)}}
+		}
+		groups = append(groups, &Schema{OneOf: oneOf})
+	}
+	switch {
+	case len(groups) == 1:
+		schema.OneOf = groups[0].OneOf
+	case len(groups) > 1:
+		schema.AllOf = groups
+	}
+}
+
 // convertArray converts an array type.
 func (g *SchemaGenerator) convertArray(m *metadata.Metadata) *Schema {
-	if m.ElementType == nil {
-		return &Schema{Type: "array"}
+	schema := &Schema{Type: "array"}
+	if m.ElementType != nil {
+		schema.Items = g.MetadataToSchema(m.ElementType)
 	}
-	return &Schema{
-		Type:  "array",
-		Items: g.MetadataToSchema(m.ElementType),
+	if g.limits.MaxArrayLength > 0 && !g.response {
+		max := g.limits.MaxArrayLength
+		schema.MaxItems = &max
 	}
+	return schema
 }
 
 // convertTuple converts a tuple type to JSON Schema using prefixItems.
@@ -312,17 +493,33 @@ func (g *SchemaGenerator) convertUnion(m *metadata.Metadata) *Schema {
 
 	// Named complex union (e.g., type Result = SuccessDto | ErrorDto) → register as $ref
 	if m.Name != "" {
-		if _, exists := g.schemas[m.Name]; !exists {
-			g.schemas[m.Name] = &Schema{} // placeholder for recursion
-			schema := g.buildUnionSchema(m)
-			g.schemas[m.Name] = schema
-		}
-		return &Schema{Ref: "#/components/schemas/" + m.Name}
+		name := g.viewName(m.Name, m)
+		g.register(name, func() *Schema { return g.buildUnionSchema(m) })
+		return &Schema{Ref: "#/components/schemas/" + name}
 	}
 
 	return g.buildUnionSchema(m)
 }
 
+// discriminantWireName returns the JSON name of the discriminant property of
+// the discriminated union m, as declared by its first object member.
+func (g *SchemaGenerator) discriminantWireName(m *metadata.Metadata) string {
+	for i := range m.UnionMembers {
+		member := &m.UnionMembers[i]
+		if member.Kind == metadata.KindRef {
+			if resolved, ok := g.registry.Types[member.Ref]; ok {
+				member = resolved
+			}
+		}
+		for _, prop := range member.Properties {
+			if prop.Name == m.Discriminant.Property {
+				return prop.WireName()
+			}
+		}
+	}
+	return m.Discriminant.Property
+}
+
 // buildUnionSchema builds the actual schema for a union type (discriminated or general).
 func (g *SchemaGenerator) buildUnionSchema(m *metadata.Metadata) *Schema {
 	// Check for discriminated union
@@ -346,14 +543,15 @@ func (g *SchemaGenerator) buildUnionSchema(m *metadata.Metadata) *Schema {
 			}
 		}
 		schema := &Schema{OneOf: schemas}
+		propertyName := g.discriminantWireName(m)
 		if len(mapping) > 0 {
 			schema.Discriminator = &Discriminator{
-				PropertyName: m.Discriminant.Property,
+				PropertyName: propertyName,
 				Mapping:      mapping,
 			}
 		} else {
 			schema.Discriminator = &Discriminator{
-				PropertyName: m.Discriminant.Property,
+				PropertyName: propertyName,
 			}
 		}
 		return schema
@@ -377,15 +575,15 @@ func (g *SchemaGenerator) convertIntersection(m *metadata.Metadata) *Schema {
 
 	// Named intersection → register and return $ref
 	if m.Name != "" {
-		if _, exists := g.schemas[m.Name]; !exists {
-			g.schemas[m.Name] = &Schema{} // placeholder for recursion
+		name := g.viewName(m.Name, m)
+		g.register(name, func() *Schema {
 			var schemas []*Schema
 			for _, member := range m.IntersectionMembers {
 				schemas = append(schemas, g.MetadataToSchema(&member))
 			}
-			g.schemas[m.Name] = &Schema{AllOf: schemas}
-		}
-		return &Schema{Ref: "#/components/schemas/" + m.Name}
+			return &Schema{AllOf: schemas}
+		})
+		return &Schema{Ref: "#/components/schemas/" + name}
 	}
 
 	// Anonymous intersection
@@ -442,7 +640,7 @@ func (g *SchemaGenerator) convertNative(m *metadata.Metadata) *Schema {
 		return &Schema{Type: "array", Items: &Schema{Type: "number"}}
 	case "ArrayBuffer", "SharedArrayBuffer":
 		return &Schema{Type: "string", Format: "binary"}
-	case "File", "Blob":
+	case "File", "Blob", "StreamableFile", "Buffer", "Readable":
 		return &Schema{Type: "string", Format: "binary"}
 	case "Error":
 		return &Schema{
@@ -473,16 +671,12 @@ func (g *SchemaGenerator) convertRef(m *metadata.Metadata) *Schema {
 
 	// Resolve through registry (using the original ref name) and register under the schema name
 	if regType, ok := g.registry.Types[refName]; ok {
-		if _, exists := g.schemas[schemaName]; !exists {
-			// Register placeholder for recursion protection
-			g.schemas[schemaName] = &Schema{}
-			// Build the schema based on the registered type's Kind.
-			// We call buildRefSchema instead of convertType to avoid
-			// re-entering convertObject/convertUnion/convertIntersection
-			// which would see the placeholder and short-circuit.
-			schema := g.buildRefSchema(regType)
-			g.schemas[schemaName] = schema
-		}
+		schemaName = g.viewName(schemaName, regType)
+		// Build the schema based on the registered type's Kind.
+		// We call buildRefSchema instead of convertType to avoid
+		// re-entering convertObject/convertUnion/convertIntersection
+		// which would see the placeholder and short-circuit.
+		g.register(schemaName, func() *Schema { return g.buildRefSchema(regType) })
 	}
 
 	return &Schema{Ref: "#/components/schemas/" + schemaName}
@@ -593,6 +787,11 @@ func applyConstraints(schema *Schema, c *metadata.Constraints) {
 	if c.ContentMediaType != nil {
 		schema.ContentMediaType = *c.ContentMediaType
 	}
+	// Sensitive values are still written by serializers (masked with
+	// transforms.sensitiveMask), so they are not writeOnly.
+	if c.Sensitive != nil && *c.Sensitive {
+		schema.Sensitive = true
+	}
 	// String content checks map to pattern or x-extensions in OpenAPI.
 	// startsWith/endsWith/includes → pattern (best approximation).
 	// Note: these override any existing pattern. If @pattern is also set, it takes precedence above.
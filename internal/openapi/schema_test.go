@@ -1589,6 +1589,96 @@ func TestSchemaGenerator_StrictWithIndexSig(t *testing.T) {
 	}
 }
 
+// --- Cross-field object rules ---
+
+func TestSchemaGenerator_ObjectRules(t *testing.T) {
+	gen := NewSchemaGenerator(metadata.NewTypeRegistry())
+	str := metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string", Optional: true}
+	m := &metadata.Metadata{
+		Kind: metadata.KindObject,
+		Properties: []metadata.Property{
+			{Name: "email", Type: str},
+			{Name: "phone", Type: str},
+			{Name: "startDate", Type: str},
+			{Name: "endDate", Type: str},
+		},
+		Rules: &metadata.ObjectRules{
+			ValidateFn:        "isValidPeriod",
+			RequireOneOf:      []metadata.KeyGroup{{Keys: []string{"email", "phone"}}},
+			DependentRequired: map[string][]string{"endDate": {"startDate"}},
+		},
+	}
+	schema := gen.MetadataToSchema(m)
+
+	if got := schema.DependentRequired["endDate"]; len(got) != 1 || got[0] != "startDate" {
+		t.Errorf("expected dependentRequired endDate → [startDate], got %v", schema.DependentRequired)
+	}
+	if len(schema.OneOf) != 2 {
+		t.Fatalf("expected oneOf with 2 required sets, got %d", len(schema.OneOf))
+	}
+	if len(schema.OneOf[0].Required) != 1 || schema.OneOf[0].Required[0] != "email" {
+		t.Errorf("expected oneOf[0].required = [email], got %v", schema.OneOf[0].Required)
+	}
+	if len(schema.OneOf[1].Required) != 1 || schema.OneOf[1].Required[0] != "phone" {
+		t.Errorf("expected oneOf[1].required = [phone], got %v", schema.OneOf[1].Required)
+	}
+}
+
+func TestSchemaGenerator_ObjectRulesSeveralRequireOneOf(t *testing.T) {
+	gen := NewSchemaGenerator(metadata.NewTypeRegistry())
+	str := metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string", Optional: true}
+	m := &metadata.Metadata{
+		Kind: metadata.KindObject,
+		Properties: []metadata.Property{
+			{Name: "a", Type: str}, {Name: "b", Type: str}, {Name: "c", Type: str}, {Name: "d", Type: str},
+		},
+		Rules: &metadata.ObjectRules{
+			RequireOneOf: []metadata.KeyGroup{{Keys: []string{"a", "b"}}, {Keys: []string{"c", "d"}}},
+		},
+	}
+	schema := gen.MetadataToSchema(m)
+
+	// Each group keeps its own oneOf, combined with allOf
+	if len(schema.OneOf) != 0 {
+		t.Errorf("expected no top-level oneOf, got %d", len(schema.OneOf))
+	}
+	if len(schema.AllOf) != 2 || len(schema.AllOf[0].OneOf) != 2 || len(schema.AllOf[1].OneOf) != 2 {
+		t.Fatalf("expected allOf of 2 oneOf groups, got %+v", schema.AllOf)
+	}
+	if schema.AllOf[1].OneOf[0].Required[0] != "c" {
+		t.Errorf("expected second group to start with c, got %v", schema.AllOf[1].OneOf[0].Required)
+	}
+}
+
+func TestSchemaGenerator_ObjectRulesUseWireNames(t *testing.T) {
+	gen := NewSchemaGenerator(metadata.NewTypeRegistry())
+	str := metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string", Optional: true}
+	m := &metadata.Metadata{
+		Kind: metadata.KindObject,
+		Properties: []metadata.Property{
+			{Name: "email", Type: str, JsonName: "email_address"},
+			{Name: "phone", Type: str},
+			{Name: "startDate", Type: str, JsonName: "start_date"},
+			{Name: "endDate", Type: str, JsonName: "end_date"},
+		},
+		Rules: &metadata.ObjectRules{
+			RequireOneOf:      []metadata.KeyGroup{{Keys: []string{"email", "phone"}}},
+			DependentRequired: map[string][]string{"endDate": {"startDate"}},
+		},
+	}
+	schema := gen.MetadataToSchema(m)
+
+	if got := schema.DependentRequired["end_date"]; len(got) != 1 || got[0] != "start_date" {
+		t.Errorf("expected dependentRequired end_date → [start_date], got %v", schema.DependentRequired)
+	}
+	if _, ok := schema.DependentRequired["endDate"]; ok {
+		t.Error("dependentRequired should not use the TypeScript name endDate")
+	}
+	if len(schema.OneOf) != 2 || schema.OneOf[0].Required[0] != "email_address" || schema.OneOf[1].Required[0] != "phone" {
+		t.Errorf("expected oneOf required sets [email_address] and [phone], got %+v", schema.OneOf)
+	}
+}
+
 // contentTypeKeys returns the content type keys from a MediaType map for debugging.
 func contentTypeKeys(content map[string]MediaType) []string {
 	var keys []string
@@ -3259,6 +3349,18 @@ func TestSchemaGenerator_Blob(t *testing.T) {
 	}
 }
 
+func TestSchemaGenerator_BinaryDownloadTypes(t *testing.T) {
+	gen := NewSchemaGenerator(metadata.NewTypeRegistry())
+	for _, native := range []string{"StreamableFile", "Buffer", "Readable"} {
+		m := &metadata.Metadata{Kind: metadata.KindNative, NativeType: native}
+		schema := gen.MetadataToSchema(m)
+
+		if schema.Type != "string" || schema.Format != "binary" {
+			t.Errorf("%s: expected type='string' format='binary', got type=%q format=%q", native, schema.Type, schema.Format)
+		}
+	}
+}
+
 // TestSchemaGenerator_BrandedArrayItemConstraints verifies that constraints on
 // array element types (e.g., (string & tags.Pattern<...>)[]) are applied to the
 // items schema, not lost.
@@ -3351,3 +3453,208 @@ func TestSchemaGenerator_AtomicConstraints(t *testing.T) {
 		t.Errorf("expected format='email', got %q", schema.Format)
 	}
 }
+
+func TestSchemaGenerator_Limits(t *testing.T) {
+	gen := NewSchemaGenerator(metadata.NewTypeRegistry())
+	gen.limits = SchemaLimits{MaxArrayLength: 100, MaxStringLength: 50, MaxProperties: 20}
+	maxItems := 1000
+	m := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "tags", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}}, Required: true},
+		{Name: "ids", Type: metadata.Metadata{Kind: metadata.KindArray, ElementType: &metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}}, Required: true,
+			Constraints: &metadata.Constraints{MaxItems: &maxItems}},
+		{Name: "labels", Type: metadata.Metadata{Kind: metadata.KindObject, IndexSignature: &metadata.IndexSignature{
+			KeyType: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, ValueType: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}}}, Required: true},
+	}}
+	schema := gen.MetadataToSchema(m)
+
+	tags := schema.Properties["tags"]
+	if tags.MaxItems == nil || *tags.MaxItems != 100 {
+		t.Errorf("expected implicit maxItems 100 on tags, got %v", tags.MaxItems)
+	}
+	if tags.Items.MaxLength == nil || *tags.Items.MaxLength != 50 {
+		t.Errorf("expected implicit maxLength 50 on tags items, got %v", tags.Items.MaxLength)
+	}
+	if ids := schema.Properties["ids"]; ids.MaxItems == nil || *ids.MaxItems != 1000 {
+		t.Errorf("expected declared maxItems 1000 on ids, got %v", ids.MaxItems)
+	}
+	if labels := schema.Properties["labels"]; labels.MaxProperties == nil || *labels.MaxProperties != 20 {
+		t.Errorf("expected maxProperties 20 on labels, got %v", labels.MaxProperties)
+	}
+	if schema.MaxProperties != nil {
+		t.Errorf("expected no maxProperties without an index signature, got %v", *schema.MaxProperties)
+	}
+}
+
+func TestSchemaGenerator_LimitsOnRequestsOnly(t *testing.T) {
+	registry := metadata.NewTypeRegistry()
+	registry.Register("UserDto", &metadata.Metadata{Kind: metadata.KindObject, Name: "UserDto", Properties: []metadata.Property{
+		{Name: "name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+	}})
+	gen := NewSchemaGenerator(registry)
+	gen.limits = SchemaLimits{MaxArrayLength: 100, MaxStringLength: 50}
+	ref := metadata.Metadata{Kind: metadata.KindRef, Ref: "UserDto"}
+
+	// Responses are not validated: no implicit bounds
+	list := gen.ViewSchema(&metadata.Metadata{Kind: metadata.KindArray, ElementType: &ref}, "")
+	if list.MaxItems != nil {
+		t.Errorf("expected no maxItems on a response array, got %v", *list.MaxItems)
+	}
+	if name := gen.Schemas()["UserDto"].Properties["name"]; name.MaxLength != nil {
+		t.Errorf("expected no maxLength on a response-only component, got %v", *name.MaxLength)
+	}
+
+	// A request using the same component documents the bounds
+	gen.MetadataToSchema(&ref)
+	if name := gen.Schemas()["UserDto"].Properties["name"]; name.MaxLength == nil || *name.MaxLength != 50 {
+		t.Errorf("expected maxLength 50 once a request uses the component, got %v", name.MaxLength)
+	}
+}
+
+func TestSchemaGenerator_Sensitive(t *testing.T) {
+	gen := NewSchemaGenerator(metadata.NewTypeRegistry())
+	sensitive := true
+	m := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "password", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true,
+			Constraints: &metadata.Constraints{Sensitive: &sensitive}},
+		{Name: "username", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+	}}
+	schema := gen.MetadataToSchema(m)
+
+	password := schema.Properties["password"]
+	if password.WriteOnly != nil {
+		t.Error("expected sensitive password not to be writeOnly, as serializers write it")
+	}
+	if !password.Sensitive {
+		t.Error("expected x-sensitive on password")
+	}
+	if username := schema.Properties["username"]; username.Sensitive {
+		t.Error("expected username not to be sensitive")
+	}
+	data, err := json.Marshal(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"x-sensitive":true`) {
+		t.Errorf("expected x-sensitive in %s", data)
+	}
+}
+
+func TestSchemaGenerator_DeprecatedProperty(t *testing.T) {
+	gen := NewSchemaGenerator(metadata.NewTypeRegistry())
+	m := &metadata.Metadata{Kind: metadata.KindObject, Properties: []metadata.Property{
+		{Name: "nickname", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Deprecated: true},
+		{Name: "displayName", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}},
+	}}
+	schema := gen.MetadataToSchema(m)
+
+	if !schema.Properties["nickname"].Deprecated {
+		t.Error("expected nickname to be deprecated")
+	}
+	if schema.Properties["displayName"].Deprecated {
+		t.Error("expected displayName not to be deprecated")
+	}
+	data, err := json.Marshal(schema.Properties["nickname"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"deprecated":true`) {
+		t.Errorf("expected deprecated in %s", data)
+	}
+}
+
+func TestSchemaGenerator_JSONNames(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	reg.Register("Cat", &metadata.Metadata{Kind: metadata.KindObject, Name: "Cat", Properties: []metadata.Property{
+		{Name: "petType", JsonName: "pet_type", Type: metadata.Metadata{Kind: metadata.KindLiteral, LiteralValue: "cat"}, Required: true},
+		{Name: "firstName", JsonName: "first_name", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+		{Name: "age", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number"}},
+	}})
+	gen := NewSchemaGenerator(reg)
+	schema := gen.MetadataToSchema(reg.Types["Cat"])
+	if schema.Ref == "" {
+		t.Fatalf("expected $ref, got %+v", schema)
+	}
+	cat := gen.Schemas()["Cat"]
+	if _, ok := cat.Properties["first_name"]; !ok {
+		t.Errorf("expected first_name property, got %v", cat.Properties)
+	}
+	if _, ok := cat.Properties["firstName"]; ok {
+		t.Error("expected no firstName property")
+	}
+	if strings.Join(cat.Required, ",") != "pet_type,first_name" {
+		t.Errorf("expected JSON names in required, got %v", cat.Required)
+	}
+
+	union := &metadata.Metadata{
+		Kind:         metadata.KindUnion,
+		UnionMembers: []metadata.Metadata{{Kind: metadata.KindRef, Ref: "Cat"}},
+		Discriminant: &metadata.Discriminant{Property: "petType", Mapping: map[string]int{"cat": 0}},
+	}
+	if d := gen.MetadataToSchema(union).Discriminator; d == nil || d.PropertyName != "pet_type" {
+		t.Errorf("expected discriminator pet_type, got %+v", d)
+	}
+}
+
+func TestSchemaGenerator_Views(t *testing.T) {
+	reg := metadata.NewTypeRegistry()
+	reg.Register("Note", &metadata.Metadata{Kind: metadata.KindObject, Name: "Note", Properties: []metadata.Property{
+		{Name: "text", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+	}})
+	reg.Register("User", &metadata.Metadata{Kind: metadata.KindObject, Name: "User", Properties: []metadata.Property{
+		{Name: "id", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true},
+		{Name: "email", Type: metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string"}, Required: true, Constraints: &metadata.Constraints{Groups: []string{"admin", "owner"}}},
+		{Name: "note", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "Note"}, Required: true, Constraints: &metadata.Constraints{Groups: []string{"owner"}}},
+	}})
+	ref := &metadata.Metadata{Kind: metadata.KindRef, Ref: "User"}
+	gen := NewSchemaGenerator(reg)
+
+	// The full schema documents every property, with the views writing grouped ones
+	gen.MetadataToSchema(ref)
+	user := gen.Schemas()["User"]
+	if len(user.Properties) != 3 {
+		t.Fatalf("expected 3 properties, got %v", user.Properties)
+	}
+	if g := user.Properties["email"].Groups; strings.Join(g, ",") != "admin,owner" {
+		t.Errorf("expected x-groups admin,owner, got %v", g)
+	}
+
+	// A view gets its own component, without the properties of other views
+	schema := gen.ViewSchema(&metadata.Metadata{Kind: metadata.KindArray, ElementType: ref}, "admin")
+	if schema.Items == nil || schema.Items.Ref != "#/components/schemas/User_admin" {
+		t.Fatalf("expected User_admin items, got %+v", schema.Items)
+	}
+	admin := gen.Schemas()["User_admin"]
+	if _, ok := admin.Properties["note"]; ok {
+		t.Error("expected no note property in the admin view")
+	}
+	if strings.Join(admin.Required, ",") != "id,email" {
+		t.Errorf("expected id,email required, got %v", admin.Required)
+	}
+	if admin.Properties["email"].Groups != nil {
+		t.Errorf("expected no x-groups in a view, got %v", admin.Properties["email"].Groups)
+	}
+
+	// Types without groups keep their component in every view
+	gen.ViewSchema(ref, "owner")
+	if n := gen.Schemas()["User_owner"].Properties["note"]; n == nil || n.Ref != "#/components/schemas/Note" {
+		t.Errorf("expected note to reference Note, got %+v", n)
+	}
+	if _, ok := gen.Schemas()["Note_owner"]; ok {
+		t.Error("expected no Note_owner component")
+	}
+
+	// Responses without a view only document the properties without groups
+	schema = gen.ViewSchema(ref, "")
+	if schema.Ref != "#/components/schemas/User_Response" {
+		t.Fatalf("expected User_Response, got %+v", schema)
+	}
+	if props := gen.Schemas()["User_Response"].Properties; len(props) != 1 || props["id"] == nil {
+		t.Errorf("expected only id in the default view, got %v", props)
+	}
+	if len(gen.Schemas()["User"].Properties) != 3 {
+		t.Error("expected the User component to keep every property")
+	}
+	if gen.ViewSchema(&metadata.Metadata{Kind: metadata.KindRef, Ref: "Note"}, "").Ref != "#/components/schemas/Note" {
+		t.Error("expected types without groups to keep their component")
+	}
+}
@@ -0,0 +1,133 @@
+package openapi
+
+import (
+	"fmt"
+)
+
+// ValidationErrorResponse documents the response of failed request validation
+// (transforms.validationError), sent by TsgonestValidationFilter.
+type ValidationErrorResponse struct {
+	Status int    // 4xx status code (default: 400)
+	Format string // "json" (default) or "problem+json" (RFC 9457)
+	// HasCompanion reports whether a type has a generated companion, i.e.
+	// whether the controller rewrite validates it (see analyzer.Route.ValidatesRequest).
+	// Nil treats every type as validated.
+	HasCompanion func(typeName string) bool
+}
+
+// Component schema names of the validation error response bodies. A user
+// schema with the same name keeps it; these then get a Tsgonest prefix
+// (see validationErrorNames).
+const (
+	validationErrorDetailSchema = "ValidationErrorDetail"
+	validationErrorBodySchema   = "ValidationErrorBody"
+	validationProblemSchema     = "ValidationProblem"
+)
+
+// addValidationErrorResponse documents the validation error response on op,
+// unless the route already documents its status (e.g. with @throws). It
+// returns the schema of the response body, whose $ref is set once the
+// component names are known (see validationErrorNames), or nil.
+func addValidationErrorResponse(op *Operation, ve *ValidationErrorResponse) *Schema {
+	status := ve.Status
+	if status == 0 {
+		status = 400
+	}
+	code := fmt.Sprintf("%d", status)
+	if _, exists := op.Responses[code]; exists {
+		return nil
+	}
+	contentType := "application/json"
+	if ve.Format == "problem+json" {
+		contentType = "application/problem+json"
+	}
+	ref := &Schema{}
+	op.Responses[code] = &Response{
+		Description: "Validation failed",
+		Content: map[string]MediaType{
+			contentType: {Schema: ref},
+		},
+	}
+	return ref
+}
+
+// validationErrorNames maps the component schema names of the validation
+// error bodies to names not used by the given schemas.
+func validationErrorNames(schemas map[string]*Schema) map[string]string {
+	names := make(map[string]string, 3)
+	for _, name := range []string{validationErrorDetailSchema, validationErrorBodySchema, validationProblemSchema} {
+		candidate := name
+		for i := 1; ; i++ {
+			if _, taken := schemas[candidate]; !taken {
+				break
+			}
+			candidate = "Tsgonest" + name
+			if i > 1 {
+				candidate += fmt.Sprintf("%d", i)
+			}
+		}
+		names[name] = candidate
+	}
+	return names
+}
+
+// validationErrorBodyName returns the component schema name of the response
+// body in the format of ve.
+func validationErrorBodyName(ve *ValidationErrorResponse, names map[string]string) string {
+	if ve.Format == "problem+json" {
+		return names[validationProblemSchema]
+	}
+	return names[validationErrorBodySchema]
+}
+
+// validationErrorSchemas returns the component schemas of the validation
+// error response body in the format of ve, under the given names.
+func validationErrorSchemas(ve *ValidationErrorResponse, names map[string]string) map[string]*Schema {
+	str := func() *Schema { return &Schema{Type: "string"} }
+	detail := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"path":     {Type: "string", Description: "Path to the invalid value (e.g. \"input.email\")."},
+			"expected": {Type: "string", Description: "Expected type or constraint."},
+			"received": {Type: "string", Description: "Received type or value."},
+			"code":     {Type: "string", Description: "Code of the failed check (`type` for type mismatches)."},
+			"params": {
+				Type:                 "object",
+				Description:          "Parameters of the failed check.",
+				AdditionalProperties: &SchemaOrBool{Schema: &Schema{AnyOf: []*Schema{str(), {Type: "number"}}}},
+			},
+			"message": {Type: "string", Description: "Custom message of the check."},
+		},
+		Required: []string{"path", "expected", "received"},
+	}
+	errors := &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/" + names[validationErrorDetailSchema]}}
+
+	schemas := map[string]*Schema{names[validationErrorDetailSchema]: detail}
+	if ve.Format == "problem+json" {
+		schemas[names[validationProblemSchema]] = &Schema{
+			Type:        "object",
+			Description: "RFC 9457 problem details of failed request validation.",
+			Properties: map[string]*Schema{
+				"type":     {Type: "string", Format: "uri-reference"},
+				"title":    str(),
+				"status":   {Type: "integer"},
+				"detail":   str(),
+				"instance": {Type: "string", Format: "uri-reference"},
+				"errors":   errors,
+			},
+			Required: []string{"type", "title", "status", "detail", "errors"},
+		}
+	} else {
+		schemas[names[validationErrorBodySchema]] = &Schema{
+			Type:        "object",
+			Description: "Body of failed request validation.",
+			Properties: map[string]*Schema{
+				"statusCode": {Type: "integer"},
+				"message":    str(),
+				"errors":     errors,
+			},
+			Required: []string{"statusCode", "message", "errors"},
+		}
+	}
+	return schemas
+}
@@ -1,11 +1,14 @@
 package rewrite
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/tsgonest/tsgonest/internal/analyzer"
+	"github.com/tsgonest/tsgonest/internal/codegen"
 	"github.com/tsgonest/tsgonest/internal/metadata"
 )
 
@@ -24,17 +27,44 @@ type sseTransform struct {
 	entries    []sseTransformEntry
 }
 
+// bodyFunc returns the companion function checking @Body() values of typeName
+// and whether it is async. Every request function is built on assertRequest,
+// so read-only, deprecated and JSON-named properties are handled for hydrated
+// and async bodies too:
+//   - hydrateRequest<Type>: assertRequest, async validators, then class instances (HydrateTypes)
+//   - assertAsyncRequest<Type>: assertRequest then async validators (AsyncTypes)
+//   - assertRequest<Type> (RequestTypes), or assert<Type>
+func (ctx *RewriteContext) bodyFunc(typeName string) (string, bool) {
+	switch {
+	case ctx.HydrateTypes[typeName]:
+		return "hydrateRequest", ctx.AsyncTypes[typeName]
+	case ctx.AsyncTypes[typeName]:
+		return "assertAsyncRequest", true
+	case ctx.RequestTypes[typeName]:
+		return "assertRequest", false
+	}
+	return "assert", false
+}
+
 // rewriteController injects @Body() parameter validation and return value
 // transformation into a controller file's emitted JS.
-// For body params: inserts `paramName = assertTypeName(paramName);` at method start.
-// For return values: wraps `return EXPR;` with `return transformTypeName(await EXPR);`.
+// For body params: inserts `paramName = assertTypeName(paramName);` at method start,
+// or the request function of the type chosen by ctx.bodyFunc. Whole-object
+// query/param types listed in ctx.AsyncTypes are awaited through `assertAsyncTypeName`.
+// Methods awaiting a check are made async.
+// For return values: wraps `return EXPR;` with `return transformTypeName(await EXPR);`,
+// or the transform of the route's serialization view (transformTypeName_view).
 // For @EventStream routes: injects Reflect.defineMetadata with per-event assert/stringify.
-func rewriteController(text string, outputFile string, controllers []analyzer.ControllerInfo, companionMap map[string]string, moduleFormat string) string {
+func rewriteController(text string, outputFile string, controllers []analyzer.ControllerInfo, ctx *RewriteContext) string {
+	companionMap := ctx.CompanionMap
+	moduleFormat := ctx.ModuleFormat
 	// Collect all body parameters with named types from matching controllers
 	type bodyValidation struct {
 		methodName string
 		paramName  string
 		typeName   string
+		fn         string // companion function: assert, assertRequest, hydrateRequest, ...
+		async      bool   // fn returns a promise, awaited in an async method
 	}
 
 	// Collect return transformations
@@ -42,6 +72,7 @@ func rewriteController(text string, outputFile string, controllers []analyzer.Co
 		methodName string
 		typeName   string
 		isArray    bool
+		view       string
 	}
 
 	// primitiveReturnTransform holds inline serialization info for primitive return types.
@@ -54,9 +85,10 @@ func rewriteController(text string, outputFile string, controllers []analyzer.Co
 
 	// scalarCoercion holds info for inline number/boolean coercion on named scalar params
 	type scalarCoercion struct {
-		methodName string
-		paramName  string
-		atomic     string // "number" or "boolean"
+		methodName   string
+		paramName    string
+		atomic       string // "number" or "boolean", or "" when only a default applies
+		defaultValue string // JS literal filled in when the param is absent (Default<V>)
 	}
 
 	var validations []bodyValidation
@@ -64,8 +96,12 @@ func rewriteController(text string, outputFile string, controllers []analyzer.Co
 	var primitiveTransforms []primitiveReturnTransform
 	var scalarCoercions []scalarCoercion
 	var sseTransforms []sseTransform
-	neededTypes := make(map[string]bool)
-	neededTransformTypes := make(map[string]bool)
+	// Companion functions checking parameters, by function and type name
+	type validatorFunc struct{ fn, typeName string }
+	neededValidators := make(map[validatorFunc]bool)
+	// Return types are serialized per view (see analyzer.Route.View)
+	type viewType struct{ typeName, view string }
+	neededTransformTypes := make(map[viewType]bool)
 	neededSSETypes := make(map[string]bool)
 	needsHelpersImport := false
 	needsSseInterceptor := false
@@ -105,12 +141,15 @@ func rewriteController(text string, outputFile string, controllers []analyzer.Co
 							continue
 						}
 					}
+					fn, async := ctx.bodyFunc(typeName)
 					validations = append(validations, bodyValidation{
 						methodName: route.MethodName,
 						paramName:  paramName,
 						typeName:   typeName,
+						fn:         fn,
+						async:      async,
 					})
-					neededTypes[typeName] = true
+					neededValidators[validatorFunc{fn, typeName}] = true
 
 				case "query", "headers", "param":
 					if param.Name == "" && param.TypeName != "" {
@@ -126,25 +165,39 @@ func rewriteController(text string, outputFile string, controllers []analyzer.Co
 						if paramName == "" {
 							continue
 						}
+						fn, async := "assert", ctx.AsyncTypes[typeName]
+						if async {
+							fn = "assertAsync"
+						}
 						validations = append(validations, bodyValidation{
 							methodName: route.MethodName,
 							paramName:  paramName,
 							typeName:   typeName,
+							fn:         fn,
+							async:      async,
 						})
-						neededTypes[typeName] = true
+						neededValidators[validatorFunc{fn, typeName}] = true
 					} else if param.Name != "" && param.Category != "headers" {
-						// Individual named scalar: inline coercion (no companion needed)
-						if param.Type.Kind == metadata.KindAtomic && (param.Type.Atomic == "number" || param.Type.Atomic == "boolean") {
+						// Individual named scalar: inline default and coercion (no companion needed)
+						if param.Type.Kind == metadata.KindAtomic {
 							paramName := param.LocalName
 							if paramName == "" {
 								paramName = param.Name
 							}
-							scalarCoercions = append(scalarCoercions, scalarCoercion{
+							sc := scalarCoercion{
 								methodName: route.MethodName,
 								paramName:  paramName,
-								atomic:     param.Type.Atomic,
-							})
-							needsHelpersImport = true
+							}
+							if param.Type.Atomic == "number" || param.Type.Atomic == "boolean" {
+								sc.atomic = param.Type.Atomic
+								needsHelpersImport = true
+							}
+							if c := param.Type.Constraints; c != nil && c.Default != nil {
+								sc.defaultValue = codegen.DefaultToJSLiteral(*c.Default, &param.Type)
+							}
+							if sc.atomic != "" || sc.defaultValue != "" {
+								scalarCoercions = append(scalarCoercions, sc)
+							}
 						}
 					}
 				}
@@ -188,6 +241,11 @@ func rewriteController(text string, outputFile string, controllers []analyzer.Co
 				continue
 			}
 
+			// Binary downloads (StreamableFile, Buffer, Readable) are sent as-is
+			if route.IsBinaryResponse {
+				continue
+			}
+
 			// Check for primitive return types first — these are serialized inline
 			// (no companion file needed). This handles string, number, boolean returns.
 			primitiveAtomic, primitiveNullable := resolvePrimitiveReturn(&route.ReturnType)
@@ -213,8 +271,9 @@ func rewriteController(text string, outputFile string, controllers []analyzer.Co
 				methodName: route.MethodName,
 				typeName:   returnTypeName,
 				isArray:    isArray,
+				view:       route.View,
 			})
-			neededTransformTypes[returnTypeName] = true
+			neededTransformTypes[viewType{returnTypeName, route.View}] = true
 		}
 	}
 
@@ -224,13 +283,20 @@ func rewriteController(text string, outputFile string, controllers []analyzer.Co
 
 	// Inject validation calls into method bodies
 	for _, v := range validations {
-		assertFunc := companionFuncName("assert", v.typeName)
-		assertLine := "    " + v.paramName + " = " + assertFunc + "(" + v.paramName + ");"
-		text = injectAtMethodStart(text, v.methodName, assertLine)
+		call := companionFuncName(v.fn, v.typeName) + "(" + v.paramName + ")"
+		if v.async {
+			call = "await " + call
+			text = makeMethodAsync(text, v.methodName)
+		}
+		text = injectAtMethodStart(text, v.methodName, "    "+v.paramName+" = "+call+";")
 	}
 
-	// Inject inline scalar coercion for individual @Param/@Query params
+	// Inject inline scalar defaults and coercion for individual @Param/@Query params
 	for _, sc := range scalarCoercions {
+		var lines []string
+		if sc.defaultValue != "" {
+			lines = append(lines, fmt.Sprintf("    if (%s === undefined) %s = %s;", sc.paramName, sc.paramName, sc.defaultValue))
+		}
 		var coercionCode string
 		switch sc.atomic {
 		case "number":
@@ -241,18 +307,19 @@ func rewriteController(text string, outputFile string, controllers []analyzer.Co
 				sc.paramName, sc.paramName, sc.paramName, sc.paramName, sc.paramName, sc.paramName)
 		}
 		if coercionCode != "" {
-			text = injectAtMethodStart(text, sc.methodName, coercionCode)
+			lines = append(lines, coercionCode)
 		}
+		text = injectAtMethodStart(text, sc.methodName, strings.Join(lines, "\n"))
 	}
 
 	// Wrap return statements with stringify calls
 	for _, tr := range transforms {
 		if tr.isArray {
 			// Arrays: serialize each element and join into JSON array string
-			serializeFunc := companionFuncName("serialize", tr.typeName)
+			serializeFunc := codegen.ViewFuncName("serialize", tr.typeName, tr.view)
 			text = wrapReturnsInMethod(text, tr.methodName, serializeFunc, tr.isArray)
 		} else {
-			stringifyFunc := companionFuncName("stringify", tr.typeName)
+			stringifyFunc := codegen.ViewFuncName("stringify", tr.typeName, tr.view)
 			text = wrapReturnsInMethod(text, tr.methodName, stringifyFunc, false)
 		}
 	}
@@ -269,22 +336,34 @@ func rewriteController(text string, outputFile string, controllers []analyzer.Co
 
 	// Generate companion imports for the types we need
 	var markerCalls []MarkerCall
-	for typeName := range neededTypes {
+	validators := make([]validatorFunc, 0, len(neededValidators))
+	for vf := range neededValidators {
+		validators = append(validators, vf)
+	}
+	sort.Slice(validators, func(i, j int) bool {
+		if validators[i].fn != validators[j].fn {
+			return validators[i].fn < validators[j].fn
+		}
+		return validators[i].typeName < validators[j].typeName
+	})
+	for _, vf := range validators {
 		markerCalls = append(markerCalls, MarkerCall{
-			FunctionName: "assert",
-			TypeName:     typeName,
+			FunctionName: vf.fn,
+			TypeName:     vf.typeName,
 		})
 	}
-	for typeName := range neededTransformTypes {
+	for vt := range neededTransformTypes {
 		// For arrays, we need serialize; for non-arrays, we need stringify
 		// Import both to be safe since companion files export both
 		markerCalls = append(markerCalls, MarkerCall{
 			FunctionName: "stringify",
-			TypeName:     typeName,
+			TypeName:     vt.typeName,
+			View:         vt.view,
 		})
 		markerCalls = append(markerCalls, MarkerCall{
 			FunctionName: "serialize",
-			TypeName:     typeName,
+			TypeName:     vt.typeName,
+			View:         vt.view,
 		})
 	}
 	// Import assert + stringify for SSE variant data types
@@ -828,6 +907,71 @@ func injectClassInterceptor(text string, controllers []analyzer.ControllerInfo,
 	return text
 }
 
+// ValidationErrorOptions are the options of the TsgonestValidationFilter
+// injected on controllers with request validation (transforms.validationError).
+type ValidationErrorOptions struct {
+	Status int    `json:"status,omitempty"`
+	Format string `json:"format,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Title  string `json:"title,omitempty"`
+}
+
+// injectValidationFilter adds TsgonestValidationFilter, constructed with opts,
+// to the filters of the controller classes with request validation, so their
+// validation errors are sent with the configured status and body format.
+//
+// It generates code like:
+//
+//	ClassName = __decorate([
+//	    (0, common_1.UseFilters)(new TsgonestValidationFilter({"status":422,"format":"problem+json"})),
+//
+// ES modules have no common_1 binding, so UseFilters is imported from
+// @nestjs/common under an alias that cannot clash with the file's own imports.
+func injectValidationFilter(text string, controllers []analyzer.ControllerInfo, companionMap map[string]string, opts *ValidationErrorOptions, moduleFormat string) string {
+	optsJSON, err := json.Marshal(opts)
+	if err != nil {
+		return text
+	}
+	hasCompanion := func(typeName string) bool {
+		_, ok := companionMap[typeName]
+		return ok
+	}
+	injected := false
+	for _, ctrl := range controllers {
+		validated := false
+		for i := range ctrl.Routes {
+			if ctrl.Routes[i].ValidatesRequest(hasCompanion) {
+				validated = true
+				break
+			}
+		}
+		if !validated {
+			continue
+		}
+		pattern := regexp.MustCompile(regexp.QuoteMeta(ctrl.Name) + `\s*=\s*__decorate\(\[`)
+		loc := pattern.FindStringIndex(text)
+		if loc == nil {
+			continue
+		}
+		useFilters := "__UseFilters"
+		if moduleFormat == "cjs" {
+			useFilters = "(0, common_1.UseFilters)"
+		}
+		filterLine := "\n    " + useFilters + "(new TsgonestValidationFilter(" + string(optsJSON) + ")),"
+		text = text[:loc[1]] + filterLine + text[loc[1]:]
+		injected = true
+	}
+	if !injected {
+		return text
+	}
+	importLines := `import { TsgonestValidationFilter } from "@tsgonest/runtime";` + "\n" +
+		`import { UseFilters as __UseFilters } from "@nestjs/common";`
+	if moduleFormat == "cjs" {
+		importLines = `const { TsgonestValidationFilter } = require("@tsgonest/runtime");`
+	}
+	return importLines + "\n" + text
+}
+
 // injectSSETransforms injects Reflect.defineMetadata for SSE per-event transform
 // maps after the method-level __decorate call for the given method.
 //
@@ -39,7 +39,7 @@ func TestRewriteController_BodyValidation(t *testing.T) {
 		"CreateUserDto": "/dist/user.dto.CreateUserDto.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	if !strings.Contains(result, "assertCreateUserDto(body)") {
 		t.Errorf("expected assert call injection, got:\n%s", result)
@@ -49,6 +49,355 @@ func TestRewriteController_BodyValidation(t *testing.T) {
 	}
 }
 
+func TestRewriteController_BodyHydration(t *testing.T) {
+	input := `class UserController {
+    async create(body) {
+        return this.service.create(body);
+    }
+    async search(query) {
+        return this.service.search(query);
+    }
+}`
+
+	controllers := []analyzer.ControllerInfo{
+		{
+			Name:       "UserController",
+			SourceFile: "/src/user.controller.ts",
+			Routes: []analyzer.Route{
+				{
+					OperationID: "create",
+					MethodName:  "create",
+					Parameters: []analyzer.RouteParameter{
+						{
+							Category:  "body",
+							LocalName: "body",
+							Type:      metadata.Metadata{Kind: metadata.KindRef, Ref: "CreateUserDto"},
+						},
+					},
+				},
+				{
+					OperationID: "search",
+					MethodName:  "search",
+					Parameters: []analyzer.RouteParameter{
+						{
+							Category:  "query",
+							LocalName: "query",
+							TypeName:  "CreateUserDto",
+							Type:      metadata.Metadata{Kind: metadata.KindRef, Ref: "CreateUserDto"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	companionMap := map[string]string{
+		"CreateUserDto": "/dist/user.dto.CreateUserDto.tsgonest.js",
+	}
+
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, HydrateTypes: map[string]bool{"CreateUserDto": true}, ModuleFormat: "esm"})
+
+	if !strings.Contains(result, "body = hydrateRequestCreateUserDto(body);") {
+		t.Errorf("expected hydrateRequest call injection for @Body(), got:\n%s", result)
+	}
+	// Only @Body() params are hydrated.
+	if !strings.Contains(result, "query = assertCreateUserDto(query);") {
+		t.Errorf("expected assert call injection for @Query(), got:\n%s", result)
+	}
+	if !strings.Contains(result, `import { assertCreateUserDto, hydrateRequestCreateUserDto } from "./user.dto.CreateUserDto.tsgonest.js"`) {
+		t.Errorf("expected companion import, got:\n%s", result)
+	}
+}
+
+func TestRewriteController_BodyReadOnly(t *testing.T) {
+	input := `class UserController {
+    async create(body) {
+        return this.service.create(body);
+    }
+    async search(query) {
+        return this.service.search(query);
+    }
+}`
+
+	controllers := []analyzer.ControllerInfo{
+		{
+			Name:       "UserController",
+			SourceFile: "/src/user.controller.ts",
+			Routes: []analyzer.Route{
+				{
+					OperationID: "create",
+					MethodName:  "create",
+					Parameters: []analyzer.RouteParameter{
+						{
+							Category:  "body",
+							LocalName: "body",
+							Type:      metadata.Metadata{Kind: metadata.KindRef, Ref: "UserDto"},
+						},
+					},
+				},
+				{
+					OperationID: "search",
+					MethodName:  "search",
+					Parameters: []analyzer.RouteParameter{
+						{
+							Category:  "query",
+							LocalName: "query",
+							TypeName:  "UserDto",
+							Type:      metadata.Metadata{Kind: metadata.KindRef, Ref: "UserDto"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	companionMap := map[string]string{
+		"UserDto": "/dist/user.dto.UserDto.tsgonest.js",
+	}
+
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, RequestTypes: map[string]bool{"UserDto": true}, ModuleFormat: "esm"})
+
+	if !strings.Contains(result, "body = assertRequestUserDto(body);") {
+		t.Errorf("expected assertRequest call injection for @Body(), got:\n%s", result)
+	}
+	// Only @Body() params strip or reject read-only properties.
+	if !strings.Contains(result, "query = assertUserDto(query);") {
+		t.Errorf("expected assert call injection for @Query(), got:\n%s", result)
+	}
+	if !strings.Contains(result, `import { assertUserDto, assertRequestUserDto } from "./user.dto.UserDto.tsgonest.js"`) {
+		t.Errorf("expected companion import, got:\n%s", result)
+	}
+}
+
+func TestRewriteController_AsyncValidators(t *testing.T) {
+	input := `class UserController {
+    create(body) {
+        return this.service.create(body);
+    }
+    async signup(body) {
+        return this.service.signup(body);
+    }
+}`
+
+	controllers := []analyzer.ControllerInfo{
+		{
+			Name:       "UserController",
+			SourceFile: "/src/user.controller.ts",
+			Routes: []analyzer.Route{
+				{
+					OperationID: "create",
+					MethodName:  "create",
+					Parameters: []analyzer.RouteParameter{
+						{Category: "body", LocalName: "body", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "CreateUserDto"}},
+					},
+				},
+				{
+					OperationID: "signup",
+					MethodName:  "signup",
+					Parameters: []analyzer.RouteParameter{
+						{Category: "body", LocalName: "body", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "SignupDto"}},
+					},
+				},
+			},
+		},
+	}
+
+	companionMap := map[string]string{
+		"CreateUserDto": "/dist/user.dto.CreateUserDto.tsgonest.js",
+		"SignupDto":     "/dist/user.dto.SignupDto.tsgonest.js",
+	}
+	asyncTypes := map[string]bool{"CreateUserDto": true, "SignupDto": true}
+
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, HydrateTypes: map[string]bool{"SignupDto": true}, AsyncTypes: asyncTypes, ModuleFormat: "esm"})
+
+	// Sync handlers are made async so the validators can be awaited.
+	if !strings.Contains(result, "async create(body) {\n    body = await assertAsyncRequestCreateUserDto(body);") {
+		t.Errorf("expected awaited assertAsyncRequest injection, got:\n%s", result)
+	}
+	// hydrateRequest asserts once, then builds the instances.
+	if !strings.Contains(result, "body = await hydrateRequestSignupDto(body);") {
+		t.Errorf("expected awaited hydrateRequest injection, got:\n%s", result)
+	}
+	if strings.Contains(result, "async async") {
+		t.Errorf("expected async handlers to stay unchanged, got:\n%s", result)
+	}
+	if !strings.Contains(result, `import { assertAsyncRequestCreateUserDto } from "./user.dto.CreateUserDto.tsgonest.js"`) {
+		t.Errorf("expected assertAsyncRequest import, got:\n%s", result)
+	}
+	if !strings.Contains(result, `import { hydrateRequestSignupDto } from "./user.dto.SignupDto.tsgonest.js"`) {
+		t.Errorf("expected hydrateRequest import, got:\n%s", result)
+	}
+}
+
+func TestRewriteController_RequestPipeline(t *testing.T) {
+	// Hydrated and async bodies go through assertRequest too: the read-only
+	// and JSON name handling of RequestTypes is not bypassed.
+	input := `class UserController {
+    create(body) {
+        return this.service.create(body);
+    }
+    signup(body) {
+        return this.service.signup(body);
+    }
+    search(query) {
+        return this.service.search(query);
+    }
+}`
+
+	controllers := []analyzer.ControllerInfo{
+		{
+			Name:       "UserController",
+			SourceFile: "/src/user.controller.ts",
+			Routes: []analyzer.Route{
+				{
+					OperationID: "create",
+					MethodName:  "create",
+					Parameters: []analyzer.RouteParameter{
+						{Category: "body", LocalName: "body", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "UserDto"}},
+					},
+				},
+				{
+					OperationID: "signup",
+					MethodName:  "signup",
+					Parameters: []analyzer.RouteParameter{
+						{Category: "body", LocalName: "body", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "SignupDto"}},
+					},
+				},
+				{
+					OperationID: "search",
+					MethodName:  "search",
+					Parameters: []analyzer.RouteParameter{
+						{Category: "query", LocalName: "query", TypeName: "SignupDto", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "SignupDto"}},
+					},
+				},
+			},
+		},
+	}
+
+	companionMap := map[string]string{
+		"UserDto":   "/dist/user.dto.UserDto.tsgonest.js",
+		"SignupDto": "/dist/user.dto.SignupDto.tsgonest.js",
+	}
+	// UserDto: hydrated, with read-only properties; SignupDto: async, with JSON names.
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{
+		CompanionMap: companionMap,
+		HydrateTypes: map[string]bool{"UserDto": true},
+		AsyncTypes:   map[string]bool{"SignupDto": true},
+		RequestTypes: map[string]bool{"UserDto": true, "SignupDto": true},
+		ModuleFormat: "esm",
+	})
+
+	if !strings.Contains(result, "create(body) {\n    body = hydrateRequestUserDto(body);") {
+		t.Errorf("expected hydrateRequest injection for hydrate+readOnly body, got:\n%s", result)
+	}
+	if !strings.Contains(result, "async signup(body) {\n    body = await assertAsyncRequestSignupDto(body);") {
+		t.Errorf("expected assertAsyncRequest injection for async+jsonName body, got:\n%s", result)
+	}
+	// Whole-object queries are not request bodies.
+	if !strings.Contains(result, "query = await assertAsyncSignupDto(query);") {
+		t.Errorf("expected assertAsync injection for @Query(), got:\n%s", result)
+	}
+	if strings.Contains(result, "assertUserDto(") || strings.Contains(result, "hydrateUserDto(") {
+		t.Errorf("expected no plain assert or hydrate for the body, got:\n%s", result)
+	}
+	if !strings.Contains(result, `import { hydrateRequestUserDto } from "./user.dto.UserDto.tsgonest.js"`) {
+		t.Errorf("expected hydrateRequest import, got:\n%s", result)
+	}
+	if !strings.Contains(result, `import { assertAsyncSignupDto, assertAsyncRequestSignupDto } from "./user.dto.SignupDto.tsgonest.js"`) {
+		t.Errorf("expected assertAsync imports, got:\n%s", result)
+	}
+}
+
+func TestInjectValidationFilter(t *testing.T) {
+	input := `let UserController = class UserController {
+    create(body) {
+        return this.service.create(body);
+    }
+};
+UserController = __decorate([
+    (0, common_1.Controller)("users")
+], UserController);
+let HealthController = class HealthController {
+    check() {
+        return "ok";
+    }
+};
+HealthController = __decorate([
+    (0, common_1.Controller)("health")
+], HealthController);`
+
+	controllers := []analyzer.ControllerInfo{
+		{
+			Name: "UserController",
+			Routes: []analyzer.Route{
+				{
+					MethodName: "create",
+					Parameters: []analyzer.RouteParameter{
+						{Category: "body", LocalName: "body", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "CreateUserDto"}},
+					},
+				},
+			},
+		},
+		{
+			Name:   "HealthController",
+			Routes: []analyzer.Route{{MethodName: "check"}},
+		},
+	}
+	companionMap := map[string]string{"CreateUserDto": "/dist/user.dto.CreateUserDto.tsgonest.js"}
+	opts := &ValidationErrorOptions{Status: 422, Format: "problem+json"}
+
+	result := injectValidationFilter(input, controllers, companionMap, opts, "cjs")
+
+	if !strings.HasPrefix(result, `const { TsgonestValidationFilter } = require("@tsgonest/runtime");`) {
+		t.Errorf("expected filter import, got:\n%s", result)
+	}
+	if !strings.Contains(result, "UserController = __decorate([\n    (0, common_1.UseFilters)(new TsgonestValidationFilter({\"status\":422,\"format\":\"problem+json\"})),") {
+		t.Errorf("expected filter on UserController, got:\n%s", result)
+	}
+	if strings.Count(result, "UseFilters") != 1 {
+		t.Errorf("expected no filter on HealthController (no validation), got:\n%s", result)
+	}
+
+	if out := injectValidationFilter(input, controllers[1:], companionMap, opts, "esm"); out != input {
+		t.Errorf("expected unchanged output without validated routes, got:\n%s", out)
+	}
+}
+
+func TestInjectValidationFilter_ESM(t *testing.T) {
+	input := `import { Controller, Post } from "@nestjs/common";
+let UserController = class UserController {
+    create(body) {
+        return body;
+    }
+};
+UserController = __decorate([
+    Controller("users")
+], UserController);`
+
+	controllers := []analyzer.ControllerInfo{{
+		Name: "UserController",
+		Routes: []analyzer.Route{{
+			MethodName: "create",
+			Parameters: []analyzer.RouteParameter{
+				{Category: "body", LocalName: "body", Type: metadata.Metadata{Kind: metadata.KindRef, Ref: "CreateUserDto"}},
+			},
+		}},
+	}}
+	companionMap := map[string]string{"CreateUserDto": "/dist/user.dto.CreateUserDto.tsgonest.js"}
+
+	result := injectValidationFilter(input, controllers, companionMap, &ValidationErrorOptions{Status: 422}, "esm")
+
+	if !strings.HasPrefix(result, "import { TsgonestValidationFilter } from \"@tsgonest/runtime\";\nimport { UseFilters as __UseFilters } from \"@nestjs/common\";\n") {
+		t.Errorf("expected filter and UseFilters imports, got:\n%s", result)
+	}
+	if !strings.Contains(result, "UserController = __decorate([\n    __UseFilters(new TsgonestValidationFilter({\"status\":422})),\n    Controller(\"users\")") {
+		t.Errorf("expected filter on UserController, got:\n%s", result)
+	}
+	if strings.Contains(result, "common_1") {
+		t.Errorf("expected no CommonJS binding in an ES module, got:\n%s", result)
+	}
+}
+
 func TestRewriteController_MultipleRoutes(t *testing.T) {
 	input := `class UserController {
     async create(body) {
@@ -95,7 +444,7 @@ func TestRewriteController_MultipleRoutes(t *testing.T) {
 		"UpdateUserDto": "/dist/user.dto.UpdateUserDto.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	if !strings.Contains(result, "assertCreateUserDto(body)") {
 		t.Errorf("expected assertCreateUserDto, got:\n%s", result)
@@ -128,7 +477,7 @@ func TestRewriteController_NoBody(t *testing.T) {
 
 	companionMap := map[string]string{}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// Should be unchanged since there are no body params
 	if result != input {
@@ -168,7 +517,7 @@ func TestRewriteController_RawResponse(t *testing.T) {
 		"DownloadDto": "/dist/dto.DownloadDto.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// Raw response routes should be skipped
 	if result != input {
@@ -176,6 +525,41 @@ func TestRewriteController_RawResponse(t *testing.T) {
 	}
 }
 
+func TestRewriteController_BinaryResponseNotSerialized(t *testing.T) {
+	input := `class FileController {
+    async download(id) {
+        return new StreamableFile(this.service.read(id));
+    }
+}`
+
+	controllers := []analyzer.ControllerInfo{
+		{
+			Name:       "FileController",
+			SourceFile: "/src/file.controller.ts",
+			Routes: []analyzer.Route{
+				{
+					OperationID:      "download",
+					MethodName:       "download",
+					ReturnType:       metadata.Metadata{Kind: metadata.KindNative, NativeType: "StreamableFile", Name: "StreamableFile"},
+					IsBinaryResponse: true,
+				},
+			},
+		},
+	}
+
+	// Even if a companion happens to exist under the same name, binary
+	// responses must be sent as-is.
+	companionMap := map[string]string{
+		"StreamableFile": "/dist/file.StreamableFile.tsgonest.js",
+	}
+
+	result := rewriteController(input, "/dist/file.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
+
+	if result != input {
+		t.Errorf("binary response routes should not be wrapped, got:\n%s", result)
+	}
+}
+
 func TestInjectAtMethodStart(t *testing.T) {
 	input := `class Foo {
     async bar(x) {
@@ -213,7 +597,7 @@ func TestRewriteController_ReturnTransform(t *testing.T) {
 		"UserResponse": "/dist/user.dto.UserResponse.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	if !strings.Contains(result, "stringifyUserResponse(await this.service.findAll())") {
 		t.Errorf("expected return stringify wrapping, got:\n%s", result)
@@ -251,13 +635,80 @@ func TestRewriteController_ArrayReturn(t *testing.T) {
 		"UserResponse": "/dist/user.dto.UserResponse.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	if !strings.Contains(result, `"[" + (await this.service.findAll()).map(_v => serializeUserResponse(_v)).join(",") + "]"`) {
 		t.Errorf("expected array return serialize, got:\n%s", result)
 	}
 }
 
+func TestRewriteController_ReturnView(t *testing.T) {
+	input := `class UserController {
+    async findAll() {
+        return this.service.findAll();
+    }
+    async findOne(id) {
+        return this.service.findOne(id);
+    }
+    async me() {
+        return this.service.me();
+    }
+}`
+
+	userRef := metadata.Metadata{Kind: metadata.KindRef, Ref: "UserResponse"}
+	controllers := []analyzer.ControllerInfo{
+		{
+			Name:       "UserController",
+			SourceFile: "/src/user.controller.ts",
+			Routes: []analyzer.Route{
+				{
+					OperationID: "findAll",
+					MethodName:  "findAll",
+					ReturnType:  metadata.Metadata{Kind: metadata.KindArray, ElementType: &userRef},
+					View:        "admin",
+				},
+				{
+					OperationID: "findOne",
+					MethodName:  "findOne",
+					ReturnType:  userRef,
+					View:        "admin",
+				},
+				{
+					OperationID: "me",
+					MethodName:  "me",
+					ReturnType:  userRef,
+				},
+			},
+		},
+	}
+
+	companionMap := map[string]string{
+		"UserResponse": "/dist/user.dto.UserResponse.tsgonest.js",
+	}
+
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
+
+	if !strings.Contains(result, `.map(_v => serializeUserResponse_admin(_v))`) {
+		t.Errorf("expected view serialize for the array route, got:\n%s", result)
+	}
+	if !strings.Contains(result, "return stringifyUserResponse_admin(await this.service.findOne(id));") {
+		t.Errorf("expected view stringify, got:\n%s", result)
+	}
+	if !strings.Contains(result, "return stringifyUserResponse(await this.service.me());") {
+		t.Errorf("expected default stringify without a view, got:\n%s", result)
+	}
+	importLine := result[:strings.Index(result, "\n")]
+	imported := make(map[string]bool)
+	for _, fn := range strings.Split(importLine[strings.Index(importLine, "{")+1:strings.Index(importLine, "}")], ",") {
+		imported[strings.TrimSpace(fn)] = true
+	}
+	for _, fn := range []string{"stringifyUserResponse_admin", "serializeUserResponse_admin", "stringifyUserResponse", "serializeUserResponse"} {
+		if !imported[fn] {
+			t.Errorf("expected %s import, got:\n%s", fn, importLine)
+		}
+	}
+}
+
 func TestRewriteController_VoidReturn(t *testing.T) {
 	input := `class UserController {
     async remove(id) {
@@ -281,7 +732,7 @@ func TestRewriteController_VoidReturn(t *testing.T) {
 
 	companionMap := map[string]string{}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// Void return should be unchanged
 	if result != input {
@@ -322,7 +773,7 @@ func TestRewriteController_BodyAndReturn(t *testing.T) {
 		"UserResponse":  "/dist/user.dto.UserResponse.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	if !strings.Contains(result, "assertCreateUserDto(body)") {
 		t.Errorf("expected body validation, got:\n%s", result)
@@ -356,7 +807,7 @@ func TestRewriteController_NoReturnCompanion(t *testing.T) {
 	// No companion for SomeExternalType
 	companionMap := map[string]string{}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// Should be unchanged — no companion available for return type
 	if result != input {
@@ -442,7 +893,7 @@ func TestRewriteController_WholeObjectQuery(t *testing.T) {
 		"PaginationQuery": "/dist/pagination.dto.PaginationQuery.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/order.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/order.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	if !strings.Contains(result, "assertPaginationQuery(query)") {
 		t.Errorf("expected assert call for @Query() injection, got:\n%s", result)
@@ -482,7 +933,7 @@ func TestRewriteController_ScalarParamCoercion(t *testing.T) {
 
 	companionMap := map[string]string{}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	if !strings.Contains(result, "id = +id") {
 		t.Errorf("expected number coercion for @Param('id'), got:\n%s", result)
@@ -525,7 +976,7 @@ func TestRewriteController_StringParamNoCoercion(t *testing.T) {
 
 	companionMap := map[string]string{}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// String-typed scalar param should have no injection
 	if result != input {
@@ -577,7 +1028,7 @@ func TestRewriteController_MixedBodyQueryParam(t *testing.T) {
 		"OrderOptions":   "/dist/order.dto.OrderOptions.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/order.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/order.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	if !strings.Contains(result, "assertCreateOrderDto(body)") {
 		t.Errorf("expected body validation, got:\n%s", result)
@@ -622,7 +1073,7 @@ func TestRewriteController_WholeObjectParam(t *testing.T) {
 		"RouteParams": "/dist/route.dto.RouteParams.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	if !strings.Contains(result, "assertRouteParams(params)") {
 		t.Errorf("expected assert call for whole-object @Param(), got:\n%s", result)
@@ -659,7 +1110,7 @@ func TestRewriteController_BooleanParamCoercion(t *testing.T) {
 
 	companionMap := map[string]string{}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	if !strings.Contains(result, `=== "true"`) {
 		t.Errorf("expected boolean coercion for @Query('active'), got:\n%s", result)
@@ -669,6 +1120,52 @@ func TestRewriteController_BooleanParamCoercion(t *testing.T) {
 	}
 }
 
+func TestRewriteController_ScalarParamDefaults(t *testing.T) {
+	input := `class UserController {
+    async list(page, sort) {
+        return this.service.list(page, sort);
+    }
+}`
+
+	pageDefault, sortDefault := "1", "name"
+	controllers := []analyzer.ControllerInfo{
+		{
+			Name:       "UserController",
+			SourceFile: "/src/user.controller.ts",
+			Routes: []analyzer.Route{
+				{
+					OperationID: "list",
+					MethodName:  "list",
+					Parameters: []analyzer.RouteParameter{
+						{
+							Category:  "query",
+							Name:      "page",
+							LocalName: "page",
+							Type:      metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "number", Constraints: &metadata.Constraints{Default: &pageDefault}},
+						},
+						{
+							Category:  "query",
+							Name:      "sort",
+							LocalName: "sort",
+							Type:      metadata.Metadata{Kind: metadata.KindAtomic, Atomic: "string", Constraints: &metadata.Constraints{Default: &sortDefault}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: map[string]string{}, ModuleFormat: "esm"})
+
+	// The default is filled in before coercion.
+	if !strings.Contains(result, "    if (page === undefined) page = 1;\n    page = +page;") {
+		t.Errorf("expected default before number coercion for @Query('page'), got:\n%s", result)
+	}
+	if !strings.Contains(result, `if (sort === undefined) sort = "name";`) {
+		t.Errorf("expected default for string @Query('sort'), got:\n%s", result)
+	}
+}
+
 // --- @EventStream SSE Rewriter Tests ---
 
 func TestRewriteController_SSETransformInjection(t *testing.T) {
@@ -712,7 +1209,7 @@ class UserEventController {
 		"DeletePayload": "/dist/dto.DeletePayload.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/event.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/event.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// Should inject Reflect.defineMetadata after the method-level __decorate
 	if !strings.Contains(result, `Reflect.defineMetadata("__tsgonest_sse_transforms__"`) {
@@ -797,7 +1294,7 @@ class GenericController {
 		"UserDto": "/dist/dto.UserDto.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/generic.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/generic.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// Should use "*" as the wildcard key
 	if !strings.Contains(result, `"*"`) {
@@ -838,7 +1335,7 @@ func TestRewriteController_SSENoReturnWrapping(t *testing.T) {
 		"UserDto": "/dist/dto.UserDto.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/event.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/event.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// Should NOT contain stringify wrapping of return
 	if strings.Contains(result, "stringifyUserDto(await") {
@@ -894,7 +1391,7 @@ class MixedController {
 		"StatusDto":      "/dist/dto.StatusDto.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/mixed.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/mixed.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// Should have return wrapping for getHealth
 	if !strings.Contains(result, "stringifyHealthResponse(await") {
@@ -956,7 +1453,7 @@ func TestRewriteController_StringReturn(t *testing.T) {
 		"ForgotPasswordDto": "/dist/auth.dto.ForgotPasswordDto.tsgonest.js",
 	}
 
-	result := rewriteController(input, "/dist/auth.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/auth.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// The return value must be JSON-stringified — a raw string like:
 	//   If an account exists, a reset link has been sent.
@@ -991,7 +1488,7 @@ func TestRewriteController_StringReturnOnly(t *testing.T) {
 
 	companionMap := map[string]string{}
 
-	result := rewriteController(input, "/dist/health.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/health.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// Must wrap return with JSON encoding for string
 	if !strings.Contains(result, "JSON.stringify(") && !strings.Contains(result, "__s(") {
@@ -1024,7 +1521,7 @@ func TestRewriteController_NumberReturn(t *testing.T) {
 
 	companionMap := map[string]string{}
 
-	result := rewriteController(input, "/dist/stats.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/stats.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// Number returns should be serialized (e.g., "" + value or Number.isFinite check)
 	if !strings.Contains(result, "Number.isFinite") && !strings.Contains(result, "JSON.stringify") {
@@ -1056,7 +1553,7 @@ func TestRewriteController_BooleanReturn(t *testing.T) {
 
 	companionMap := map[string]string{}
 
-	result := rewriteController(input, "/dist/feature.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/feature.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// Boolean should be serialized
 	if !strings.Contains(result, `"true"`) && !strings.Contains(result, `"false"`) && !strings.Contains(result, "JSON.stringify") {
@@ -1088,7 +1585,7 @@ func TestRewriteController_NullableStringReturn(t *testing.T) {
 
 	companionMap := map[string]string{}
 
-	result := rewriteController(input, "/dist/user.controller.js", controllers, companionMap, "esm")
+	result := rewriteController(input, "/dist/user.controller.js", controllers, &RewriteContext{CompanionMap: companionMap, ModuleFormat: "esm"})
 
 	// Must wrap — nullable string needs null check + JSON encoding
 	if !strings.Contains(result, "null") || result == input {
@@ -1,7 +1,7 @@
 // Package rewrite handles inline rewriting of emitted JavaScript files.
-// It replaces marker function calls (is, validate, assert, stringify, serialize)
-// with direct calls to companion functions, and injects body validation
-// into NestJS controller methods.
+// It replaces marker function calls (is, validate, assert, stringify, serialize
+// and the on-demand markers) with direct calls to companion functions, and
+// injects body validation into NestJS controller methods.
 package rewrite
 
 import (
@@ -9,23 +9,66 @@ import (
 
 	"github.com/microsoft/typescript-go/shim/ast"
 	shimchecker "github.com/microsoft/typescript-go/shim/checker"
+	"github.com/tsgonest/tsgonest/internal/analyzer"
 )
 
 // MarkerCall represents a detected call to a tsgonest marker function
-// (is, validate, assert, stringify, serialize) with a resolved type argument.
+// (see markerFunctions) with a resolved type argument.
 type MarkerCall struct {
-	FunctionName string // "is", "validate", "assert", "stringify", "serialize"
+	FunctionName string // marker name e.g. "validate", "random", "assertParse"
 	TypeName     string // resolved type name e.g. "CreateUserDto"
 	SourcePos    int    // character offset in source file (for ordering)
+	View         string // serialization view of serialize/stringify imports for controller routes
 }
 
 // markerFunctions is the set of function names that tsgonest recognizes as markers.
 var markerFunctions = map[string]bool{
-	"is":        true,
-	"validate":  true,
-	"assert":    true,
-	"stringify": true,
-	"serialize": true,
+	"is":             true,
+	"validate":       true,
+	"assert":         true,
+	"stringify":      true,
+	"serialize":      true,
+	"random":         true,
+	"parse":          true,
+	"assertParse":    true,
+	"equals":         true,
+	"validateEquals": true,
+	"prune":          true,
+	"clone":          true,
+	"hydrate":        true,
+	"validateAsync":  true,
+}
+
+// onDemandMarkers are marker functions whose companion functions are only
+// generated for types that are actually used with them (see codegen.CompanionGenOptions.Markers).
+var onDemandMarkers = map[string]bool{
+	"random":         true,
+	"parse":          true,
+	"assertParse":    true,
+	"equals":         true,
+	"validateEquals": true,
+	"prune":          true,
+	"clone":          true,
+	"hydrate":        true,
+	"validateAsync":  true,
+}
+
+// CollectOnDemandMarkers returns, per type name, the on-demand marker functions
+// called on it across all files (e.g. {"UserDto": {"random": true}}).
+func CollectOnDemandMarkers(markerCalls map[string][]MarkerCall) map[string]map[string]bool {
+	result := make(map[string]map[string]bool)
+	for _, calls := range markerCalls {
+		for _, call := range calls {
+			if !onDemandMarkers[call.FunctionName] {
+				continue
+			}
+			if result[call.TypeName] == nil {
+				result[call.TypeName] = make(map[string]bool)
+			}
+			result[call.TypeName][call.FunctionName] = true
+		}
+	}
+	return result
 }
 
 // ExtractMarkerCalls finds tsgonest marker calls in a source file.
@@ -35,6 +78,13 @@ var markerFunctions = map[string]bool{
 //
 // Returns nil if the file has no tsgonest imports.
 func ExtractMarkerCalls(sf *ast.SourceFile, checker *shimchecker.Checker) []MarkerCall {
+	return ExtractMarkerCallsWithNamer(sf, checker, nil)
+}
+
+// ExtractMarkerCallsWithNamer is like ExtractMarkerCalls but resolves type
+// arguments to their schema names (see analyzer.SchemaNamer), so markers on
+// same-named types from different modules map to distinct companions.
+func ExtractMarkerCallsWithNamer(sf *ast.SourceFile, checker *shimchecker.Checker, namer *analyzer.SchemaNamer) []MarkerCall {
 	// Step 1: Find tsgonest import and collect imported names
 	importedNames := findTsgonestImports(sf)
 	if len(importedNames) == 0 {
@@ -43,7 +93,7 @@ func ExtractMarkerCalls(sf *ast.SourceFile, checker *shimchecker.Checker) []Mark
 
 	// Step 2: Walk AST to find call expressions using imported marker names
 	var calls []MarkerCall
-	walkNode(sf.AsNode(), importedNames, checker, &calls)
+	walkNode(sf.AsNode(), importedNames, checker, namer, &calls)
 
 	// Step 3: Sort by source position for deterministic ordering
 	sort.Slice(calls, func(i, j int) bool {
@@ -112,7 +162,7 @@ func findTsgonestImports(sf *ast.SourceFile) map[string]string {
 
 // walkNode recursively walks the AST looking for CallExpression nodes
 // that match marker function calls with type arguments.
-func walkNode(node *ast.Node, importedNames map[string]string, checker *shimchecker.Checker, calls *[]MarkerCall) {
+func walkNode(node *ast.Node, importedNames map[string]string, checker *shimchecker.Checker, namer *analyzer.SchemaNamer, calls *[]MarkerCall) {
 	if node == nil {
 		return
 	}
@@ -126,7 +176,7 @@ func walkNode(node *ast.Node, importedNames map[string]string, checker *shimchec
 				if origName, ok := importedNames[calleeName]; ok {
 					// Resolve type argument to a named type
 					typeNode := call.TypeArguments.Nodes[0]
-					typeName := resolveTypeArgName(typeNode, checker)
+					typeName := resolveTypeArgName(typeNode, checker, namer)
 					if typeName != "" {
 						*calls = append(*calls, MarkerCall{
 							FunctionName: origName,
@@ -141,14 +191,14 @@ func walkNode(node *ast.Node, importedNames map[string]string, checker *shimchec
 
 	// Recurse into children
 	node.ForEachChild(func(child *ast.Node) bool {
-		walkNode(child, importedNames, checker, calls)
+		walkNode(child, importedNames, checker, namer, calls)
 		return false // continue visiting
 	})
 }
 
 // resolveTypeArgName resolves a type argument node to a named type string.
-// Uses the checker to get the type, then extracts the symbol name.
-func resolveTypeArgName(typeNode *ast.Node, checker *shimchecker.Checker) string {
+// Uses the checker to get the type, then extracts the symbol's schema name.
+func resolveTypeArgName(typeNode *ast.Node, checker *shimchecker.Checker, namer *analyzer.SchemaNamer) string {
 	resolvedType := shimchecker.Checker_getTypeFromTypeNode(checker, typeNode)
 	if resolvedType == nil {
 		return ""
@@ -159,5 +209,5 @@ func resolveTypeArgName(typeNode *ast.Node, checker *shimchecker.Checker) string
 		return ""
 	}
 
-	return sym.Name
+	return namer.SymbolName(sym)
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+
+	"github.com/tsgonest/tsgonest/internal/codegen"
 )
 
 // companionFuncName returns the companion function name for a marker+type combination.
@@ -67,6 +69,9 @@ func companionImports(calls []MarkerCall, companionMap map[string]string, output
 			order = append(order, companionPath)
 		}
 		funcName := companionFuncName(call.FunctionName, call.TypeName)
+		if call.View != "" {
+			funcName = codegen.ViewFuncName(call.FunctionName, call.TypeName, call.View)
+		}
 		// Deduplicate function names within the same companion
 		found := false
 		for _, existing := range g.funcNames {
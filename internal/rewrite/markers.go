@@ -90,11 +90,11 @@ var markerCallPatterns = map[string]*regexp.Regexp{}
 
 func init() {
 	for name := range markerFunctions {
-		// Match the function name followed by ( but not preceded by an alphanumeric
-		// character (to avoid matching e.g., "promise" when looking for "is").
-		// The negative lookbehind ensures we match standalone calls.
-		// We match: funcName( and replace just the function name part.
-		markerCallPatterns[name] = regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\(`)
+		// Match the function name followed by ( but not preceded by an identifier
+		// character or a dot (to avoid matching e.g., "promise" when looking for "is",
+		// or "Math.random(" when looking for "random").
+		// RE2 has no lookbehind, so the preceding character is captured and kept.
+		markerCallPatterns[name] = regexp.MustCompile(`(^|[^\w$.])` + regexp.QuoteMeta(name) + `\(`)
 	}
 }
 
@@ -112,7 +112,8 @@ func replaceMarkerCalls(text string, funcName string, typeNames []string, occurr
 		}
 		typeName := typeNames[idx]
 		idx++
-		return companionFuncName(funcName, typeName) + "("
+		prefix := match[:len(match)-len(funcName)-1]
+		return prefix + companionFuncName(funcName, typeName) + "("
 	})
 	(*occurrenceIndex)[funcName] = idx
 
@@ -93,6 +93,123 @@ const s = stringify(user);`
 	}
 }
 
+func TestRewriteMarkers_IgnoresMemberCalls(t *testing.T) {
+	input := `import { random } from "tsgonest";
+const jitter = Math.random();
+const user = random({ seed: 1 });`
+
+	calls := []MarkerCall{
+		{FunctionName: "random", TypeName: "UserDto", SourcePos: 0},
+	}
+
+	companionMap := map[string]string{
+		"UserDto": "/dist/user.dto.UserDto.tsgonest.js",
+	}
+
+	result := rewriteMarkers(input, "/dist/seed.js", calls, companionMap, "esm")
+
+	if !strings.Contains(result, "Math.random()") {
+		t.Errorf("Math.random() should be left untouched, got:\n%s", result)
+	}
+	if !strings.Contains(result, "const user = randomUserDto({ seed: 1 })") {
+		t.Errorf("expected randomUserDto call, got:\n%s", result)
+	}
+}
+
+func TestRewriteMarkers_ParseMarkers(t *testing.T) {
+	input := `import { parse, assertParse, assert } from "tsgonest";
+const raw = JSON.parse(text);
+const result = parse(message.body);
+const order = assertParse(line);
+const checked = assert(raw);`
+
+	calls := []MarkerCall{
+		{FunctionName: "parse", TypeName: "EventDto", SourcePos: 0},
+		{FunctionName: "assertParse", TypeName: "OrderDto", SourcePos: 10},
+		{FunctionName: "assert", TypeName: "EventDto", SourcePos: 20},
+	}
+
+	companionMap := map[string]string{
+		"EventDto": "/dist/event.dto.EventDto.tsgonest.js",
+		"OrderDto": "/dist/order.dto.OrderDto.tsgonest.js",
+	}
+
+	result := rewriteMarkers(input, "/dist/consumer.js", calls, companionMap, "esm")
+
+	if !strings.Contains(result, "JSON.parse(text)") {
+		t.Errorf("JSON.parse() should be left untouched, got:\n%s", result)
+	}
+	if !strings.Contains(result, "parseEventDto(message.body)") {
+		t.Errorf("expected parseEventDto call, got:\n%s", result)
+	}
+	if !strings.Contains(result, "assertParseOrderDto(line)") {
+		t.Errorf("expected assertParseOrderDto call, got:\n%s", result)
+	}
+	if !strings.Contains(result, "assertEventDto(raw)") {
+		t.Errorf("expected assertEventDto call, got:\n%s", result)
+	}
+}
+
+func TestRewriteMarkers_EqualsMarkers(t *testing.T) {
+	input := `import { equals, validateEquals, validate } from "tsgonest";
+const a = equals(x);
+const b = validateEquals(y);
+const c = validate(z);
+const d = lodash.equals(x, y);`
+
+	calls := []MarkerCall{
+		{FunctionName: "equals", TypeName: "UserDto", SourcePos: 0},
+		{FunctionName: "validateEquals", TypeName: "UserDto", SourcePos: 10},
+		{FunctionName: "validate", TypeName: "UserDto", SourcePos: 20},
+	}
+
+	companionMap := map[string]string{
+		"UserDto": "/dist/user.dto.UserDto.tsgonest.js",
+	}
+
+	result := rewriteMarkers(input, "/dist/check.js", calls, companionMap, "esm")
+
+	for _, want := range []string{"equalsUserDto(x)", "validateEqualsUserDto(y)", "validateUserDto(z)", "lodash.equals(x, y)"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q, got:\n%s", want, result)
+		}
+	}
+}
+
+func TestCollectOnDemandMarkers(t *testing.T) {
+	markers := CollectOnDemandMarkers(map[string][]MarkerCall{
+		"/src/a.ts": {
+			{FunctionName: "random", TypeName: "UserDto"},
+			{FunctionName: "assert", TypeName: "UserDto"},
+		},
+		"/src/b.ts": {
+			{FunctionName: "validate", TypeName: "OrderDto"},
+			{FunctionName: "parse", TypeName: "EventDto"},
+			{FunctionName: "hydrate", TypeName: "CreateUserDto"},
+			{FunctionName: "validateAsync", TypeName: "SignupDto"},
+		},
+	})
+
+	if !markers["UserDto"]["random"] {
+		t.Error("expected random marker for UserDto")
+	}
+	if markers["UserDto"]["assert"] {
+		t.Error("assert is always generated and should not be collected")
+	}
+	if !markers["EventDto"]["parse"] {
+		t.Error("expected parse marker for EventDto")
+	}
+	if !markers["CreateUserDto"]["hydrate"] {
+		t.Error("expected hydrate marker for CreateUserDto")
+	}
+	if !markers["SignupDto"]["validateAsync"] {
+		t.Error("expected validateAsync marker for SignupDto")
+	}
+	if _, ok := markers["OrderDto"]; ok {
+		t.Error("OrderDto has no on-demand markers")
+	}
+}
+
 func TestRewriteMarkers_AlreadyRewritten(t *testing.T) {
 	input := rewriteSentinel + "\n" + `import { isCreateUserDto } from "./user.dto.CreateUserDto.tsgonest.js";
 const ok = isCreateUserDto(body);`
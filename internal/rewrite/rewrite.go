@@ -23,6 +23,24 @@ type RewriteContext struct {
 	// Controllers holds analyzed controller info.
 	Controllers []analyzer.ControllerInfo
 
+	// HydrateTypes lists the @Body() types constructed with hydrateRequest<Type>()
+	// instead of assert<Type>() (transforms.hydrate).
+	HydrateTypes map[string]bool
+
+	// AsyncTypes lists the @Body() and whole-object parameter types with async
+	// custom validators, awaited through assertAsyncRequest<Type>() (bodies) or
+	// assertAsync<Type>() before the handler runs.
+	AsyncTypes map[string]bool
+
+	// RequestTypes lists the @Body() types with read-only, deprecated or
+	// JSON-named properties, checked by assertRequest<Type>() instead of
+	// assert<Type>() (transforms.readOnly, onDeprecated, jsonNaming).
+	RequestTypes map[string]bool
+
+	// ValidationError sets the options of the TsgonestValidationFilter injected
+	// on controllers with request validation (nil: no filter).
+	ValidationError *ValidationErrorOptions
+
 	// ControllerSourceFiles maps source file paths that are controllers.
 	ControllerSourceFiles map[string]bool
 
@@ -73,7 +91,10 @@ func (ctx *RewriteContext) MakeWriteFile() shimcompiler.WriteFile {
 					}
 				}
 				if len(matchingControllers) > 0 {
-					text = rewriteController(text, fileName, matchingControllers, ctx.CompanionMap, ctx.ModuleFormat)
+					text = rewriteController(text, fileName, matchingControllers, ctx)
+					if ctx.ValidationError != nil {
+						text = injectValidationFilter(text, matchingControllers, ctx.CompanionMap, ctx.ValidationError, ctx.ModuleFormat)
+					}
 				}
 			}
 		}
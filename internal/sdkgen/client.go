@@ -11,7 +11,16 @@ export interface SDKError {
   body?: unknown;
 }
 
-export type SDKResult<T> = { data: T; error: null; response: Response } | { data: null; error: SDKError; response: Response };
+/**
+ * SDKError of a request whose route documents its error bodies. E maps the
+ * documented error statuses to their body type: narrow on documented and status
+ * for a typed body. Undocumented statuses have an unknown body.
+ */
+export type TypedSDKError<E extends Record<number, unknown> = {}> =
+  | { [S in keyof E & number]: SDKError & { status: S; documented: true; body?: E[S] } }[keyof E & number]
+  | (SDKError & { documented: false });
+
+export type SDKResult<T, E extends Record<number, unknown> = {}> = { data: T; error: null; response: Response } | { data: null; error: TypedSDKError<E>; response: Response };
 
 export type Fetcher = (url: string, init: RequestInit) => Promise<Response>;
 
@@ -24,7 +33,7 @@ export interface ClientConfig {
   onRequest?: (url: string, init: RequestInit) => RequestInit | Promise<RequestInit>;
 }
 
-export type RequestFn = <T>(
+export type RequestFn = <T, E extends Record<number, unknown> = {}>(
   method: string,
   path: string,
   options?: {
@@ -35,13 +44,14 @@ export type RequestFn = <T>(
     signal?: AbortSignal;
     contentType?: string;
     responseType?: 'json' | 'blob' | 'text' | 'stream' | 'sse' | 'sse-raw';
+    errorStatuses?: number[];
   },
-) => Promise<SDKResult<T>>;
+) => Promise<SDKResult<T, E>>;
 
 export function createRequestFn(config: ClientConfig): RequestFn {
   const fetcher = config.fetcher ?? fetch;
 
-  return async <T>(
+  return async <T, E extends Record<number, unknown> = {}>(
     method: string,
     path: string,
     options?: {
@@ -52,8 +62,9 @@ export function createRequestFn(config: ClientConfig): RequestFn {
       signal?: AbortSignal;
       contentType?: string;
       responseType?: 'json' | 'blob' | 'text' | 'stream' | 'sse' | 'sse-raw';
+      errorStatuses?: number[];
     },
-  ): Promise<SDKResult<T>> => {
+  ): Promise<SDKResult<T, E>> => {
     // Interpolate path params
     let url = path;
     if (options?.params) {
@@ -137,9 +148,10 @@ export function createRequestFn(config: ClientConfig): RequestFn {
         data: null,
         error: {
           status: response.status,
+          documented: options?.errorStatuses?.includes(response.status) ?? false,
           message: response.statusText,
           body: errorBody,
-        },
+        } as TypedSDKError<E>,
         response,
       };
     }
@@ -2,6 +2,7 @@ package sdkgen
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -93,11 +94,11 @@ func generateController(ctrl ControllerGroup, doc *SDKDocument, version string)
 			sb.WriteString(jsdoc)
 		}
 		optionsType := buildOptionsType(method)
-		responseType := buildResponseType(method)
+		resultType := buildResultType(method)
 		if optionsType == "" {
-			sb.WriteString(fmt.Sprintf("  %s(options?: { signal?: AbortSignal; headers?: Record<string, string>; responseType?: 'json' | 'blob' | 'text' | 'stream'; contentType?: string }): Promise<SDKResult<%s>>;\n", method.Name, responseType))
+			sb.WriteString(fmt.Sprintf("  %s(options?: { signal?: AbortSignal; headers?: Record<string, string>; responseType?: 'json' | 'blob' | 'text' | 'stream'; contentType?: string }): Promise<%s>;\n", method.Name, resultType))
 		} else {
-			sb.WriteString(fmt.Sprintf("  %s(options: %s): Promise<SDKResult<%s>>;\n", method.Name, optionsType, responseType))
+			sb.WriteString(fmt.Sprintf("  %s(options: %s): Promise<%s>;\n", method.Name, optionsType, resultType))
 		}
 	}
 	sb.WriteString("}\n\n")
@@ -140,11 +141,11 @@ func generateStandaloneFunction(ctrlName string, method SDKMethod) string {
 
 	// Function signature
 	optionsType := buildOptionsType(method)
-	responseType := buildResponseType(method)
+	resultType := buildResultType(method)
 	if optionsType == "" {
-		sb.WriteString(fmt.Sprintf("export async function %s(\n  request: RequestFn,\n  options?: { signal?: AbortSignal; headers?: Record<string, string>; responseType?: 'json' | 'blob' | 'text' | 'stream'; contentType?: string },\n): Promise<SDKResult<%s>> {\n", qName, responseType))
+		sb.WriteString(fmt.Sprintf("export async function %s(\n  request: RequestFn,\n  options?: { signal?: AbortSignal; headers?: Record<string, string>; responseType?: 'json' | 'blob' | 'text' | 'stream'; contentType?: string },\n): Promise<%s> {\n", qName, resultType))
 	} else {
-		sb.WriteString(fmt.Sprintf("export async function %s(\n  request: RequestFn,\n  options: %s,\n): Promise<SDKResult<%s>> {\n", qName, optionsType, responseType))
+		sb.WriteString(fmt.Sprintf("export async function %s(\n  request: RequestFn,\n  options: %s,\n): Promise<%s> {\n", qName, optionsType, resultType))
 	}
 
 	// Build params object for path interpolation
@@ -221,8 +222,18 @@ func writeRequestOptions(sb *strings.Builder, method SDKMethod, indent string) {
 		if hint == "sse" && method.SSEEventType == "" {
 			hint = "sse-raw"
 		}
+		if method.ResponseType == "Blob" {
+			hint = "blob"
+		}
 		sb.WriteString(fmt.Sprintf(indent+"  responseType: '%s',\n", hint))
 	}
+	if len(method.ErrorStatuses) > 0 {
+		statuses := make([]string, len(method.ErrorStatuses))
+		for i, status := range method.ErrorStatuses {
+			statuses[i] = strconv.Itoa(status)
+		}
+		sb.WriteString(indent + "  errorStatuses: [" + strings.Join(statuses, ", ") + "],\n")
+	}
 	sb.WriteString(indent + "  signal: options?.signal,\n")
 	sb.WriteString(indent + "  headers: options?.headers,\n")
 	sb.WriteString(indent + "  ...(options?.responseType && { responseType: options.responseType }),\n")
@@ -340,11 +351,21 @@ func buildResponseType(method SDKMethod) string {
 	return method.ResponseType
 }
 
+// buildResultType returns the SDKResult type of a method, with the status map
+// of its documented error bodies when there is one.
+func buildResultType(method SDKMethod) string {
+	if method.ErrorType != "" {
+		return "SDKResult<" + buildResponseType(method) + ", " + method.ErrorType + ">"
+	}
+	return "SDKResult<" + buildResponseType(method) + ">"
+}
+
 // collectTypeImports returns sorted, deduplicated type names referenced by the controller.
 func collectTypeImports(ctrl ControllerGroup, schemas map[string]*SchemaNode) []string {
 	refs := make(map[string]bool)
 	for _, method := range ctrl.Methods {
 		collectRefs(method.ResponseType, schemas, refs)
+		collectRefs(method.ErrorType, schemas, refs)
 		for _, p := range method.PathParams {
 			collectRefs(p.TSType, schemas, refs)
 		}
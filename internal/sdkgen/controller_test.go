@@ -279,6 +279,24 @@ func TestGenerateStandaloneFunction_OverridesInRequestCall(t *testing.T) {
 	}
 }
 
+func TestGenerateStandaloneFunction_BinaryDownloadUsesBlob(t *testing.T) {
+	// A binary download with a non-standard content type (e.g. application/zip)
+	// must still be fetched as a blob.
+	method := SDKMethod{
+		Name:                "exportArchive",
+		HTTPMethod:          "GET",
+		Path:                "/exports/archive",
+		ResponseType:        "Blob",
+		ResponseContentType: "application/zip",
+	}
+
+	code := generateStandaloneFunction("ExportsController", method)
+
+	if !strings.Contains(code, "responseType: 'blob'") {
+		t.Errorf("binary download should use blob responseType, got:\n%s", code)
+	}
+}
+
 func TestGenerateStandaloneFunction_NoParamsHasOverrides(t *testing.T) {
 	// Method with no path/query/body params should still have override fields
 	// in the inline options type
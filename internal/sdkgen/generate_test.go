@@ -42,7 +42,8 @@ func TestGenerate_BasicFixture(t *testing.T) {
 
 	// Verify client.ts contains core infrastructure
 	clientContent := readFile(t, filepath.Join(outputDir, "client.ts"))
-	assertContains(t, clientContent, "export interface SDKError", "client.ts should contain SDKError")
+	assertContains(t, clientContent, "export interface SDKError {", "client.ts should contain SDKError")
+	assertContains(t, clientContent, "export type TypedSDKError", "client.ts should contain TypedSDKError")
 	assertContains(t, clientContent, "export type SDKResult", "client.ts should contain SDKResult")
 	assertContains(t, clientContent, "export function createRequestFn", "client.ts should contain createRequestFn")
 
@@ -423,8 +424,9 @@ func TestGenerate_EdgeCasesFixture(t *testing.T) {
 	// Image response should produce Blob type
 	assertContains(t, ctrlContent, "SDKResult<Blob>", "image/png response should be Blob")
 
-	// Void fallback for no 2xx responses
-	assertContains(t, ctrlContent, "SDKResult<void>", "no 2xx responses should produce void")
+	// Void fallback for no 2xx responses, typed with the documented 400 body
+	assertContains(t, ctrlContent, "SDKResult<void, { 400: ErrorResponse }>", "no 2xx responses should produce void")
+	assertContains(t, ctrlContent, "errorStatuses: [400],", "documented error statuses should be passed to request")
 }
 
 func readFile(t *testing.T, path string) string {
@@ -12,7 +12,7 @@ func generateIndex(versions []VersionGroup) string {
 
 	// Re-export client types and factory
 	sb.WriteString("export { createRequestFn } from './client';\n")
-	sb.WriteString("export type { ClientConfig, SDKResult, SDKError, Fetcher, RequestFn, HeadersInit } from './client';\n")
+	sb.WriteString("export type { ClientConfig, SDKResult, SDKError, TypedSDKError, Fetcher, RequestFn, HeadersInit } from './client';\n")
 
 	// Re-export SSE types (always — they're type-only for most consumers)
 	sb.WriteString("export { SSEConnection } from './sse';\n")
@@ -6,6 +6,7 @@ import (
 	"os"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -220,6 +221,7 @@ func ParseOpenAPIBytesWithOptions(data []byte, opts *GenerateOptions) (*SDKDocum
 			sdkMethod.ResponseStatus = resp.status
 			sdkMethod.ResponseContentType = resp.contentType
 			sdkMethod.IsVoid = resp.isVoid
+			sdkMethod.ErrorType, sdkMethod.ErrorStatuses = resolveErrorType(op.Responses, resolver)
 
 			// Extract typed SSE event data from itemSchema
 			if resp.contentType == "text/event-stream" {
@@ -396,8 +398,65 @@ func resolveResponse(responses map[string]*openAPIResponse, resolver *schemaReso
 	return resolvedResponse{"void", 200, "", true}
 }
 
+// resolveErrorType returns the TypeScript status map of the 4xx responses with
+// a JSON body (application/json or a +json type such as
+// application/problem+json), e.g. "{ 404: NotFoundError; 422: ValidationProblem }",
+// and their statuses in order, or "" if there are none. Status ranges such as
+// 4XX are left out: their errors keep an unknown body.
+func resolveErrorType(responses map[string]*openAPIResponse, resolver *schemaResolver) (string, []int) {
+	var statuses []int
+	for code := range responses {
+		status, err := strconv.Atoi(code)
+		if err == nil && status >= 400 && status < 500 {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Ints(statuses)
+
+	var entries []string
+	var documented []int
+	for _, status := range statuses {
+		resp := responses[strconv.Itoa(status)]
+		contentTypes := make([]string, 0, len(resp.Content))
+		for ct := range resp.Content {
+			if ct == "application/json" || strings.HasSuffix(ct, "+json") {
+				contentTypes = append(contentTypes, ct)
+			}
+		}
+		sort.Strings(contentTypes)
+		var types []string
+		seen := make(map[string]bool)
+		for _, ct := range contentTypes {
+			schema := resp.Content[ct].Schema
+			if len(schema) == 0 {
+				continue
+			}
+			tsType := resolver.schemaToTS(schema)
+			if !seen[tsType] {
+				seen[tsType] = true
+				types = append(types, tsType)
+			}
+		}
+		if len(types) == 0 {
+			continue
+		}
+		entries = append(entries, fmt.Sprintf("%d: %s", status, strings.Join(types, " | ")))
+		documented = append(documented, status)
+	}
+	if len(entries) == 0 {
+		return "", nil
+	}
+	return "{ " + strings.Join(entries, "; ") + " }", documented
+}
+
 // contentTypeToTSType maps a response content type to an appropriate TypeScript type.
 func contentTypeToTSType(contentType string, media openAPIMediaType, resolver *schemaResolver) string {
+	// Binary schemas (format: binary) are always downloaded as Blob,
+	// regardless of the declared content type (e.g. text/csv file downloads).
+	if contentType != "application/json" && contentType != "text/event-stream" &&
+		media.Schema != nil && resolver.schemaToTS(media.Schema) == "Blob" {
+		return "Blob"
+	}
 	switch {
 	case contentType == "application/json":
 		return resolver.schemaToTS(media.Schema)
@@ -717,13 +776,13 @@ func (r *schemaResolver) schemaToTS(raw json.RawMessage) string {
 		return r.compositeToTS(anyOfRaw, " | ")
 	}
 
-	// Handle oneOf
-	if oneOfRaw, ok := node["oneOf"]; ok {
+	// Handle oneOf/allOf, unless they only constrain declared properties
+	// (e.g. required sets of RequireOneOf rules)
+	_, hasProps := node["properties"]
+	if oneOfRaw, ok := node["oneOf"]; ok && !hasProps {
 		return r.compositeToTS(oneOfRaw, " | ")
 	}
-
-	// Handle allOf
-	if allOfRaw, ok := node["allOf"]; ok {
+	if allOfRaw, ok := node["allOf"]; ok && !hasProps {
 		return r.compositeToTS(allOfRaw, " & ")
 	}
 
@@ -889,6 +948,11 @@ func parseSchemaNode(raw json.RawMessage) (*SchemaNode, error) {
 
 	node := &SchemaNode{}
 
+	// deprecated is also kept on $ref properties, as a sibling of the reference
+	if v, ok := m["deprecated"]; ok {
+		json.Unmarshal(v, &node.Deprecated)
+	}
+
 	if v, ok := m["$ref"]; ok {
 		var ref string
 		json.Unmarshal(v, &ref)
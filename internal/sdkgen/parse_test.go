@@ -315,6 +315,50 @@ func TestResolveResponse_204NoContent(t *testing.T) {
 	}
 }
 
+func TestResolveErrorType(t *testing.T) {
+	responses := map[string]*openAPIResponse{
+		"201": {Description: "Created"},
+		"422": {
+			Description: "Validation failed",
+			Content: map[string]openAPIMediaType{
+				"application/problem+json": {
+					Schema: json.RawMessage(`{"$ref": "#/components/schemas/ValidationProblem"}`),
+				},
+			},
+		},
+		"404": {
+			Description: "Not Found",
+			Content: map[string]openAPIMediaType{
+				"application/json": {
+					Schema: json.RawMessage(`{"$ref": "#/components/schemas/NotFoundError"}`),
+				},
+			},
+		},
+		"409": {Description: "Conflict"},
+		"500": {
+			Description: "Internal Server Error",
+			Content: map[string]openAPIMediaType{
+				"application/json": {
+					Schema: json.RawMessage(`{"$ref": "#/components/schemas/ServerError"}`),
+				},
+			},
+		},
+	}
+	resolver := &schemaResolver{schemas: map[string]*SchemaNode{}}
+	resolver.initFingerprints()
+
+	got, statuses := resolveErrorType(responses, resolver)
+	if got != "{ 404: NotFoundError; 422: ValidationProblem }" {
+		t.Errorf("expected 4xx JSON bodies by status, got %q", got)
+	}
+	if len(statuses) != 2 || statuses[0] != 404 || statuses[1] != 422 {
+		t.Errorf("expected statuses [404 422], got %v", statuses)
+	}
+	if got, statuses := resolveErrorType(map[string]*openAPIResponse{"200": {Description: "OK"}}, resolver); got != "" || statuses != nil {
+		t.Errorf("expected no error type without 4xx responses, got %q %v", got, statuses)
+	}
+}
+
 func TestResolveResponse_Multiple2xx(t *testing.T) {
 	// When both 200 and 201 exist, 200 should be picked (priority order)
 	responses := map[string]*openAPIResponse{
@@ -371,6 +415,9 @@ func TestContentTypeToTSType_AllBranches(t *testing.T) {
 		{"application/xml", openAPIMediaType{Schema: json.RawMessage(`{"type": "string"}`)}, "string"},
 		// Unknown without schema
 		{"application/xml", openAPIMediaType{}, "Blob"},
+		// Binary schema overrides text content types (e.g. StreamableFile CSV download)
+		{"text/csv", openAPIMediaType{Schema: json.RawMessage(`{"type": "string", "format": "binary"}`)}, "Blob"},
+		{"application/zip", openAPIMediaType{Schema: json.RawMessage(`{"type": "string", "format": "binary"}`)}, "Blob"},
 	}
 	for _, tt := range tests {
 		got := contentTypeToTSType(tt.contentType, tt.media, resolver)
@@ -31,10 +31,12 @@ func SchemaToTS(node *SchemaNode, visited map[string]bool) string {
 	if len(node.AnyOf) > 0 {
 		return compositionToTS(node.AnyOf, " | ", visited)
 	}
-	if len(node.OneOf) > 0 {
+	// oneOf/allOf next to declared properties only constrain the object
+	// (e.g. required sets of RequireOneOf rules): type it by its properties.
+	if len(node.OneOf) > 0 && len(node.Properties) == 0 {
 		return compositionToTS(node.OneOf, " | ", visited)
 	}
-	if len(node.AllOf) > 0 {
+	if len(node.AllOf) > 0 && len(node.Properties) == 0 {
 		return compositionToTS(node.AllOf, " & ", visited)
 	}
 
@@ -173,8 +175,12 @@ func GenerateInterface(name string, node *SchemaNode, visited map[string]bool) s
 		if requiredSet[propName] {
 			opt = ""
 		}
-		if prop.Description != "" {
-			sb.WriteString(buildPropertyJSDoc(prop.Description))
+		doc := prop.Description
+		if prop.Deprecated {
+			doc = strings.TrimSpace(doc + "\n@deprecated")
+		}
+		if doc != "" {
+			sb.WriteString(buildPropertyJSDoc(doc))
 		}
 		fmt.Fprintf(&sb, "  %s%s: %s;\n", tsPropertyKey(propName), opt, tsType)
 	}
@@ -94,6 +94,26 @@ func TestSchemaToTS_Object(t *testing.T) {
 	}
 }
 
+func TestSchemaToTS_ObjectWithRequireOneOf(t *testing.T) {
+	// oneOf of required sets only constrains the declared properties
+	node := &SchemaNode{
+		Type: "object",
+		Properties: map[string]*SchemaNode{
+			"email": {Type: "string"},
+			"phone": {Type: "string"},
+		},
+		OneOf: []*SchemaNode{
+			{Required: []string{"email"}},
+			{Required: []string{"phone"}},
+		},
+	}
+	got := SchemaToTS(node, nil)
+	want := "{ email?: string; phone?: string }"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
 func TestSchemaToTS_Record(t *testing.T) {
 	node := &SchemaNode{
 		Type:                 "object",
@@ -255,6 +275,28 @@ func TestGenerateInterface_WithJSDoc(t *testing.T) {
 	}
 }
 
+func TestGenerateInterface_DeprecatedProperty(t *testing.T) {
+	node := &SchemaNode{
+		Type: "object",
+		Properties: map[string]*SchemaNode{
+			"nickname": {Type: "string", Deprecated: true},
+			"legacyId": {Type: "string", Description: "Identifier of the v1 API", Deprecated: true},
+			"name":     {Type: "string"},
+		},
+	}
+	got := GenerateInterface("User", node, nil)
+
+	if !contains(got, "  /** @deprecated */\n  nickname?: string;") {
+		t.Errorf("expected @deprecated JSDoc on nickname, got:\n%s", got)
+	}
+	if !contains(got, "  /**\n   * Identifier of the v1 API\n   * @deprecated\n   */\n  legacyId?: string;") {
+		t.Errorf("expected description and @deprecated JSDoc on legacyId, got:\n%s", got)
+	}
+	if contains(got, "*/\n  name?: string;") {
+		t.Errorf("name should not have JSDoc, got:\n%s", got)
+	}
+}
+
 func TestGenerateInterface_TypeAlias_WithJSDoc(t *testing.T) {
 	node := &SchemaNode{
 		Type:        "string",
@@ -35,6 +35,8 @@ type SDKMethod struct {
 	ResponseContentType string     // e.g., "application/json", "application/pdf", "text/event-stream"
 	SSEEventType        string     // TypeScript type for SSE event data (e.g., "OrderUpdate"), empty if untyped SSE
 	IsVoid              bool       // true if 204 or no response body
+	ErrorType           string     // TypeScript status map of the documented 4xx JSON bodies, empty if none
+	ErrorStatuses       []int      // statuses of ErrorType, e.g. [404, 422]
 	Summary             string
 	Description         string
 	Deprecated          bool
@@ -61,6 +63,7 @@ type SchemaNode struct {
 	Type        string // "string", "number", "integer", "boolean", "object", "array"
 	Format      string // "int32", "int64", "float", "double", "date-time", "uuid", etc.
 	Description string // OpenAPI description, used for JSDoc generation
+	Deprecated  bool   // OpenAPI deprecated, emitted as @deprecated JSDoc on properties
 
 	// Object properties
 	Properties map[string]*SchemaNode
@@ -4,12 +4,19 @@ export interface ValidationResult<T> {
   errors?: Array<{ path: string; expected: string; received: string }>;
 }
 
+export interface ValidateOptions {
+  /** Stop at the first error, like maxErrors: 1 (default: transforms.failFast). */
+  failFast?: boolean;
+  /** Stop once this many errors are collected (default: transforms.maxErrors, else all errors). */
+  maxErrors?: number;
+}
+
 // Marker functions — identity/no-op at runtime, replaced by tsgonest at compile time.
 // Code works without tsgonest compilation (just slower, no validation).
 export function is<T>(input: unknown): input is T {
   return true;
 }
-export function validate<T>(input: unknown): ValidationResult<T> {
+export function validate<T>(input: unknown, options?: ValidateOptions): ValidationResult<T> {
   return { success: true, data: input as T };
 }
 export function assert<T>(input: unknown): T {
@@ -21,3 +28,64 @@ export function stringify<T>(input: T): string {
 export function serialize<T>(input: T): string {
   return JSON.stringify(input);
 }
+
+// Exact-shape variants of is<T>() / validate<T>(): undeclared properties are
+// rejected (reported per path by validateEquals). No-op without compilation.
+export function equals<T>(input: unknown): input is T {
+  return true;
+}
+export function validateEquals<T>(input: unknown, options?: ValidateOptions): ValidationResult<T> {
+  return { success: true, data: input as T };
+}
+
+// validate<T>() that also awaits the ValidateAsync<typeof fn> validators declared
+// in T, once the synchronous checks pass. Always succeeds without compilation.
+export async function validateAsync<T>(input: unknown, options?: ValidateOptions): Promise<ValidationResult<T>> {
+  return { success: true, data: input as T };
+}
+
+// Sanitizers limited to the declared shape of T: prune<T>() deletes undeclared
+// properties in place, clone<T>() returns a deep copy without them.
+// Without compilation prune is a no-op and clone a structuredClone.
+export function prune<T>(input: T): void {}
+export function clone<T>(input: T): T {
+  return structuredClone(input);
+}
+
+// assert<T>() that also constructs instances of the exported classes declared in T
+// (replacing class-transformer's plainToInstance). Constructors are not invoked.
+// Without compilation the input is returned as-is, like assert.
+export function hydrate<T>(input: unknown): T {
+  return input as T;
+}
+
+export interface ParseOptions extends ValidateOptions {
+  /** Revive ISO strings/timestamps at Date fields and integer strings at bigint fields (default: true). */
+  coerce?: boolean;
+}
+
+// JSON.parse + validate<T>() in one call. Without compilation only the
+// JSON syntax is checked.
+export function parse<T>(input: string, options?: ParseOptions): ValidationResult<T> {
+  try {
+    return { success: true, data: JSON.parse(input) as T };
+  } catch (err) {
+    return { success: false, errors: [{ path: 'input', expected: 'valid JSON', received: String(err) }] };
+  }
+}
+export function assertParse<T>(input: string, options?: ParseOptions): T {
+  return JSON.parse(input) as T;
+}
+
+export interface RandomOptions {
+  /** Seed for reproducible output. Omit for a different value on each call. */
+  seed?: number;
+  /** Depth after which optional properties, nullable values and recursion stop expanding (default: 3). */
+  maxDepth?: number;
+}
+
+// Unlike the validation markers, random<T>() has no meaningful fallback: the
+// generator is derived from T at compile time.
+export function random<T>(options?: RandomOptions): T {
+  throw new Error('random<T>() requires compilation with tsgonest');
+}
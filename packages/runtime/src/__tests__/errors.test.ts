@@ -0,0 +1,35 @@
+import { describe, it, expect } from "vitest";
+import { localizeErrors, type ValidationErrorDetail } from "../errors";
+
+const errors: ValidationErrorDetail[] = [
+  { path: "input.name", expected: "minLength 3", received: "length 1", code: "minLength", params: { min: 3 } },
+  { path: "input.age", expected: "number", received: "string" },
+  { path: "input.nick", expected: "Too short!", received: "length 1", code: "minLength", params: { min: 3 }, message: "Too short!" },
+];
+
+const catalogs = {
+  de: { minLength: "{path}: mindestens {min} Zeichen", type: "muss {expected} sein" },
+  "pt-BR": { minLength: "no mínimo {min} caracteres" },
+};
+
+describe("localizeErrors", () => {
+  it("resolves templates of the first matching Accept-Language tag", () => {
+    const out = localizeErrors(errors, catalogs, "fr-FR, de-AT;q=0.8");
+    expect(out.map((e) => e.message)).toEqual([
+      "input.name: mindestens 3 Zeichen",
+      "muss number sein",
+      "Too short!",
+    ]);
+    expect(out[1].code).toBe("type");
+  });
+
+  it("falls back to the default locale, then to English", () => {
+    const out = localizeErrors(errors, catalogs, "pt-br", "de");
+    expect(out.map((e) => e.message)).toEqual([
+      "no mínimo 3 caracteres",
+      "muss number sein",
+      "Too short!",
+    ]);
+    expect(localizeErrors(errors, catalogs, undefined)[0].message).toBe("expected minLength 3, received length 1");
+  });
+});
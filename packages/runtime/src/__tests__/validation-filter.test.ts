@@ -0,0 +1,80 @@
+import { describe, it, expect } from "vitest";
+import "reflect-metadata";
+import { TsgonestValidationError } from "../errors";
+import { TsgonestValidationFilter, validationErrorResponse } from "../validation-filter";
+
+const errors = [{ path: "input.name", expected: "string", received: "number" }];
+
+function mockHost(url: string, headers: Record<string, string> = {}) {
+  const sent: { status?: number; headers: Record<string, string>; body?: string } = { headers: {} };
+  const response = {
+    status(code: number) { sent.status = code; return response; },
+    header(name: string, value: string) { sent.headers[name] = value; return response; },
+    send(body: string) { sent.body = body; return response; },
+  };
+  const host = { switchToHttp: () => ({ getResponse: () => response, getRequest: () => ({ url, headers }) }) };
+  return { host, sent };
+}
+
+describe("validationErrorResponse", () => {
+  it("defaults to a 400 json body", () => {
+    expect(validationErrorResponse(errors)).toEqual({
+      status: 400,
+      contentType: "application/json; charset=utf-8",
+      body: { statusCode: 400, message: "Validation failed", errors },
+    });
+  });
+
+  it("builds an RFC 9457 problem", () => {
+    const res = validationErrorResponse(errors, { status: 422, format: "problem+json" }, "/users");
+    expect(res.contentType).toBe("application/problem+json");
+    expect(res.body).toEqual({
+      type: "about:blank",
+      title: "Validation failed",
+      status: 422,
+      detail: "1 validation error(s)",
+      instance: "/users",
+      errors,
+    });
+  });
+});
+
+describe("TsgonestValidationFilter", () => {
+  it("sends the configured response", () => {
+    const { host, sent } = mockHost("/users");
+    new TsgonestValidationFilter({ status: 422, format: "problem+json", type: "https://example.com/validation" })
+      .catch(new TsgonestValidationError(errors), host);
+    expect(sent.status).toBe(422);
+    expect(sent.headers["Content-Type"]).toBe("application/problem+json");
+    expect(JSON.parse(sent.body!)).toMatchObject({ type: "https://example.com/validation", status: 422, errors });
+    expect(JSON.parse(sent.body!).errors[0]).toMatchObject({ code: "type", message: "expected string, received number" });
+  });
+
+  it("localizes errors for the request's Accept-Language header", () => {
+    const { host, sent } = mockHost("/users", { "accept-language": "de-AT" });
+    const exception = new TsgonestValidationError(errors);
+    let locale: string | null | undefined;
+    exception.localize = (l) => {
+      locale = l;
+      return errors.map((err) => ({ ...err, code: "type" as const, message: "Ungültiger Typ" }));
+    };
+    new TsgonestValidationFilter().catch(exception, host);
+    expect(locale).toBe("de-AT");
+    expect(JSON.parse(sent.body!).errors[0].message).toBe("Ungültiger Typ");
+  });
+
+  it("catches validation errors thrown by generated code", () => {
+    const catches = Reflect.getMetadata("__filterCatchExceptions__", TsgonestValidationFilter);
+    expect(catches).toEqual([TsgonestValidationError]);
+
+    class GeneratedError extends Error {
+      errors = errors;
+      constructor() {
+        super("Validation failed: 1 error(s)");
+        this.name = "TsgonestValidationError";
+      }
+    }
+    expect(new GeneratedError() instanceof TsgonestValidationError).toBe(true);
+    expect(new Error("boom") instanceof TsgonestValidationError).toBe(false);
+  });
+});
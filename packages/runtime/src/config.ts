@@ -1,3 +1,6 @@
+import type { ValidationErrorCode } from './errors';
+import type { ValidationErrorOptions } from './validation-filter';
+
 /** Contact info for the OpenAPI document. */
 export interface OpenAPIContact {
   name?: string;
@@ -82,6 +85,126 @@ export interface TsgonestConfig {
     include?: string[];
     /** Type name patterns to exclude from codegen (e.g., "Legacy*", "SomeInternalDto"). */
     exclude?: string[];
+    /**
+     * Construct `@Body()` class DTOs (and nested class properties) as instances of
+     * their class after validation, replacing class-transformer's `plainToInstance`.
+     * Constructors are not invoked. Default: false.
+     */
+    hydrate?: boolean;
+    /**
+     * Custom string formats by name, checked by `Format<"name">` and `@format name`
+     * like the built-in formats and emitted as `format` in OpenAPI. A format is a
+     * regular expression (a string, or `{ pattern, flags }`) or a predicate
+     * `(value: string) => boolean` exported as `export` by `module` (relative to this file).
+     *
+     * @example
+     *   formats: {
+     *     slug: "^[a-z0-9]+(?:-[a-z0-9]+)*$",
+     *     e164: { pattern: "^\\+[1-9][0-9]{1,14}$" },
+     *     iban: { module: "./src/validators/iban.ts", export: "isIban" },
+     *   }
+     */
+    formats?: Record<
+      string,
+      string | { pattern: string; flags?: string } | { module: string; export: string }
+    >;
+    /**
+     * Message catalogs by locale tag: templates keyed by validation error code
+     * (`minLength`, `format`, `required`, `type`, ...), with `{param}` placeholders
+     * filled from the error params and `{path}`, `{expected}`, `{received}`.
+     * Errors are localized with `err.localize(locale)` on thrown validation
+     * errors, or `localizeErrors()` from `@tsgonest/runtime`.
+     *
+     * @example
+     *   locales: {
+     *     de: { minLength: "Mindestens {min} Zeichen", required: "Pflichtfeld" },
+     *     fr: { minLength: "Au moins {min} caractères" },
+     *   }
+     */
+    locales?: Record<string, Partial<Record<ValidationErrorCode, string>>>;
+    /**
+     * Locale whose catalog is used when none of the requested locales has one,
+     * and for codes missing from the matched catalog. Default: the built-in
+     * English messages.
+     */
+    defaultLocale?: string;
+    /**
+     * HTTP response of failed `@Body()`, `@Query()`, `@Param()` and `@Headers()`
+     * validation: the status (400 or 422, any 4xx) and the body format, `json`
+     * or RFC 9457 `problem+json`. Controllers get a `TsgonestValidationFilter`
+     * and the response is documented on every validated route in OpenAPI.
+     * When unset, validation errors are left to the application's exception filters.
+     *
+     * @example
+     *   validationError: { status: 422, format: "problem+json" }
+     */
+    validationError?: ValidationErrorOptions;
+    /**
+     * Stop validate functions at the first error, like `maxErrors: 1`.
+     * Default: false.
+     */
+    failFast?: boolean;
+    /**
+     * Stop validate functions once this many errors are collected: loops and
+     * recursive checks exit early instead of truncating the result. Overridable
+     * per call with `validate<T>(input, { maxErrors, failFast })`.
+     * Default: 0 (all errors).
+     */
+    maxErrors?: number;
+    /**
+     * Structural limits enforced by generated `is`, `validate` and `assert`
+     * functions on top of the declared constraints, to bound the work done on
+     * hostile payloads. Arrays and strings use them unless they declare
+     * `MaxItems` / `MaxLength` (on the property or the type); OpenAPI documents them as implicit `maxItems`,
+     * `maxLength` and `maxProperties`. Unset fields are unlimited.
+     *
+     * @example
+     *   limits: { maxDepth: 32, maxArrayLength: 10_000, maxStringLength: 100_000, maxProperties: 1_000 }
+     */
+    limits?: {
+      /** Nesting depth of recursive types (the root is depth 1). */
+      maxDepth?: number;
+      /** Array length, unless the array declares `MaxItems`. */
+      maxArrayLength?: number;
+      /** String length, unless the string declares `MaxLength`. */
+      maxStringLength?: number;
+      /** Key count of objects with an index signature (`Record<string, T>`). */
+      maxProperties?: number;
+    };
+    /**
+     * String serialized in place of `Sensitive` / `@sensitive` properties by
+     * generated `serialize` functions. Default: unset (the value is serialized).
+     *
+     * @example
+     *   sensitiveMask: "***"
+     */
+    sensitiveMask?: string;
+    /**
+     * Handling of read-only properties (`readonly` / `@readOnly`) in controller
+     * request bodies: `"strip"` deletes them, `"reject"` fails validation with a
+     * `readOnly` error when they are sent. Default: unset (they are validated
+     * like other properties).
+     */
+    readOnly?: 'strip' | 'reject';
+    /**
+     * Function called with the path of each deprecated property (`@deprecated`)
+     * sent in a controller request body, e.g. to log clients still using it.
+     * The module path is relative to the config file.
+     *
+     * @example
+     *   onDeprecated: { module: "./src/deprecation.ts", export: "onDeprecated" }
+     */
+    onDeprecated?: { module: string; export: string };
+    /**
+     * Naming policy of property names in JSON bodies, derived from the
+     * TypeScript names: request bodies are read under it, serializers write it,
+     * and OpenAPI documents it. `JsonName<"name">` overrides it per property.
+     * Default: unset (JSON names are the TypeScript names).
+     *
+     * @example
+     *   jsonNaming: "snake_case" // firstName ⇄ first_name
+     */
+    jsonNaming?: 'camelCase' | 'PascalCase' | 'snake_case' | 'kebab-case';
   };
 
   /** OpenAPI document generation settings. Omit or set output to "" to disable. */
@@ -125,6 +248,32 @@ export interface TsgonestConfig {
     versioning?: VersioningConfig;
   };
 
+  /** Schema naming settings shared by companions, OpenAPI and the SDK. */
+  schemaNames?: {
+    /**
+     * How same-named types declared in different modules are disambiguated.
+     * - "path" (default): prefix the distinguishing directory segments (e.g., BillingUserDto)
+     * - "namespace": prefix the enclosing namespace, falling back to "path"
+     *
+     * An explicit `@schemaName` JSDoc tag on a declaration always takes precedence.
+     */
+    strategy?: 'path' | 'namespace';
+    /**
+     * Template for generic instantiation names, using `{base}` and `{args}` placeholders.
+     * - "{base}_{args}" (default): Page<UserDto> → Page_UserDto
+     * - "{base}Of{args}": Page<UserDto> → PageOfUserDto
+     * - "{args}{base}": Page<UserDto> → UserDtoPage
+     *
+     * Text around the placeholders must be identifier characters (letters, digits, `_`, `$`).
+     *
+     * A `@schemaName` tag on a type alias (`type UserPage = Page<UserDto>`) names that
+     * instantiation explicitly.
+     */
+    generics?: string;
+    /** Separator between multiple type argument names in `{args}` (default: "_"), made of identifier characters. */
+    genericArgSeparator?: string;
+  };
+
   /** Entry point name without extension (default: "main"). */
   entryFile?: string;
   /** Source root directory (default: "src"). */
@@ -1,3 +1,36 @@
+/**
+ * Code of the check that failed, stable across releases. Type mismatches have
+ * the code `type`.
+ */
+export type ValidationErrorCode =
+  | 'type'
+  | 'required'
+  | 'unknownProperty'
+  | 'minimum'
+  | 'maximum'
+  | 'exclusiveMinimum'
+  | 'exclusiveMaximum'
+  | 'multipleOf'
+  | 'minLength'
+  | 'maxLength'
+  | 'pattern'
+  | 'format'
+  | 'startsWith'
+  | 'endsWith'
+  | 'includes'
+  | 'uppercase'
+  | 'lowercase'
+  | 'minItems'
+  | 'maxItems'
+  | 'uniqueItems'
+  | 'maxProperties'
+  | 'maxDepth'
+  | 'validate'
+  | 'validateAsync'
+  | 'requireOneOf'
+  | 'dependentRequired'
+  | 'readOnly';
+
 /**
  * Validation error details for a single field.
  */
@@ -8,11 +41,33 @@ export interface ValidationErrorDetail {
   expected: string;
   /** The received type or value description. */
   received: string;
+  /** The code of the failed check (`type` for type mismatches). */
+  code?: ValidationErrorCode;
+  /** The parameters of the failed check (e.g., `{ min: 3 }` for minLength). */
+  params?: Record<string, string | number>;
+  /** The custom message of the check (`Error<M>`), never replaced by catalogs. */
+  message?: string;
+  /** The discriminant value that selected the failed branch of a discriminated union. */
+  discriminant?: { property: string; value: string | number | boolean };
+}
+
+/**
+ * Validation error details with a code and a message resolved by {@link localizeErrors}.
+ */
+export interface LocalizedValidationErrorDetail extends ValidationErrorDetail {
+  code: ValidationErrorCode;
+  message: string;
 }
 
+/** Message catalogs by locale tag: templates by error code. */
+export type MessageCatalogs = Record<string, Partial<Record<ValidationErrorCode, string>>>;
+
 /**
  * Error thrown when validation fails.
  * Contains structured error details for each invalid field.
+ *
+ * `instanceof TsgonestValidationError` also matches the errors thrown by
+ * generated companion functions, which share its name but not its class.
  */
 export class TsgonestValidationError extends Error {
   public readonly errors: ValidationErrorDetail[];
@@ -25,4 +80,71 @@ export class TsgonestValidationError extends Error {
     this.name = 'TsgonestValidationError';
     this.errors = errors;
   }
+
+  /**
+   * Returns the errors with their code and an English message. Errors thrown
+   * by generated assert functions resolve messages from the configured locale
+   * catalogs instead.
+   */
+  localize(locale?: string | null): LocalizedValidationErrorDetail[] {
+    return localizeErrors(this.errors, {}, locale);
+  }
+
+  static [Symbol.hasInstance](value: unknown): boolean {
+    if (this !== TsgonestValidationError) {
+      return Function.prototype[Symbol.hasInstance].call(this, value);
+    }
+    return (
+      value instanceof Error &&
+      value.name === 'TsgonestValidationError' &&
+      Array.isArray((value as { errors?: unknown }).errors)
+    );
+  }
+}
+
+/**
+ * Resolves a message for each error from the catalogs, the same way as
+ * `localize()` on the errors thrown by generated assert functions.
+ *
+ * `locale` is a tag or an Accept-Language header value: its tags are tried in
+ * order, each then without its region (`de-AT` → `de`). Codes missing from the
+ * matched catalog fall back to the `defaultLocale` catalog, then to the English
+ * message. Templates replace `{name}` with the error params, then with
+ * `path`, `expected` and `received`. Errors with a custom message keep it.
+ */
+export function localizeErrors(
+  errors: readonly ValidationErrorDetail[],
+  catalogs: MessageCatalogs,
+  locale?: string | null,
+  defaultLocale?: string,
+): LocalizedValidationErrorDetail[] {
+  const byTag = new Map<string, Partial<Record<ValidationErrorCode, string>>>();
+  for (const tag of Object.keys(catalogs)) {
+    byTag.set(tag.toLowerCase(), catalogs[tag]);
+  }
+  let catalog: Partial<Record<ValidationErrorCode, string>> | undefined;
+  for (const part of (locale ?? '').toLowerCase().split(',')) {
+    const tag = part.split(';')[0].trim();
+    if (tag === '') continue;
+    catalog = byTag.get(tag) ?? byTag.get(tag.split('-')[0]);
+    if (catalog !== undefined) break;
+  }
+  const fallback = defaultLocale !== undefined ? byTag.get(defaultLocale.toLowerCase()) : undefined;
+
+  return errors.map((err) => {
+    const code = err.code ?? 'type';
+    let message = err.message;
+    const template = catalog?.[code] ?? fallback?.[code];
+    if (message === undefined && template !== undefined) {
+      message = template.replace(/\{(\w+)\}/g, (m, k: string) => {
+        if (err.params && Object.prototype.hasOwnProperty.call(err.params, k)) return String(err.params[k]);
+        if (k === 'path' || k === 'expected' || k === 'received') return err[k];
+        return m;
+      });
+    }
+    if (message === undefined) {
+      message = `expected ${err.expected}, received ${err.received}`;
+    }
+    return { ...err, code, message };
+  });
 }
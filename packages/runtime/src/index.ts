@@ -3,8 +3,15 @@ export { defineConfig } from './config';
 export type { TsgonestConfig } from './config';
 
 // Errors
-export { TsgonestValidationError } from './errors';
-export type { ValidationErrorDetail } from './errors';
+export { TsgonestValidationError, localizeErrors } from './errors';
+export type {
+  ValidationErrorDetail,
+  ValidationErrorCode,
+  LocalizedValidationErrorDetail,
+  MessageCatalogs,
+} from './errors';
+export { TsgonestValidationFilter, validationErrorResponse } from './validation-filter';
+export type { ValidationErrorOptions, ValidationErrorBody, ValidationProblem } from './validation-filter';
 
 // FormData
 export { FormDataBody, TSGONEST_FORM_DATA_FACTORY } from './form-data-body';
@@ -23,6 +23,15 @@ export interface ReturnsOptions {
    * When set, takes precedence over `@HttpCode()`.
    */
   status?: number;
+
+  /**
+   * Serialization view of the response: properties tagged `Groups<G>` are
+   * only serialized, and documented, in the views named by their groups.
+   * Same as the `@view` JSDoc tag, which it takes precedence over.
+   *
+   * @example 'admin'
+   */
+  view?: string;
 }
 
 /**
@@ -0,0 +1,95 @@
+import 'reflect-metadata';
+import { TsgonestValidationError } from './errors';
+import type { ValidationErrorDetail } from './errors';
+
+/**
+ * HTTP response of failed request validation (`transforms.validationError`).
+ */
+export interface ValidationErrorOptions {
+  /** 4xx status code (default: 400). */
+  status?: number;
+  /**
+   * Body format:
+   * - "json" (default): `{ statusCode, message, errors }` as `application/json`
+   * - "problem+json": RFC 9457 `{ type, title, status, detail, errors }` as `application/problem+json`
+   */
+  format?: 'json' | 'problem+json';
+  /** problem+json type URI (default: "about:blank"). */
+  type?: string;
+  /** problem+json title (default: "Validation failed"). */
+  title?: string;
+}
+
+/** Body of a failed validation response in the "json" format. */
+export interface ValidationErrorBody {
+  statusCode: number;
+  message: string;
+  errors: ValidationErrorDetail[];
+}
+
+/** Body of a failed validation response in the "problem+json" format (RFC 9457). */
+export interface ValidationProblem {
+  type: string;
+  title: string;
+  status: number;
+  detail: string;
+  instance?: string;
+  errors: ValidationErrorDetail[];
+}
+
+/**
+ * Builds the HTTP response of failed validation: status, content type and body.
+ * `instance` is the request path, set as the problem's `instance`.
+ */
+export function validationErrorResponse(
+  errors: ValidationErrorDetail[],
+  options: ValidationErrorOptions = {},
+  instance?: string,
+): { status: number; contentType: string; body: ValidationErrorBody | ValidationProblem } {
+  const status = options.status ?? 400;
+  if (options.format === 'problem+json') {
+    const body: ValidationProblem = {
+      type: options.type ?? 'about:blank',
+      title: options.title ?? 'Validation failed',
+      status,
+      detail: `${errors.length} validation error(s)`,
+      errors,
+    };
+    if (instance !== undefined) {
+      body.instance = instance;
+    }
+    return { status, contentType: 'application/problem+json', body };
+  }
+  return {
+    status,
+    contentType: 'application/json; charset=utf-8',
+    body: { statusCode: status, message: 'Validation failed', errors },
+  };
+}
+
+/**
+ * NestJS exception filter that sends validation errors thrown by injected
+ * request validation with the configured status and body format.
+ *
+ * Auto-injected by `tsgonest build` on controller classes when
+ * `transforms.validationError` is configured. Errors are localized for the
+ * request's Accept-Language header. Other exceptions are left to the
+ * application's filters.
+ */
+export class TsgonestValidationFilter {
+  constructor(private readonly options: ValidationErrorOptions = {}) {}
+
+  catch(exception: TsgonestValidationError, host: any): void {
+    const http = host.switchToHttp();
+    const response = http.getResponse();
+    const request = http.getRequest();
+    const errors = exception.localize(request?.headers?.['accept-language']);
+    const { status, contentType, body } = validationErrorResponse(errors, this.options, request?.url);
+    response.status(status);
+    response.header('Content-Type', contentType);
+    response.send(JSON.stringify(body));
+  }
+}
+
+// Equivalent of @Catch(TsgonestValidationError), without a runtime dependency on @nestjs/common.
+Reflect.defineMetadata('__filterCatchExceptions__', [TsgonestValidationError], TsgonestValidationFilter);
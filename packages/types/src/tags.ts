@@ -64,13 +64,23 @@ export type FormatValue =
   | "cidrv4" | "cidrv6" | "emoji";
 
 /**
- * Validate a string matches a specific format.
+ * Name of a custom format registered in the tsgonest config (`transforms.formats`).
+ * Accepts any string while keeping autocompletion of the built-in formats.
+ */
+export type CustomFormatValue = string & {};
+
+/**
+ * Validate a string matches a specific format, built-in or registered in the
+ * tsgonest config (`transforms.formats`).
  *
  * @example
  *   Format<"email">
  *   Format<{type: "email", error: "Must be a valid email"}>
+ *   Format<"iban">  // with transforms.formats.iban in tsgonest.config.ts
  */
-export type Format<F extends FormatValue | { type: FormatValue; error?: string }> =
+export type Format<
+  F extends FormatValue | CustomFormatValue | { type: FormatValue | CustomFormatValue; error?: string }
+> =
   F extends { type: infer V; error: infer E extends string }
     ? { readonly __tsgonest_format?: V; readonly __tsgonest_format_error?: E }
     : { readonly __tsgonest_format?: F extends { type: infer V } ? V : F };
@@ -265,6 +275,25 @@ export type ToLowerCase = { readonly __tsgonest_transform_toLowerCase?: true };
 /** Convert to uppercase before validation. */
 export type ToUpperCase = { readonly __tsgonest_transform_toUpperCase?: true };
 
+/**
+ * Transform the value with a custom function before validation:
+ * `(value: unknown) => T`. tsgonest resolves the function's source file and
+ * emits an import + call in the generated validator. Several Transform tags
+ * are applied in order, after the built-in transforms. The function is not
+ * called when the property is absent, and should return values it cannot
+ * handle unchanged so that validation reports them.
+ *
+ * @example
+ *   import { normalizePhone } from "./transforms/phone";
+ *   import { stripHtml } from "./transforms/html";
+ *
+ *   interface ContactDto {
+ *     phone: string & Trim & Transform<typeof normalizePhone> & Pattern<"^\\+[0-9]+$">;
+ *     bio: string & Transform<typeof stripHtml> & MaxLength<500>;
+ *   }
+ */
+export type Transform<F extends (value: any) => unknown> = { readonly __tsgonest_transform?: F };
+
 // ═══════════════════════════════════════════════════════════════════════════════
 // Coercion
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -280,6 +309,51 @@ export type ToUpperCase = { readonly __tsgonest_transform_toUpperCase?: true };
  */
 export type Coerce = { readonly __tsgonest_coerce?: true };
 
+// ═══════════════════════════════════════════════════════════════════════════════
+// Sensitive values
+// ═══════════════════════════════════════════════════════════════════════════════
+
+/**
+ * Mark a value as sensitive (passwords, tokens, card numbers).
+ * Validation errors report only its received type, never the value; OpenAPI
+ * marks it `x-sensitive`; serializers replace it with
+ * `transforms.sensitiveMask` when configured.
+ *
+ * @example
+ *   password: string & MinLength<8> & Sensitive
+ *   cardNumber: string & Pattern<"^[0-9]{16}$"> & Sensitive
+ */
+export type Sensitive = { readonly __tsgonest_sensitive?: true };
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// JSON names
+// ═══════════════════════════════════════════════════════════════════════════════
+
+/**
+ * Name of the property in JSON bodies, overriding `transforms.jsonNaming`.
+ * Request bodies are read under this name and validated values are renamed to
+ * the TypeScript name; serializers write it and OpenAPI documents it.
+ *
+ * @example
+ *   firstName: string & JsonName<"first_name">
+ */
+export type JsonName<N extends string> = { readonly __tsgonest_jsonName?: N };
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Serialization groups
+// ═══════════════════════════════════════════════════════════════════════════════
+
+/**
+ * Only serialize the property in the views named by G, selected per route with
+ * `@view admin` or `@Returns<T>({ view: 'admin' })`. Routes without a view
+ * omit it. Validation is unaffected. JSDoc equivalent: `@groups admin`.
+ *
+ * @example
+ *   email: string & Groups<"admin">
+ *   internalNotes: string & Groups<"admin" | "support">
+ */
+export type Groups<G extends string> = { readonly __tsgonest_groups?: G };
+
 // ═══════════════════════════════════════════════════════════════════════════════
 // Custom Validators (function reference)
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -289,6 +363,10 @@ export type Coerce = { readonly __tsgonest_coerce?: true };
  * `(value: T) => boolean`. tsgonest resolves the function's source file
  * and emits an import + call in the generated validator.
  *
+ * Intersected with an object type, the predicate receives the whole object
+ * and runs after its properties were validated (cross-field rules).
+ * JSDoc equivalent on the type declaration: `@validate isValidPeriod`.
+ *
  * @example
  *   import { isValidCard } from "./validators/credit-card";
  *
@@ -296,6 +374,9 @@ export type Coerce = { readonly __tsgonest_coerce?: true };
  *     card: string & Validate<typeof isValidCard>;
  *     card: string & Validate<{fn: typeof isValidCard, error: "Invalid card"}>;
  *   }
+ *
+ *   type PeriodDto = { startDate: string; endDate: string }
+ *     & Validate<{fn: typeof isValidPeriod, error: "endDate must be after startDate"}>;
  */
 export type Validate<
   F extends ((...args: any[]) => boolean) | { fn: (...args: any[]) => boolean; error?: string }
@@ -303,6 +384,59 @@ export type Validate<
     ? { readonly __tsgonest_validate?: Fn; readonly __tsgonest_validate_error?: E }
     : { readonly __tsgonest_validate?: F extends { fn: infer Fn } ? Fn : F };
 
+/**
+ * Validate using an async custom function (e.g. a database lookup):
+ * `(value: T) => Promise<boolean>`. Ignored by is/validate/assert; it runs in
+ * validateAsync<T>() and in controllers, which await it before the handler runs,
+ * once the synchronous checks have passed. Failures are reported with the other
+ * validation errors.
+ *
+ * @example
+ *   import { isUsernameFree } from "./validators/users";
+ *
+ *   interface SignupDto {
+ *     username: string & ValidateAsync<typeof isUsernameFree>;
+ *     username: string & ValidateAsync<{fn: typeof isUsernameFree, error: "Username is taken"}>;
+ *   }
+ */
+export type ValidateAsync<
+  F extends ((...args: any[]) => Promise<boolean>) | { fn: (...args: any[]) => Promise<boolean>; error?: string }
+> = F extends { fn: infer Fn; error: infer E extends string }
+    ? { readonly __tsgonest_validateAsync?: Fn; readonly __tsgonest_validateAsync_error?: E }
+    : { readonly __tsgonest_validateAsync?: F extends { fn: infer Fn } ? Fn : F };
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// Object Rules (intersected with an object type)
+// ═══════════════════════════════════════════════════════════════════════════════
+
+/**
+ * Exactly one of the listed properties must be present (not undefined).
+ * Emitted as `oneOf` of `required` sets in OpenAPI.
+ * JSDoc equivalent on the type declaration: `@requireOneOf email phone`.
+ *
+ * @example
+ *   type ContactDto = { email?: string; phone?: string } & RequireOneOf<["email", "phone"]>;
+ *   RequireOneOf<{keys: ["email", "phone"], error: "Provide an email or a phone"}>
+ */
+export type RequireOneOf<
+  K extends readonly string[] | { keys: readonly string[]; error?: string }
+> = K extends { keys: infer Ks; error: infer E extends string }
+    ? { readonly __tsgonest_requireOneOf?: Ks; readonly __tsgonest_requireOneOf_error?: E }
+    : { readonly __tsgonest_requireOneOf?: K extends { keys: infer Ks } ? Ks : K };
+
+/**
+ * Properties required whenever another property is present.
+ * Emitted as `dependentRequired` in OpenAPI.
+ * JSDoc equivalent on the type declaration: `@dependentRequired endDate startDate`.
+ *
+ * @example
+ *   type RangeDto = { startDate?: string; endDate?: string }
+ *     & DependentRequired<{ endDate: ["startDate"] }>;
+ */
+export type DependentRequired<D extends Record<string, readonly string[]>> = {
+  readonly __tsgonest_dependentRequired?: D;
+};
+
 // ═══════════════════════════════════════════════════════════════════════════════
 // Meta Types
 // ═══════════════════════════════════════════════════════════════════════════════
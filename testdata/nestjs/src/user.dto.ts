@@ -32,3 +32,17 @@ export interface ListQuery {
   limit?: number;
   search?: string;
 }
+
+export interface PageFilter {
+  cursor: number;
+  /** @default 20 */
+  limit?: number;
+}
+
+export interface NameFilter {
+  name: string;
+}
+
+export interface SearchDto {
+  filter: PageFilter | NameFilter;
+}